@@ -0,0 +1,211 @@
+package goify
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"time"
+)
+
+type Priority int
+
+const (
+	PriorityLow Priority = iota - 1
+	PriorityNormal
+	PriorityHigh
+)
+
+func (p Priority) String() string {
+	switch p {
+	case PriorityLow:
+		return "low"
+	case PriorityNormal:
+		return "normal"
+	case PriorityHigh:
+		return "high"
+	}
+	return fmt.Sprintf("priority(%d)", int(p))
+}
+
+type PriorityRule struct {
+	Priority   Priority
+	PathPrefix string
+	Methods    []string
+	Match      func(*Context) bool
+}
+
+type LaneConfig struct {
+	MaxConcurrent int
+	MaxQueue      int
+	QueueTimeout  time.Duration
+}
+
+type AdmissionConfig struct {
+	Rules      []PriorityRule
+	Default    Priority
+	Lanes      map[Priority]LaneConfig
+	RetryAfter time.Duration
+}
+
+type LaneStats struct {
+	InFlight int64 `json:"in_flight"`
+	Queued   int64 `json:"queued"`
+	Rejected int64 `json:"rejected"`
+}
+
+type admissionLane struct {
+	config   LaneConfig
+	slots    chan struct{}
+	inFlight int64
+	queued   int64
+	rejected int64
+}
+
+type AdmissionController struct {
+	config AdmissionConfig
+	lanes  map[Priority]*admissionLane
+}
+
+func DefaultAdmissionConfig() AdmissionConfig {
+	return AdmissionConfig{
+		Default: PriorityNormal,
+		Lanes: map[Priority]LaneConfig{
+			PriorityHigh:   {MaxConcurrent: 50, MaxQueue: 100, QueueTimeout: 5 * time.Second},
+			PriorityNormal: {MaxConcurrent: 100, MaxQueue: 200, QueueTimeout: 2 * time.Second},
+			PriorityLow:    {MaxConcurrent: 10, MaxQueue: 20, QueueTimeout: time.Second},
+		},
+		RetryAfter: time.Second,
+	}
+}
+
+func NewAdmissionController(config AdmissionConfig) *AdmissionController {
+	if config.Lanes == nil {
+		config.Lanes = DefaultAdmissionConfig().Lanes
+	}
+	if config.RetryAfter <= 0 {
+		config.RetryAfter = time.Second
+	}
+
+	ac := &AdmissionController{
+		config: config,
+		lanes:  make(map[Priority]*admissionLane),
+	}
+
+	for priority, laneConfig := range config.Lanes {
+		lane := &admissionLane{config: laneConfig}
+		if laneConfig.MaxConcurrent > 0 {
+			lane.slots = make(chan struct{}, laneConfig.MaxConcurrent)
+		}
+		ac.lanes[priority] = lane
+	}
+
+	return ac
+}
+
+func (ac *AdmissionController) Classify(c *Context) Priority {
+	for _, rule := range ac.config.Rules {
+		if rule.matches(c) {
+			return rule.Priority
+		}
+	}
+	return ac.config.Default
+}
+
+func (rule PriorityRule) matches(c *Context) bool {
+	if rule.PathPrefix != "" && !strings.HasPrefix(c.Request.URL.Path, rule.PathPrefix) {
+		return false
+	}
+
+	if len(rule.Methods) > 0 {
+		found := false
+		for _, method := range rule.Methods {
+			if strings.EqualFold(method, c.Request.Method) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+
+	if rule.Match != nil && !rule.Match(c) {
+		return false
+	}
+
+	return true
+}
+
+func (ac *AdmissionController) Stats() map[Priority]LaneStats {
+	stats := make(map[Priority]LaneStats, len(ac.lanes))
+	for priority, lane := range ac.lanes {
+		stats[priority] = LaneStats{
+			InFlight: atomic.LoadInt64(&lane.inFlight),
+			Queued:   atomic.LoadInt64(&lane.queued),
+			Rejected: atomic.LoadInt64(&lane.rejected),
+		}
+	}
+	return stats
+}
+
+func (ac *AdmissionController) Middleware() MiddlewareFunc {
+	return func(c *Context, next func()) {
+		priority := ac.Classify(c)
+		c.Set("priority", priority)
+
+		lane, exists := ac.lanes[priority]
+		if !exists || lane.slots == nil {
+			next()
+			return
+		}
+
+		if !lane.acquire(c) {
+			atomic.AddInt64(&lane.rejected, 1)
+			c.SetHeader("Retry-After", strconv.Itoa(int((ac.config.RetryAfter+time.Second-1)/time.Second)))
+			c.SendError(http.StatusServiceUnavailable, "Server is busy", H{"priority": priority.String()})
+			return
+		}
+		defer lane.release()
+
+		next()
+	}
+}
+
+func (lane *admissionLane) acquire(c *Context) bool {
+	select {
+	case lane.slots <- struct{}{}:
+		atomic.AddInt64(&lane.inFlight, 1)
+		return true
+	default:
+	}
+
+	if atomic.AddInt64(&lane.queued, 1) > int64(lane.config.MaxQueue) {
+		atomic.AddInt64(&lane.queued, -1)
+		return false
+	}
+	defer atomic.AddInt64(&lane.queued, -1)
+
+	var timeout <-chan time.Time
+	if lane.config.QueueTimeout > 0 {
+		timer := time.NewTimer(lane.config.QueueTimeout)
+		defer timer.Stop()
+		timeout = timer.C
+	}
+
+	select {
+	case lane.slots <- struct{}{}:
+		atomic.AddInt64(&lane.inFlight, 1)
+		return true
+	case <-timeout:
+		return false
+	case <-c.Request.Context().Done():
+		return false
+	}
+}
+
+func (lane *admissionLane) release() {
+	atomic.AddInt64(&lane.inFlight, -1)
+	<-lane.slots
+}
@@ -0,0 +1,190 @@
+package goify
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base64"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+const (
+	PasswordHashIterations = 600_000
+	passwordSaltSize       = 16
+	passwordKeySize        = 32
+	maxPasswordIterations  = 10_000_000
+)
+
+var (
+	ErrPasswordMismatch        = errors.New("goify: password does not match")
+	ErrUnsupportedPasswordHash = errors.New("goify: unsupported password hash format")
+)
+
+type BasicAuthConfig struct {
+	Realm       string
+	Users       map[string]string
+	Validator   func(c *Context, username, password string) bool
+	CompareHash func(hash, password string) bool
+}
+
+func BasicAuth(username, password string) MiddlewareFunc {
+	expectedUser := sha256.Sum256([]byte(username))
+	expectedPassword := sha256.Sum256([]byte(password))
+
+	return BasicAuthWithConfig(BasicAuthConfig{
+		Validator: func(c *Context, username, password string) bool {
+			user := sha256.Sum256([]byte(username))
+			pass := sha256.Sum256([]byte(password))
+			userOK := subtle.ConstantTimeCompare(user[:], expectedUser[:])
+			passOK := subtle.ConstantTimeCompare(pass[:], expectedPassword[:])
+			return userOK&passOK == 1
+		},
+	})
+}
+
+func BasicAuthUsers(users map[string]string) MiddlewareFunc {
+	return BasicAuthWithConfig(BasicAuthConfig{Users: users})
+}
+
+func BasicAuthWithConfig(config BasicAuthConfig) MiddlewareFunc {
+	if config.Realm == "" {
+		config.Realm = "Restricted"
+	}
+	if config.Validator == nil && config.Users == nil {
+		panic("goify: BasicAuth requires Users or a Validator")
+	}
+
+	challenge := fmt.Sprintf("Basic realm=%q, charset=\"UTF-8\"", config.Realm)
+
+	return func(c *Context, next func()) {
+		username, password, ok := c.Request.BasicAuth()
+		if !ok || !config.authenticate(c, username, password) {
+			c.SetHeader("WWW-Authenticate", challenge)
+			c.SendUnauthorized("Authentication required")
+			return
+		}
+
+		c.Set("authUser", username)
+		next()
+	}
+}
+
+func (config BasicAuthConfig) authenticate(c *Context, username, password string) bool {
+	if config.Validator != nil {
+		return config.Validator(c, username, password)
+	}
+
+	hash, exists := config.Users[username]
+	if !exists {
+		hash = unknownUserHash()
+	}
+
+	if config.CompareHash != nil {
+		return config.CompareHash(hash, password) && exists
+	}
+
+	err := ComparePassword(hash, password)
+	if err != nil && !errors.Is(err, ErrPasswordMismatch) {
+		c.Logger().Error("basic auth: password hash cannot be verified, set BasicAuthConfig.CompareHash for this format",
+			"user", username,
+			"error", err,
+		)
+	}
+	return err == nil && exists
+}
+
+var (
+	unknownUserOnce sync.Once
+	unknownUser     string
+)
+
+func unknownUserHash() string {
+	unknownUserOnce.Do(func() {
+		unknownUser, _ = HashPassword("goify-unknown-user")
+	})
+	return unknownUser
+}
+
+func HashPassword(password string) (string, error) {
+	salt := make([]byte, passwordSaltSize)
+	if _, err := rand.Read(salt); err != nil {
+		return "", fmt.Errorf("failed to generate password salt: %v", err)
+	}
+
+	key := pbkdf2SHA256([]byte(password), salt, PasswordHashIterations, passwordKeySize)
+	return fmt.Sprintf("$pbkdf2-sha256$%d$%s$%s",
+		PasswordHashIterations,
+		base64.RawStdEncoding.EncodeToString(salt),
+		base64.RawStdEncoding.EncodeToString(key),
+	), nil
+}
+
+func ComparePassword(hash, password string) error {
+	parts := strings.Split(hash, "$")
+	if len(parts) != 5 || parts[0] != "" || parts[1] != "pbkdf2-sha256" {
+		return ErrUnsupportedPasswordHash
+	}
+
+	iterations, err := strconv.Atoi(parts[2])
+	if err != nil || iterations <= 0 || iterations > maxPasswordIterations {
+		return fmt.Errorf("%w: invalid iteration count", ErrUnsupportedPasswordHash)
+	}
+	salt, err := base64.RawStdEncoding.DecodeString(parts[3])
+	if err != nil || len(salt) == 0 {
+		return fmt.Errorf("%w: invalid salt", ErrUnsupportedPasswordHash)
+	}
+	expected, err := base64.RawStdEncoding.DecodeString(parts[4])
+	if err != nil || len(expected) == 0 {
+		return fmt.Errorf("%w: invalid key", ErrUnsupportedPasswordHash)
+	}
+
+	key := pbkdf2SHA256([]byte(password), salt, iterations, len(expected))
+	if subtle.ConstantTimeCompare(key, expected) != 1 {
+		return ErrPasswordMismatch
+	}
+	return nil
+}
+
+func pbkdf2SHA256(password, salt []byte, iterations, keyLen int) []byte {
+	prf := hmac.New(sha256.New, password)
+	hashLen := prf.Size()
+	blocks := (keyLen + hashLen - 1) / hashLen
+
+	key := make([]byte, 0, blocks*hashLen)
+	u := make([]byte, hashLen)
+	var counter [4]byte
+	for block := 1; block <= blocks; block++ {
+		prf.Reset()
+		prf.Write(salt)
+		binary.BigEndian.PutUint32(counter[:], uint32(block))
+		prf.Write(counter[:])
+		key = prf.Sum(key)
+
+		t := key[len(key)-hashLen:]
+		copy(u, t)
+		for i := 1; i < iterations; i++ {
+			prf.Reset()
+			prf.Write(u)
+			u = prf.Sum(u[:0])
+			for j := range t {
+				t[j] ^= u[j]
+			}
+		}
+	}
+	return key[:keyLen]
+}
+
+func (c *Context) AuthUser() string {
+	if value, exists := c.Get("authUser"); exists {
+		if username, ok := value.(string); ok {
+			return username
+		}
+	}
+	return ""
+}
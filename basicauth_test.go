@@ -0,0 +1,115 @@
+package goify
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestPBKDF2SHA256Vectors(t *testing.T) {
+	cases := []struct {
+		password, salt string
+		iterations     int
+		want           string
+	}{
+		{"passwd", "salt", 1, "55ac046e56e3089fec1691c22544b605f94185216dde0465e68b9d57c20dacbc49ca9cccf179b645991664b39d77ef317c71b845b1e30bd509112041d3a19783"},
+		{"Password", "NaCl", 80000, "4ddcd8f60b98be21830cee5ef22701f9641a4418d04c0414aeff08876b34ab56a1d425a1225833549adb841b51c9b3176a272bdebba1d078478f62b397f33c8d"},
+	}
+
+	for _, tc := range cases {
+		got := hex.EncodeToString(pbkdf2SHA256([]byte(tc.password), []byte(tc.salt), tc.iterations, 64))
+		if got != tc.want {
+			t.Errorf("pbkdf2(%q, %q, %d) = %s, want %s", tc.password, tc.salt, tc.iterations, got, tc.want)
+		}
+	}
+}
+
+// testPasswordHash builds a hash with a low iteration count so tests stay fast.
+func testPasswordHash(password string) string {
+	salt := []byte("0123456789abcdef")
+	key := pbkdf2SHA256([]byte(password), salt, 10, passwordKeySize)
+	return fmt.Sprintf("$pbkdf2-sha256$10$%s$%s",
+		base64.RawStdEncoding.EncodeToString(salt),
+		base64.RawStdEncoding.EncodeToString(key))
+}
+
+func TestComparePassword(t *testing.T) {
+	hash := testPasswordHash("s3cret")
+
+	if err := ComparePassword(hash, "s3cret"); err != nil {
+		t.Fatalf("expected match, got %v", err)
+	}
+	if err := ComparePassword(hash, "wrong"); !errors.Is(err, ErrPasswordMismatch) {
+		t.Fatalf("expected ErrPasswordMismatch, got %v", err)
+	}
+	if err := ComparePassword("$2a$10$N9qo8uLOickgx2ZMRZoMyeIjZAgcfl7p92ldGxad68LJZdL17lhWy", "s3cret"); !errors.Is(err, ErrUnsupportedPasswordHash) {
+		t.Fatalf("expected ErrUnsupportedPasswordHash, got %v", err)
+	}
+}
+
+func basicAuthStatus(rt *Router, username, password string) int {
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	req.SetBasicAuth(username, password)
+	w := httptest.NewRecorder()
+	rt.ServeHTTP(w, req)
+	return w.Code
+}
+
+func TestBasicAuthUsers(t *testing.T) {
+	var logs bytes.Buffer
+	rt := New()
+	rt.SetLogger(slog.New(slog.NewTextHandler(&logs, nil)))
+	rt.Use(Recovery())
+	rt.Use(BasicAuthUsers(map[string]string{
+		"alice": testPasswordHash("s3cret"),
+		"bob":   "$2b$10$N9qo8uLOickgx2ZMRZoMyeIjZAgcfl7p92ldGxad68LJZdL17lhWy",
+	}))
+	rt.GET("/", func(c *Context) {
+		c.String(http.StatusOK, c.AuthUser())
+	})
+
+	if code := basicAuthStatus(rt, "alice", "s3cret"); code != http.StatusOK {
+		t.Fatalf("valid credentials: expected 200, got %d", code)
+	}
+	if code := basicAuthStatus(rt, "alice", "wrong"); code != http.StatusUnauthorized {
+		t.Fatalf("wrong password: expected 401, got %d", code)
+	}
+	if code := basicAuthStatus(rt, "mallory", "s3cret"); code != http.StatusUnauthorized {
+		t.Fatalf("unknown user: expected 401, got %d", code)
+	}
+	if logs.Len() != 0 {
+		t.Fatalf("unexpected log output: %s", logs.String())
+	}
+
+	if code := basicAuthStatus(rt, "bob", "s3cret"); code != http.StatusUnauthorized {
+		t.Fatalf("unsupported hash: expected 401, got %d", code)
+	}
+	if !strings.Contains(logs.String(), "CompareHash") || !strings.Contains(logs.String(), "user=bob") {
+		t.Fatalf("expected a configuration error in the log, got %q", logs.String())
+	}
+}
+
+func TestBasicAuthCompareHash(t *testing.T) {
+	rt := New()
+	rt.Use(BasicAuthWithConfig(BasicAuthConfig{
+		Users:       map[string]string{"bob": "plain:s3cret"},
+		CompareHash: func(hash, password string) bool { return hash == "plain:"+password },
+	}))
+	rt.GET("/", func(c *Context) {
+		c.Status(http.StatusOK)
+	})
+
+	if code := basicAuthStatus(rt, "bob", "s3cret"); code != http.StatusOK {
+		t.Fatalf("expected 200, got %d", code)
+	}
+	if code := basicAuthStatus(rt, "bob", "wrong"); code != http.StatusUnauthorized {
+		t.Fatalf("expected 401, got %d", code)
+	}
+}
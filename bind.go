@@ -0,0 +1,226 @@
+package goify
+
+import (
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"mime"
+	"net/http"
+	"reflect"
+	"strings"
+)
+
+var ErrUnsupportedMediaType = errors.New("unsupported media type")
+
+func (c *Context) ContentType() string {
+	contentType := c.GetHeader("Content-Type")
+	if contentType == "" {
+		return ""
+	}
+
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return contentType
+	}
+	return mediaType
+}
+
+func (c *Context) BindXML(obj interface{}) error {
+	c.markBodyRead("BindXML")
+	return xml.NewDecoder(c.Request.Body).Decode(obj)
+}
+
+func (c *Context) BindForm(obj interface{}) error {
+	if err := c.Request.ParseForm(); err != nil {
+		return err
+	}
+
+	rv := reflect.ValueOf(obj)
+	if rv.Kind() != reflect.Ptr || rv.Elem().Kind() != reflect.Struct {
+		return fmt.Errorf("obj must be a pointer to struct")
+	}
+
+	return c.bindMultipartFields(rv.Elem())
+}
+
+func (c *Context) ShouldBind(obj interface{}) error {
+	err := c.bindByContentType(obj)
+	var missing ValidationErrors
+	if err == nil || errors.As(err, &missing) {
+		rv := reflect.ValueOf(obj)
+		if rv.Kind() == reflect.Ptr && rv.Elem().Kind() == reflect.Struct {
+			if paramErr := c.bindParamFields(rv.Elem(), nil); paramErr != nil {
+				return paramErr
+			}
+		}
+	}
+
+	return c.validateBound(obj, err)
+}
+
+func (c *Context) Bind(obj interface{}) error {
+	err := c.ShouldBind(obj)
+	if err == nil {
+		return nil
+	}
+
+	var validationErrors ValidationErrors
+	var maxBytesError *http.MaxBytesError
+	switch {
+	case errors.As(err, &validationErrors):
+		c.SendValidationError(validationErrors)
+	case errors.As(err, &maxBytesError):
+		c.SendError(http.StatusRequestEntityTooLarge, "Request body too large", H{"limit": FormatFileSize(maxBytesError.Limit)})
+	case errors.Is(err, ErrUnsupportedMediaType):
+		c.SendError(http.StatusUnsupportedMediaType, err.Error())
+	default:
+		c.SendBadRequest(err.Error())
+	}
+
+	return err
+}
+
+func (c *Context) bindByContentType(obj interface{}) error {
+	contentType := c.ContentType()
+
+	if contentType == "" && (c.Request.Method == http.MethodGet || c.Request.Method == http.MethodHead) {
+		rv := reflect.ValueOf(obj)
+		if rv.Kind() != reflect.Ptr || rv.Elem().Kind() != reflect.Struct {
+			return fmt.Errorf("obj must be a pointer to struct")
+		}
+		return c.bindQueryFields(rv.Elem(), c.Request.URL.Query())
+	}
+
+	switch contentType {
+	case "", MIMEJSON:
+		return c.BindJSON(obj)
+	case "application/x-www-form-urlencoded":
+		return c.BindForm(obj)
+	case "multipart/form-data":
+		return c.BindMultipart(obj)
+	}
+
+	return c.BindWith(obj, contentType)
+}
+
+func (c *Context) BindParams(obj interface{}) error {
+	rv := reflect.ValueOf(obj)
+	if rv.Kind() != reflect.Ptr || rv.Elem().Kind() != reflect.Struct {
+		return fmt.Errorf("obj must be a pointer to struct")
+	}
+
+	if err := c.bindParamFields(rv.Elem(), nil); err != nil {
+		return err
+	}
+
+	if validationErrors := c.ValidateStruct(obj); len(validationErrors) > 0 {
+		return validationErrors
+	}
+
+	return nil
+}
+
+func (c *Context) bindParamFields(rv reflect.Value, errs *ValidationErrors) error {
+	rt := rv.Type()
+
+	for i := 0; i < rv.NumField(); i++ {
+		field := rv.Field(i)
+		fieldType := rt.Field(i)
+
+		if embedded, ok := embeddedStruct(field, fieldType); ok {
+			if err := c.bindParamFields(embedded, errs); err != nil {
+				return err
+			}
+			continue
+		}
+
+		paramName := fieldType.Tag.Get("param")
+		if paramName == "" || paramName == "-" || !field.CanSet() {
+			continue
+		}
+
+		value, exists := c.params[paramName]
+		if !exists {
+			continue
+		}
+
+		layout := fieldType.Tag.Get("time_format")
+		if field.Kind() == reflect.Slice {
+			if err := setSliceValue(field, []string{value}, layout); err != nil {
+				if err := bindFailure(errs, paramName, value, field.Type().Elem(), fmt.Errorf("invalid value for path parameter %s: %v", paramName, err)); err != nil {
+					return err
+				}
+			}
+			continue
+		}
+
+		if err := setFieldValue(field, value, layout); err != nil {
+			if err := bindFailure(errs, paramName, value, field.Type(), fmt.Errorf("invalid value for path parameter %s: %v", paramName, err)); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+func (c *Context) BindHeader(obj interface{}) error {
+	rv := reflect.ValueOf(obj)
+	if rv.Kind() != reflect.Ptr || rv.Elem().Kind() != reflect.Struct {
+		return fmt.Errorf("obj must be a pointer to struct")
+	}
+
+	if err := c.bindHeaderFields(rv.Elem(), nil); err != nil {
+		return err
+	}
+
+	if validationErrors := c.ValidateStruct(obj); len(validationErrors) > 0 {
+		return validationErrors
+	}
+
+	return nil
+}
+
+func (c *Context) bindHeaderFields(rv reflect.Value, errs *ValidationErrors) error {
+	rt := rv.Type()
+
+	for i := 0; i < rv.NumField(); i++ {
+		field := rv.Field(i)
+		fieldType := rt.Field(i)
+
+		if embedded, ok := embeddedStruct(field, fieldType); ok {
+			if err := c.bindHeaderFields(embedded, errs); err != nil {
+				return err
+			}
+			continue
+		}
+
+		headerName := fieldType.Tag.Get("header")
+		if headerName == "" || headerName == "-" || !field.CanSet() {
+			continue
+		}
+
+		values := c.Request.Header.Values(headerName)
+		if len(values) == 0 {
+			continue
+		}
+
+		layout := fieldType.Tag.Get("time_format")
+		if field.Kind() == reflect.Slice {
+			if err := setSliceValue(field, values, layout); err != nil {
+				if err := bindFailure(errs, headerName, strings.Join(values, ","), field.Type().Elem(), fmt.Errorf("invalid value for header %s: %v", headerName, err)); err != nil {
+					return err
+				}
+			}
+			continue
+		}
+
+		if err := setFieldValue(field, values[0], layout); err != nil {
+			if err := bindFailure(errs, headerName, values[0], field.Type(), fmt.Errorf("invalid value for header %s: %v", headerName, err)); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,29 @@
+package goify
+
+import (
+	"fmt"
+	"net/http"
+)
+
+func BodyLimit(limit string) MiddlewareFunc {
+	size, err := ParseSize(limit)
+	if err != nil {
+		panic(fmt.Sprintf("goify: invalid body limit: %v", err))
+	}
+	return BodyLimitBytes(size)
+}
+
+func BodyLimitBytes(limit int64) MiddlewareFunc {
+	return func(c *Context, next func()) {
+		if c.Request.ContentLength > limit {
+			c.SendError(http.StatusRequestEntityTooLarge, "Request body too large", H{"limit": FormatFileSize(limit)})
+			return
+		}
+
+		if c.Request.Body != nil {
+			c.Request.Body = http.MaxBytesReader(c.Response, c.Request.Body, limit)
+		}
+
+		next()
+	}
+}
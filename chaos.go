@@ -0,0 +1,101 @@
+package goify
+
+import (
+	"log"
+	"math/rand"
+	"net/http"
+	"strings"
+	"time"
+)
+
+type ChaosConfig struct {
+	Paths           []string
+	Header          string
+	LatencyRate     float64
+	MinLatency      time.Duration
+	MaxLatency      time.Duration
+	ErrorRate       float64
+	ErrorCode       int
+	ErrorMessage    string
+	DropRate        float64
+	AllowProduction bool
+}
+
+func Chaos(config ChaosConfig) MiddlewareFunc {
+	if config.ErrorCode == 0 {
+		config.ErrorCode = http.StatusServiceUnavailable
+	}
+	if config.ErrorMessage == "" {
+		config.ErrorMessage = "Injected fault"
+	}
+	if config.MaxLatency < config.MinLatency {
+		config.MaxLatency = config.MinLatency
+	}
+
+	return func(c *Context, next func()) {
+		if c.router.Health().Environment() == "production" && !config.AllowProduction {
+			next()
+			return
+		}
+
+		if !config.matches(c) {
+			next()
+			return
+		}
+
+		if config.DropRate > 0 && rand.Float64() < config.DropRate {
+			dropConnection(c)
+			return
+		}
+
+		if config.LatencyRate > 0 && rand.Float64() < config.LatencyRate {
+			delay := config.MinLatency
+			if spread := config.MaxLatency - config.MinLatency; spread > 0 {
+				delay += time.Duration(rand.Int63n(int64(spread)))
+			}
+
+			select {
+			case <-time.After(delay):
+			case <-c.Request.Context().Done():
+				return
+			}
+		}
+
+		if config.ErrorRate > 0 && rand.Float64() < config.ErrorRate {
+			c.SetHeader("X-Chaos-Injected", "error")
+			c.SendError(config.ErrorCode, config.ErrorMessage)
+			return
+		}
+
+		next()
+	}
+}
+
+func (config ChaosConfig) matches(c *Context) bool {
+	if config.Header != "" && c.GetHeader(config.Header) == "" {
+		return false
+	}
+
+	if len(config.Paths) == 0 {
+		return true
+	}
+
+	for _, prefix := range config.Paths {
+		if strings.HasPrefix(c.Request.URL.Path, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+func dropConnection(c *Context) {
+	if hijacker, ok := c.Response.(http.Hijacker); ok {
+		conn, _, err := hijacker.Hijack()
+		if err == nil {
+			conn.Close()
+			return
+		}
+		log.Printf("Chaos: failed to hijack connection: %v", err)
+	}
+	panic(http.ErrAbortHandler)
+}
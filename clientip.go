@@ -0,0 +1,172 @@
+package goify
+
+import (
+	"fmt"
+	"net"
+	"net/http"
+	"strings"
+)
+
+func (rt *Router) SetTrustedProxies(proxies ...string) error {
+	networks := make([]*net.IPNet, 0, len(proxies))
+	for _, proxy := range proxies {
+		network, err := parseNetwork(proxy)
+		if err != nil {
+			return fmt.Errorf("invalid trusted proxy: %s", proxy)
+		}
+		networks = append(networks, network)
+	}
+
+	rt.trustedProxies = networks
+	return nil
+}
+
+func parseNetwork(value string) (*net.IPNet, error) {
+	if !strings.Contains(value, "/") {
+		ip := net.ParseIP(value)
+		if ip == nil {
+			return nil, fmt.Errorf("invalid IP address: %s", value)
+		}
+		if ip.To4() != nil {
+			value += "/32"
+		} else {
+			value += "/128"
+		}
+	}
+
+	_, network, err := net.ParseCIDR(value)
+	return network, err
+}
+
+func (rt *Router) isTrustedProxy(ip net.IP) bool {
+	if rt == nil {
+		return false
+	}
+	return containsIP(rt.trustedProxies, ip)
+}
+
+func containsIP(networks []*net.IPNet, ip net.IP) bool {
+	if ip == nil {
+		return false
+	}
+	for _, network := range networks {
+		if network.Contains(ip) {
+			return true
+		}
+	}
+	return false
+}
+
+func (c *Context) RemoteIP() string {
+	host, _, err := net.SplitHostPort(c.Request.RemoteAddr)
+	if err != nil {
+		return c.Request.RemoteAddr
+	}
+	return host
+}
+
+func (c *Context) ClientIP() string {
+	if value, exists := c.Get("realIP"); exists {
+		if ip, ok := value.(string); ok {
+			return ip
+		}
+	}
+
+	var trusted []*net.IPNet
+	if c.router != nil {
+		trusted = c.router.trustedProxies
+	}
+	return resolveClientIP(c.Request, c.RemoteIP(), trusted)
+}
+
+func RealIP(trustedCIDRs ...string) MiddlewareFunc {
+	networks := make([]*net.IPNet, 0, len(trustedCIDRs))
+	for _, cidr := range trustedCIDRs {
+		network, err := parseNetwork(cidr)
+		if err != nil {
+			panic(fmt.Sprintf("goify: invalid trusted proxy %q for RealIP", cidr))
+		}
+		networks = append(networks, network)
+	}
+
+	return func(c *Context, next func()) {
+		if _, exists := c.Get("realIP"); !exists {
+			remote := c.RemoteIP()
+			ip := resolveClientIP(c.Request, remote, networks)
+			if ip != remote {
+				c.Request.RemoteAddr = net.JoinHostPort(ip, "0")
+			}
+			c.Set("realIP", ip)
+		}
+
+		next()
+	}
+}
+
+func resolveClientIP(req *http.Request, remote string, trusted []*net.IPNet) string {
+	if !containsIP(trusted, net.ParseIP(remote)) {
+		return remote
+	}
+
+	if chain := forwardedFor(req.Header.Values("Forwarded")); len(chain) > 0 {
+		return firstUntrusted(chain, remote, trusted)
+	}
+
+	if values := req.Header.Values("X-Forwarded-For"); len(values) > 0 {
+		var chain []string
+		for _, value := range values {
+			for _, part := range strings.Split(value, ",") {
+				chain = append(chain, strings.TrimSpace(part))
+			}
+		}
+		return firstUntrusted(chain, remote, trusted)
+	}
+
+	if realIP := strings.TrimSpace(req.Header.Get("X-Real-IP")); net.ParseIP(realIP) != nil {
+		return realIP
+	}
+
+	return remote
+}
+
+func firstUntrusted(chain []string, remote string, trusted []*net.IPNet) string {
+	for i := len(chain) - 1; i >= 0; i-- {
+		ip := net.ParseIP(chain[i])
+		if ip == nil {
+			return remote
+		}
+		if !containsIP(trusted, ip) {
+			return ip.String()
+		}
+	}
+
+	if ip := net.ParseIP(chain[0]); ip != nil {
+		return ip.String()
+	}
+	return remote
+}
+
+func forwardedFor(values []string) []string {
+	var chain []string
+	for _, value := range values {
+		for _, element := range strings.Split(value, ",") {
+			for _, pair := range strings.Split(element, ";") {
+				key, val, ok := strings.Cut(strings.TrimSpace(pair), "=")
+				if !ok || !strings.EqualFold(key, "for") {
+					continue
+				}
+
+				val = strings.Trim(val, `"`)
+				if strings.HasPrefix(val, "[") {
+					if end := strings.Index(val, "]"); end > 0 {
+						val = val[1:end]
+					}
+				} else if host, _, err := net.SplitHostPort(val); err == nil {
+					val = host
+				}
+				chain = append(chain, val)
+			}
+		}
+	}
+	return chain
+}
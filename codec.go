@@ -0,0 +1,165 @@
+package goify
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"reflect"
+	"sync"
+)
+
+const (
+	MIMEJSON    = "application/json"
+	MIMEXML     = "application/xml"
+	MIMEYAML    = "application/yaml"
+	MIMEMsgPack = "application/msgpack"
+)
+
+type Codec interface {
+	Marshal(v interface{}) ([]byte, error)
+	Unmarshal(data []byte, v interface{}) error
+}
+
+type CodecFuncs struct {
+	MarshalFunc   func(v interface{}) ([]byte, error)
+	UnmarshalFunc func(data []byte, v interface{}) error
+}
+
+func (cf CodecFuncs) Marshal(v interface{}) ([]byte, error) {
+	return cf.MarshalFunc(v)
+}
+
+func (cf CodecFuncs) Unmarshal(data []byte, v interface{}) error {
+	return cf.UnmarshalFunc(data, v)
+}
+
+var (
+	codecsMu sync.RWMutex
+	codecs   = map[string]Codec{
+		MIMEJSON:                CodecFuncs{marshalJSON, unmarshalJSON},
+		MIMEXML:                 CodecFuncs{xml.Marshal, xml.Unmarshal},
+		"text/xml":              CodecFuncs{xml.Marshal, xml.Unmarshal},
+		MIMEYAML:                CodecFuncs{marshalYAML, unmarshalYAML},
+		"application/x-yaml":    CodecFuncs{marshalYAML, unmarshalYAML},
+		"text/yaml":             CodecFuncs{marshalYAML, unmarshalYAML},
+		MIMEMsgPack:             CodecFuncs{marshalMsgPack, unmarshalMsgPack},
+		"application/x-msgpack": CodecFuncs{marshalMsgPack, unmarshalMsgPack},
+	}
+)
+
+var (
+	jsonMarshal   = json.Marshal
+	jsonUnmarshal = json.Unmarshal
+)
+
+func SetJSONCodec(marshal func(v interface{}) ([]byte, error), unmarshal func(data []byte, v interface{}) error) {
+	codecsMu.Lock()
+	defer codecsMu.Unlock()
+	if marshal == nil {
+		marshal = json.Marshal
+	}
+	if unmarshal == nil {
+		unmarshal = json.Unmarshal
+	}
+	jsonMarshal = marshal
+	jsonUnmarshal = unmarshal
+}
+
+func marshalJSON(v interface{}) ([]byte, error) {
+	codecsMu.RLock()
+	marshal := jsonMarshal
+	timeFormat, utc := jsonTimeFormat, jsonTimeUTC
+	codecsMu.RUnlock()
+
+	if timeFormat != JSONTimeDefault && v != nil {
+		v = jsonTimeWriter{format: timeFormat, utc: utc, marshal: marshal}.value(reflect.ValueOf(v))
+	}
+	return marshal(v)
+}
+
+func unmarshalJSON(data []byte, v interface{}) error {
+	codecsMu.RLock()
+	unmarshal := jsonUnmarshal
+	codecsMu.RUnlock()
+	return unmarshal(data, v)
+}
+
+type EncodeError struct {
+	Type string
+	Err  error
+}
+
+func (e *EncodeError) Error() string {
+	return fmt.Sprintf("failed to encode %s: %v", e.Type, e.Err)
+}
+
+func (e *EncodeError) Unwrap() error {
+	return e.Err
+}
+
+func (c *Context) encodeFailed(obj interface{}, err error) error {
+	encodeErr := &EncodeError{Type: fmt.Sprintf("%T", obj), Err: err}
+
+	c.Logger().Error("response encoding failed",
+		"type", encodeErr.Type,
+		"error", err,
+		"method", c.Request.Method,
+		"path", c.routeTemplate(),
+	)
+
+	c.Error(encodeErr)
+	return encodeErr
+}
+
+func RegisterCodec(contentType string, codec Codec) {
+	codecsMu.Lock()
+	defer codecsMu.Unlock()
+	codecs[contentType] = codec
+}
+
+func GetCodec(contentType string) (Codec, bool) {
+	codecsMu.RLock()
+	defer codecsMu.RUnlock()
+	codec, exists := codecs[contentType]
+	return codec, exists
+}
+
+func (c *Context) BindWith(obj interface{}, contentType string) error {
+	codec, exists := GetCodec(contentType)
+	if !exists {
+		return fmt.Errorf("%w: %s", ErrUnsupportedMediaType, contentType)
+	}
+
+	c.markBodyRead("BindWith")
+	data, err := io.ReadAll(c.Request.Body)
+	if err != nil {
+		return err
+	}
+	return codec.Unmarshal(data, obj)
+}
+
+func (c *Context) Render(code int, contentType string, obj interface{}) error {
+	codec, exists := GetCodec(contentType)
+	if !exists {
+		return fmt.Errorf("%w: %s", ErrUnsupportedMediaType, contentType)
+	}
+
+	data, err := codec.Marshal(obj)
+	if err != nil {
+		return err
+	}
+
+	c.SetHeader("Content-Type", contentType)
+	c.Response.WriteHeader(code)
+	_, err = c.Response.Write(data)
+	return err
+}
+
+func (c *Context) BindMsgPack(obj interface{}) error {
+	return c.BindWith(obj, MIMEMsgPack)
+}
+
+func (c *Context) MsgPack(code int, obj interface{}) error {
+	return c.Render(code, MIMEMsgPack, obj)
+}
@@ -0,0 +1,67 @@
+package goify
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+type upperCodec struct{}
+
+func (upperCodec) Marshal(v interface{}) ([]byte, error) {
+	return []byte(strings.ToUpper(*v.(*string))), nil
+}
+
+func (upperCodec) Unmarshal(data []byte, v interface{}) error {
+	*v.(*string) = strings.ToLower(string(data))
+	return nil
+}
+
+func TestRegisteredCodecIsUsedForBindAndRender(t *testing.T) {
+	const contentType = "application/x-test-upper"
+	RegisterCodec(contentType, upperCodec{})
+	defer func() {
+		codecsMu.Lock()
+		delete(codecs, contentType)
+		codecsMu.Unlock()
+	}()
+
+	rt := New()
+	rt.POST("/echo", func(c *Context) {
+		var body string
+		if err := c.BindWith(&body, contentType); err != nil {
+			t.Errorf("bind: %v", err)
+			return
+		}
+		c.Render(http.StatusOK, contentType, &body)
+	})
+
+	req := httptest.NewRequest(http.MethodPost, "/echo", strings.NewReader("Hello"))
+	req.Header.Set("Content-Type", contentType)
+	w := httptest.NewRecorder()
+	rt.ServeHTTP(w, req)
+
+	if w.Code != http.StatusOK || w.Body.String() != "HELLO" {
+		t.Fatalf("unexpected response: %d %q", w.Code, w.Body.String())
+	}
+	if got := w.Header().Get("Content-Type"); got != contentType {
+		t.Fatalf("unexpected content type %q", got)
+	}
+}
+
+func TestUnregisteredCodecIsUnsupported(t *testing.T) {
+	rt := New()
+	var bindErr error
+	rt.POST("/", func(c *Context) {
+		var body map[string]interface{}
+		bindErr = c.BindWith(&body, "application/x-protobuf")
+		c.Status(http.StatusNoContent)
+	})
+
+	rt.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodPost, "/", strings.NewReader("x")))
+	if !errors.Is(bindErr, ErrUnsupportedMediaType) {
+		t.Fatalf("expected ErrUnsupportedMediaType, got %v", bindErr)
+	}
+}
@@ -0,0 +1,52 @@
+package goify
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+)
+
+func MaxConcurrency(n int, queueTimeout time.Duration) MiddlewareFunc {
+	if n <= 0 {
+		panic("goify: MaxConcurrency requires a positive limit")
+	}
+
+	slots := make(chan struct{}, n)
+	retryAfter := strconv.Itoa(ceilSeconds(queueTimeout))
+	if queueTimeout <= 0 {
+		retryAfter = "1"
+	}
+
+	return func(c *Context, next func()) {
+		select {
+		case slots <- struct{}{}:
+		default:
+			if !waitForSlot(c, slots, queueTimeout) {
+				c.SetHeader("Retry-After", retryAfter)
+				c.SendError(http.StatusServiceUnavailable, "Server is busy")
+				return
+			}
+		}
+		defer func() { <-slots }()
+
+		next()
+	}
+}
+
+func waitForSlot(c *Context, slots chan struct{}, timeout time.Duration) bool {
+	if timeout <= 0 {
+		return false
+	}
+
+	timer := time.NewTimer(timeout)
+	defer timer.Stop()
+
+	select {
+	case slots <- struct{}{}:
+		return true
+	case <-timer.C:
+		return false
+	case <-c.Request.Context().Done():
+		return false
+	}
+}
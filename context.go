@@ -1,27 +1,80 @@
 package goify
 
 import (
-	"encoding/json"
+	"bytes"
+	"context"
+	"errors"
+	"io"
 	"fmt"
+	"mime/multipart"
 	"net/http"
 	"net/url"
-	"path/filepath"
 	"reflect"
 	"strconv"
 	"strings"
+	"time"
+
+	"github.com/VsRnA/goify/upload"
+	"github.com/VsRnA/goify/validate"
 )
 
 type Context struct {
-	Request  *http.Request
-	Response http.ResponseWriter
-	params   map[string]string
-	store    map[string]interface{}
+	Request   *http.Request
+	Response  http.ResponseWriter
+	params    map[string]string
+	paramKeys []string
+	fullPath  string
+	store     map[string]interface{}
+	router    *Router
+	writer    *responseWriter
+	bodyRead  string
+	openFiles []*trackedFile
+	multipart MultipartConfig
+	err       error
+	inError   bool
+	panicked  bool
+	recovery  bool
+	deferred  []func(context.Context)
+}
+
+type Param struct {
+	Key   string `json:"key"`
+	Value string `json:"value"`
 }
 
 func (c *Context) Param(key string) string {
 	return c.params[key]
 }
 
+func (c *Context) Params() []Param {
+	params := make([]Param, 0, len(c.params))
+	seen := make(map[string]bool, len(c.params))
+	for _, key := range c.paramKeys {
+		if value, exists := c.params[key]; exists && !seen[key] {
+			params = append(params, Param{Key: key, Value: value})
+			seen[key] = true
+		}
+	}
+	for key, value := range c.params {
+		if !seen[key] {
+			params = append(params, Param{Key: key, Value: value})
+		}
+	}
+	return params
+}
+
+func (c *Context) FullPath() string {
+	return c.fullPath
+}
+
+func (c *Context) ParamsMap() map[string]string {
+	params := make(map[string]string, len(c.params))
+	for key, value := range c.params {
+		params[key] = value
+	}
+	return params
+}
+
 func (c *Context) Query(key string) string {
 	return c.Request.URL.Query().Get(key)
 }
@@ -43,6 +96,7 @@ func (c *Context) QueryInt(key string) (int, error) {
 }
 
 func (c *Context) Body() ([]byte, error) {
+	c.markBodyRead("Body")
 	defer c.Request.Body.Close()
 	buf := make([]byte, c.Request.ContentLength)
 	_, err := c.Request.Body.Read(buf)
@@ -50,8 +104,15 @@ func (c *Context) Body() ([]byte, error) {
 }
 
 func (c *Context) BindJSON(obj interface{}) error {
-	decoder := json.NewDecoder(c.Request.Body)
-	return decoder.Decode(obj)
+	c.markBodyRead("BindJSON")
+	data, err := io.ReadAll(c.Request.Body)
+	if err != nil {
+		return err
+	}
+	if len(bytes.TrimSpace(data)) == 0 {
+		return io.EOF
+	}
+	return unmarshalJSON(data, obj)
 }
 
 func (c *Context) BindAndValidate(obj interface{}) error {
@@ -59,7 +120,7 @@ func (c *Context) BindAndValidate(obj interface{}) error {
 		return err
 	}
 	
-	if validationErrors := Validate(obj); len(validationErrors) > 0 {
+	if validationErrors := c.ValidateStruct(obj); len(validationErrors) > 0 {
 		return validationErrors
 	}
 	
@@ -67,7 +128,7 @@ func (c *Context) BindAndValidate(obj interface{}) error {
 }
 
 func (c *Context) ValidateStruct(obj interface{}) ValidationErrors {
-	return Validate(obj)
+	return validate.StructWith(obj, validate.Options{Context: c.Request.Context(), Locale: c.Locale()})
 }
 
 func (c *Context) ValidateQuery(obj interface{}) error {
@@ -76,12 +137,86 @@ func (c *Context) ValidateQuery(obj interface{}) error {
 		return fmt.Errorf("obj must be a pointer to struct")
 	}
 	
-	rv = rv.Elem()
+	return c.validateBound(obj, c.bindQueryFields(rv.Elem(), c.Request.URL.Query()))
+}
+
+func (c *Context) validateBound(obj interface{}, err error) error {
+	var missing ValidationErrors
+	if err != nil && !errors.As(err, &missing) {
+		return err
+	}
+
+	validationErrors := mergeMissing(c.ValidateStruct(obj), missing)
+	if len(validationErrors) > 0 {
+		return validationErrors
+	}
+	return nil
+}
+
+func mergeMissing(validationErrors, missing ValidationErrors) ValidationErrors {
+	for _, missingErr := range missing {
+		reported := false
+		kept := validationErrors[:0]
+		for _, validationErr := range validationErrors {
+			if validationErr.Field != missingErr.Field {
+				kept = append(kept, validationErr)
+			} else if validationErr.Tag == "required" {
+				kept = append(kept, validationErr)
+				reported = true
+			}
+		}
+		validationErrors = kept
+		if !reported {
+			validationErrors = append(validationErrors, missingErr)
+		}
+	}
+	return validationErrors
+}
+
+func embeddedStruct(field reflect.Value, fieldType reflect.StructField) (reflect.Value, bool) {
+	if !fieldType.Anonymous || field.Type() == timeType {
+		return reflect.Value{}, false
+	}
+
+	switch {
+	case field.Kind() == reflect.Struct:
+		return field, true
+	case field.Kind() == reflect.Ptr && field.Type().Elem().Kind() == reflect.Struct:
+		if field.IsNil() {
+			if !field.CanSet() {
+				return reflect.Value{}, false
+			}
+			field.Set(reflect.New(field.Type().Elem()))
+		}
+		return field.Elem(), true
+	}
+	return reflect.Value{}, false
+}
+
+func (c *Context) bindQueryFields(rv reflect.Value, query url.Values) error {
+	var missing ValidationErrors
+	if err := c.bindQueryValues(rv, query, &missing, nil); err != nil {
+		return err
+	}
+	if len(missing) > 0 {
+		return missing
+	}
+	return nil
+}
+
+func (c *Context) bindQueryValues(rv reflect.Value, query url.Values, missing, errs *ValidationErrors) error {
 	rt := rv.Type()
-	
+
 	for i := 0; i < rv.NumField(); i++ {
 		field := rv.Field(i)
 		fieldType := rt.Field(i)
+
+		if embedded, ok := embeddedStruct(field, fieldType); ok {
+			if err := c.bindQueryValues(embedded, query, missing, errs); err != nil {
+				return err
+			}
+			continue
+		}
 		
 		if !field.CanSet() {
 			continue
@@ -90,30 +225,192 @@ func (c *Context) ValidateQuery(obj interface{}) error {
 		paramName := fieldType.Name
 		if tag := fieldType.Tag.Get("query"); tag != "" {
 			paramName = tag
+		} else if errs != nil {
+			continue
 		} else if tag := fieldType.Tag.Get("json"); tag != "" {
 			if tagName := strings.Split(tag, ",")[0]; tagName != "" && tagName != "-" {
 				paramName = tagName
 			}
 		}
 		
-		queryValue := c.Query(paramName)
+		layout := fieldType.Tag.Get("time_format")
+
+		switch {
+		case field.Kind() == reflect.Map:
+			if err := setMapValue(field, query, paramName, layout); err != nil {
+				if err := bindFailure(errs, paramName, "", field.Type().Elem(), fmt.Errorf("invalid value for field %s: %v", fieldType.Name, err)); err != nil {
+					return err
+				}
+			}
+			continue
+		case field.Kind() == reflect.Slice:
+			values := query[paramName]
+			if len(values) == 0 {
+				values = query[paramName+"[]"]
+			}
+			if len(values) == 0 {
+				if defaultValue, ok := fieldType.Tag.Lookup("default"); ok {
+					values = []string{defaultValue}
+				} else {
+					missingParam(missing, fieldType, paramName)
+					continue
+				}
+			}
+			if err := setSliceValue(field, values, layout); err != nil {
+				if err := bindFailure(errs, paramName, strings.Join(values, ","), field.Type().Elem(), fmt.Errorf("invalid value for field %s: %v", fieldType.Name, err)); err != nil {
+					return err
+				}
+			}
+			continue
+		}
+
+		queryValue := query.Get(paramName)
 		if queryValue == "" {
+			defaultValue, ok := fieldType.Tag.Lookup("default")
+			if !ok || defaultValue == "" {
+				missingParam(missing, fieldType, paramName)
+				continue
+			}
+			queryValue = defaultValue
+		}
+
+		if err := setFieldValue(field, queryValue, layout); err != nil {
+			if err := bindFailure(errs, paramName, queryValue, field.Type(), fmt.Errorf("invalid value for field %s: %v", fieldType.Name, err)); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+func bindFailure(errs *ValidationErrors, name, value string, expected reflect.Type, err error) error {
+	if errs == nil {
+		return err
+	}
+	*errs = append(*errs, conversionError(name, value, expected))
+	return nil
+}
+
+func missingParam(missing *ValidationErrors, fieldType reflect.StructField, paramName string) {
+	required := false
+	for _, rule := range validate.ParseRules(fieldType.Tag.Get("validate")) {
+		if rule.Tag == "dive" {
+			break
+		}
+		if rule.Tag == "required" {
+			required = true
+		}
+	}
+	if !required {
+		return
+	}
+
+	name := fieldType.Name
+	if tag := fieldType.Tag.Get("json"); tag != "" {
+		if tagName := strings.Split(tag, ",")[0]; tagName != "" && tagName != "-" {
+			name = tagName
+		}
+	}
+	*missing = append(*missing, ValidationError{
+		Field:   name,
+		Tag:     "required",
+		Message: fmt.Sprintf("query parameter %s is required", paramName),
+	})
+}
+
+var timeType = reflect.TypeOf(time.Time{})
+
+var DefaultTimeLayouts = []string{time.RFC3339, "2006-01-02T15:04:05", "2006-01-02"}
+
+func parseTime(value, layout string) (time.Time, error) {
+	if layout != "" {
+		return time.Parse(layout, value)
+	}
+
+	var err error
+	for _, l := range DefaultTimeLayouts {
+		var t time.Time
+		if t, err = time.Parse(l, value); err == nil {
+			return t, nil
+		}
+	}
+	return time.Time{}, err
+}
+
+func setSliceValue(field reflect.Value, values []string, layout string) error {
+	var items []string
+	for _, value := range values {
+		for _, item := range strings.Split(value, ",") {
+			if item = strings.TrimSpace(item); item != "" {
+				items = append(items, item)
+			}
+		}
+	}
+
+	slice := reflect.MakeSlice(field.Type(), 0, len(items))
+	for _, item := range items {
+		elem := reflect.New(field.Type().Elem()).Elem()
+		if err := setFieldValue(elem, item, layout); err != nil {
+			return err
+		}
+		slice = reflect.Append(slice, elem)
+	}
+
+	field.Set(slice)
+	return nil
+}
+
+func setMapValue(field reflect.Value, values url.Values, name, layout string) error {
+	if field.Type().Key().Kind() != reflect.String {
+		return fmt.Errorf("unsupported map key type: %v", field.Type().Key().Kind())
+	}
+
+	prefix := name + "["
+	result := reflect.MakeMap(field.Type())
+	for key, vals := range values {
+		if !strings.HasPrefix(key, prefix) || !strings.HasSuffix(key, "]") || len(vals) == 0 {
+			continue
+		}
+
+		mapKey := key[len(prefix) : len(key)-1]
+		if mapKey == "" {
 			continue
 		}
 
-		if err := setFieldValue(field, queryValue); err != nil {
-			return fmt.Errorf("invalid value for field %s: %v", fieldType.Name, err)
+		elem := reflect.New(field.Type().Elem()).Elem()
+		var err error
+		if elem.Kind() == reflect.Slice {
+			err = setSliceValue(elem, vals, layout)
+		} else {
+			err = setFieldValue(elem, vals[0], layout)
+		}
+		if err != nil {
+			return err
 		}
+		result.SetMapIndex(reflect.ValueOf(mapKey).Convert(field.Type().Key()), elem)
 	}
 
-	if validationErrors := Validate(obj); len(validationErrors) > 0 {
-		return validationErrors
+	if result.Len() > 0 {
+		field.Set(result)
 	}
-	
 	return nil
 }
 
-func setFieldValue(field reflect.Value, value string) error {
+func setFieldValue(field reflect.Value, value string, layout ...string) error {
+	if field.Type() == timeType {
+		timeLayout := ""
+		if len(layout) > 0 {
+			timeLayout = layout[0]
+		}
+		t, err := parseTime(value, timeLayout)
+		if err != nil {
+			return err
+		}
+		field.Set(reflect.ValueOf(t))
+		return nil
+	}
+
 	switch field.Kind() {
 	case reflect.String:
 		field.SetString(value)
@@ -161,9 +458,15 @@ func (c *Context) Status(code int) *Context {
 }
 
 func (c *Context) JSON(code int, obj interface{}) error {
+	data, err := marshalJSON(obj)
+	if err != nil {
+		return c.encodeFailed(obj, err)
+	}
+
 	c.SetHeader("Content-Type", "application/json")
 	c.Response.WriteHeader(code)
-	return json.NewEncoder(c.Response).Encode(obj)
+	_, err = c.Response.Write(append(data, '\n'))
+	return err
 }
 
 func (c *Context) String(code int, format string, values ...interface{}) error {
@@ -198,10 +501,17 @@ func (c *Context) SetCookie(cookie *http.Cookie) {
 }
 
 func (c *Context) Form(key string) string {
+	if c.ContentType() == "multipart/form-data" {
+		c.parseMultipartForm()
+	}
 	return c.Request.FormValue(key)
 }
 
 func (c *Context) FormFile(key string) (*FileHeader, error) {
+	if err := c.parseMultipartForm(); err != nil {
+		return nil, err
+	}
+	
 	file, header, err := c.Request.FormFile(key)
 	if err != nil {
 		return nil, err
@@ -209,12 +519,12 @@ func (c *Context) FormFile(key string) (*FileHeader, error) {
 	
 	return &FileHeader{
 		FileHeader: header,
-		File:       file,
+		File:       c.trackFile(key, file),
 	}, nil
 }
 
 func (c *Context) FormFiles(key string) ([]*FileHeader, error) {
-	if err := c.Request.ParseMultipartForm(32 << 20); err != nil {
+	if err := c.parseMultipartForm(); err != nil {
 		return nil, err
 	}
 	
@@ -236,26 +546,192 @@ func (c *Context) FormFiles(key string) ([]*FileHeader, error) {
 		
 		files = append(files, &FileHeader{
 			FileHeader: fh,
-			File:       file,
+			File:       c.trackFile(key, file),
 		})
 	}
 	
 	return files, nil
 }
 
-func (c *Context) SaveUploadedFile(fileHeader *FileHeader, uploadDir string) (string, error) {
+func (c *Context) Cleanup() error {
+	var firstErr error
+	for _, file := range c.openFiles {
+		if err := file.Close(); err != nil && firstErr == nil {
+			firstErr = err
+		}
+	}
+	c.openFiles = nil
+	
+	if form := c.Request.MultipartForm; form != nil {
+		if err := form.RemoveAll(); err != nil && firstErr == nil {
+			firstErr = err
+		}
+	}
+	return firstErr
+}
+
+func (c *Context) SaveUploadedFile(fileHeader *FileHeader, uploadDir string, opts ...SaveOptions) (string, error) {
+	result, err := c.SaveUploadedFileResult(fileHeader, uploadDir, opts...)
+	if err != nil {
+		return "", err
+	}
+	
+	return result.Location, nil
+}
+
+func (c *Context) SaveUploadedFileResult(fileHeader *FileHeader, uploadDir string, opts ...SaveOptions) (*UploadResult, error) {
 	if fileHeader == nil {
-		return "", fmt.Errorf("file header is nil")
+		return nil, fmt.Errorf("file header is nil")
 	}
 
-	filename := GenerateUniqueFilename(fileHeader.Filename)
+	pipeline := upload.NewPipeline(uploadDir)
+	if len(opts) > 0 {
+		if opts[0].Namer != nil {
+			pipeline.Namer = opts[0].Namer
+		}
+		pipeline.MaxAttempts = opts[0].MaxAttempts
+		pipeline.Hash = opts[0].Hash
+		pipeline.Deduplicate = opts[0].Deduplicate
+		if opts[0].Scanner != nil {
+			pipeline.Validator = upload.Validation{
+				Scanner:       opts[0].Scanner,
+				ScanAction:    opts[0].ScanAction,
+				QuarantineDir: opts[0].QuarantineDir,
+			}
+		}
+		if len(opts[0].Variants) > 0 || opts[0].BaseURL != "" {
+			pipeline.PostProcess = append(pipeline.PostProcess, upload.ImageProcessor{
+				Variants: opts[0].Variants,
+				BaseURL:  opts[0].BaseURL,
+			})
+		}
+	}
+
+	return pipeline.Process(fileHeader)
+}
 
-	err := SaveFileWithName(fileHeader, uploadDir, filename)
+func (c *Context) Upload(key string, pipeline upload.Pipeline) (*upload.Result, error) {
+	fileHeader, err := c.FormFile(key)
 	if err != nil {
-		return "", err
+		return nil, err
+	}
+	defer fileHeader.File.Close()
+
+	return pipeline.Process(fileHeader)
+}
+
+func (c *Context) StreamUpload(key string, stream *upload.Stream) (*upload.Result, error) {
+	if err := c.limitUpload(); err != nil {
+		return nil, err
 	}
 	
-	return filepath.Join(uploadDir, filename), nil
+	reader, err := c.Request.MultipartReader()
+	if err != nil {
+		return nil, err
+	}
+	c.markBodyRead("StreamUpload")
+
+	for {
+		part, err := reader.NextPart()
+		if err == io.EOF {
+			return nil, FileUploadError{Field: key, Message: "File is required", Code: "required"}
+		}
+		if err != nil {
+			return nil, uploadError(err)
+		}
+
+		if part.FormName() != key || part.FileName() == "" {
+			if part.FileName() == "" && part.FormName() != "" {
+				if err := c.addStreamedField(part); err != nil {
+					part.Close()
+					return nil, uploadError(err)
+				}
+			}
+			part.Close()
+			continue
+		}
+
+		result, err := stream.Process(part)
+		part.Close()
+		if uploadErr, ok := err.(FileUploadError); ok && uploadErr.Field == "" {
+			uploadErr.Field = key
+			return nil, uploadErr
+		}
+		return result, uploadError(err)
+	}
+}
+
+func (c *Context) StreamMultipart(handler func(part *multipart.Part) error) error {
+	if err := c.limitUpload(); err != nil {
+		return err
+	}
+	
+	reader, err := c.Request.MultipartReader()
+	if err != nil {
+		return err
+	}
+	c.markBodyRead("StreamMultipart")
+
+	for {
+		part, err := reader.NextPart()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return uploadError(err)
+		}
+
+		if part.FileName() == "" {
+			if part.FormName() != "" {
+				err = c.addStreamedField(part)
+			}
+		} else {
+			err = handler(part)
+		}
+		part.Close()
+		if err != nil {
+			return uploadError(err)
+		}
+	}
+}
+
+func (c *Context) StreamFiles(stream *upload.Stream) ([]*upload.Result, error) {
+	var results []*upload.Result
+	err := c.StreamMultipart(func(part *multipart.Part) error {
+		result, err := stream.Process(part)
+		if uploadErr, ok := err.(FileUploadError); ok && uploadErr.Field == "" {
+			uploadErr.Field = part.FormName()
+			err = uploadErr
+		}
+		if err != nil {
+			return err
+		}
+		results = append(results, result)
+		return nil
+	})
+	if err != nil {
+		for _, result := range results {
+			stream.Storage.Delete(result.Name)
+		}
+		return nil, err
+	}
+	return results, nil
+}
+
+func (c *Context) addStreamedField(part *multipart.Part) error {
+	value, err := io.ReadAll(io.LimitReader(part, 1<<20))
+	if err != nil {
+		return err
+	}
+	if c.Request.PostForm == nil {
+		c.Request.PostForm = make(url.Values)
+	}
+	if c.Request.Form == nil {
+		c.Request.Form = c.Request.URL.Query()
+	}
+	c.Request.PostForm.Add(part.FormName(), string(value))
+	c.Request.Form.Add(part.FormName(), string(value))
+	return nil
 }
 
 func (c *Context) ValidateFile(fileHeader *FileHeader, validation FileValidation) error {
@@ -278,7 +754,7 @@ func (c *Context) ValidateFiles(fileHeaders []*FileHeader, validation FileValida
 }
 
 func (c *Context) BindMultipart(obj interface{}) error {
-	if err := c.Request.ParseMultipartForm(32 << 20); err != nil {
+	if err := c.parseMultipartForm(); err != nil {
 		return err
 	}
 	
@@ -287,12 +763,29 @@ func (c *Context) BindMultipart(obj interface{}) error {
 		return fmt.Errorf("obj must be a pointer to struct")
 	}
 	
-	rv = rv.Elem()
+	return c.bindMultipartFields(rv.Elem())
+}
+
+func (c *Context) bindMultipartFields(rv reflect.Value) error {
+	var errs ValidationErrors
+	c.collectMultipartFields(rv, &errs)
+	if len(errs) > 0 {
+		return errs
+	}
+	return nil
+}
+
+func (c *Context) collectMultipartFields(rv reflect.Value, errs *ValidationErrors) {
 	rt := rv.Type()
 	
 	for i := 0; i < rv.NumField(); i++ {
 		field := rv.Field(i)
 		fieldType := rt.Field(i)
+
+		if embedded, ok := embeddedStruct(field, fieldType); ok {
+			c.collectMultipartFields(embedded, errs)
+			continue
+		}
 		
 		if !field.CanSet() {
 			continue
@@ -319,17 +812,34 @@ func (c *Context) BindMultipart(obj interface{}) error {
 			continue
 		}
 
+		if field.Kind() == reflect.Slice {
+			if values := c.Request.Form[fieldName]; len(values) > 0 {
+				if err := setSliceValue(field, values, fieldType.Tag.Get("time_format")); err != nil {
+					*errs = append(*errs, conversionError(fieldName, strings.Join(values, ","), field.Type().Elem()))
+				}
+			}
+			continue
+		}
+
 		formValue := c.Request.FormValue(fieldName)
 		if formValue == "" {
 			continue
 		}
 		
-		if err := setFieldValue(field, formValue); err != nil {
-			return fmt.Errorf("invalid value for field %s: %v", fieldType.Name, err)
+		if err := setFieldValue(field, formValue, fieldType.Tag.Get("time_format")); err != nil {
+			*errs = append(*errs, conversionError(fieldName, formValue, field.Type()))
 		}
 	}
-	
-	return nil
+}
+
+func conversionError(field string, value string, expected reflect.Type) ValidationError {
+	return ValidationError{
+		Field:   field,
+		Value:   value,
+		Tag:     "type",
+		Param:   expected.String(),
+		Message: fmt.Sprintf("invalid value, expected %s", expected),
+	}
 }
 
 func (c *Context) GetUploadedFileInfo(key string) (map[string]interface{}, error) {
@@ -352,6 +862,9 @@ func (c *Context) setParam(key, value string) {
 		c.params = make(map[string]string)
 	}
 	decoded, _ := url.QueryUnescape(value)
+	if _, exists := c.params[key]; !exists {
+		c.paramKeys = append(c.paramKeys, key)
+	}
 	c.params[key] = decoded
 }
 
@@ -0,0 +1,230 @@
+package goify
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"net/http"
+	"strings"
+	"time"
+)
+
+var (
+	ErrCookieKeysNotSet = errors.New("goify: cookie keys are not configured")
+	ErrInvalidCookie    = errors.New("goify: invalid cookie signature or value")
+)
+
+type cookieKey struct {
+	sign    []byte
+	encrypt cipher.AEAD
+}
+
+func (rt *Router) SetCookieKeys(keys ...[]byte) error {
+	parsed := make([]cookieKey, 0, len(keys))
+	for _, key := range keys {
+		if len(key) < 16 {
+			return errors.New("goify: cookie key must be at least 16 bytes")
+		}
+
+		block, err := aes.NewCipher(deriveKey(key, "encrypt"))
+		if err != nil {
+			return err
+		}
+		aead, err := cipher.NewGCM(block)
+		if err != nil {
+			return err
+		}
+
+		parsed = append(parsed, cookieKey{
+			sign:    deriveKey(key, "sign"),
+			encrypt: aead,
+		})
+	}
+
+	rt.cookieKeys = parsed
+	return nil
+}
+
+func deriveKey(key []byte, purpose string) []byte {
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte("goify-cookie-" + purpose))
+	return mac.Sum(nil)
+}
+
+func (c *Context) cookieKeys() ([]cookieKey, error) {
+	if c.router == nil || len(c.router.cookieKeys) == 0 {
+		return nil, ErrCookieKeysNotSet
+	}
+	return c.router.cookieKeys, nil
+}
+
+func signCookie(key []byte, name, payload string) string {
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(name + "|" + payload))
+	return base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
+
+func (c *Context) SetSignedCookie(cookie *http.Cookie) error {
+	keys, err := c.cookieKeys()
+	if err != nil {
+		return err
+	}
+
+	signed := *cookie
+	payload := base64.RawURLEncoding.EncodeToString([]byte(cookie.Value))
+	signed.Value = payload + "." + signCookie(keys[0].sign, cookie.Name, payload)
+	c.SetCookie(&signed)
+	return nil
+}
+
+func (c *Context) GetSignedCookie(name string) (string, error) {
+	keys, err := c.cookieKeys()
+	if err != nil {
+		return "", err
+	}
+
+	cookie, err := c.Cookie(name)
+	if err != nil {
+		return "", err
+	}
+
+	payload, signature, ok := strings.Cut(cookie.Value, ".")
+	if !ok {
+		return "", ErrInvalidCookie
+	}
+
+	for _, key := range keys {
+		if hmac.Equal([]byte(signature), []byte(signCookie(key.sign, name, payload))) {
+			value, err := base64.RawURLEncoding.DecodeString(payload)
+			if err != nil {
+				return "", ErrInvalidCookie
+			}
+			return string(value), nil
+		}
+	}
+
+	return "", ErrInvalidCookie
+}
+
+func (c *Context) SetEncryptedCookie(cookie *http.Cookie) error {
+	keys, err := c.cookieKeys()
+	if err != nil {
+		return err
+	}
+
+	aead := keys[0].encrypt
+	nonce := make([]byte, aead.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return err
+	}
+
+	encrypted := *cookie
+	sealed := aead.Seal(nonce, nonce, []byte(cookie.Value), []byte(cookie.Name))
+	encrypted.Value = base64.RawURLEncoding.EncodeToString(sealed)
+	c.SetCookie(&encrypted)
+	return nil
+}
+
+func (c *Context) GetEncryptedCookie(name string) (string, error) {
+	keys, err := c.cookieKeys()
+	if err != nil {
+		return "", err
+	}
+
+	cookie, err := c.Cookie(name)
+	if err != nil {
+		return "", err
+	}
+
+	sealed, err := base64.RawURLEncoding.DecodeString(cookie.Value)
+	if err != nil {
+		return "", ErrInvalidCookie
+	}
+
+	for _, key := range keys {
+		nonceSize := key.encrypt.NonceSize()
+		if len(sealed) < nonceSize {
+			return "", ErrInvalidCookie
+		}
+		if value, err := key.encrypt.Open(nil, sealed[:nonceSize], sealed[nonceSize:], []byte(name)); err == nil {
+			return string(value), nil
+		}
+	}
+
+	return "", ErrInvalidCookie
+}
+
+type CookieOptions struct {
+	Path        string
+	Domain      string
+	MaxAge      int
+	Expires     time.Time
+	SameSite    http.SameSite
+	Secure      bool
+	AllowScript bool
+	Signed      bool
+	Encrypted   bool
+}
+
+func (c *Context) SetCookieValue(name, value string, opts ...CookieOptions) error {
+	var options CookieOptions
+	if len(opts) > 0 {
+		options = opts[0]
+	}
+
+	cookie := &http.Cookie{
+		Name:     name,
+		Value:    value,
+		Path:     options.Path,
+		Domain:   options.Domain,
+		MaxAge:   options.MaxAge,
+		Expires:  options.Expires,
+		SameSite: options.SameSite,
+		Secure:   options.Secure || c.Request.TLS != nil,
+		HttpOnly: !options.AllowScript,
+	}
+	if cookie.Path == "" {
+		cookie.Path = "/"
+	}
+	if cookie.SameSite == 0 {
+		cookie.SameSite = http.SameSiteLaxMode
+	}
+	if cookie.SameSite == http.SameSiteNoneMode {
+		cookie.Secure = true
+	}
+
+	switch {
+	case options.Encrypted:
+		return c.SetEncryptedCookie(cookie)
+	case options.Signed:
+		return c.SetSignedCookie(cookie)
+	}
+
+	c.SetCookie(cookie)
+	return nil
+}
+
+func (c *Context) DeleteCookie(name string, opts ...CookieOptions) {
+	var options CookieOptions
+	if len(opts) > 0 {
+		options = opts[0]
+	}
+
+	path := options.Path
+	if path == "" {
+		path = "/"
+	}
+
+	c.SetCookie(&http.Cookie{
+		Name:    name,
+		Value:   "",
+		Path:    path,
+		Domain:  options.Domain,
+		MaxAge:  -1,
+		Expires: time.Unix(0, 0),
+	})
+}
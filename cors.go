@@ -0,0 +1,158 @@
+package goify
+
+import (
+	"net/http"
+	"strings"
+)
+
+type CORSConfig struct {
+	AllowOrigins []string
+	AllowMethods []string
+	AllowHeaders []string
+}
+
+type corsPolicy struct {
+	config CORSConfig
+	prefix string
+	host   *hostTable
+}
+
+func CORS() MiddlewareFunc {
+	return CORSWithConfig(CORSConfig{
+		AllowOrigins: []string{"*"},
+		AllowMethods: []string{"GET", "POST", "PUT", "DELETE", "PATCH", "OPTIONS"},
+		AllowHeaders: []string{"Content-Type", "Authorization"},
+	})
+}
+
+func CORSWithConfig(config CORSConfig) MiddlewareFunc {
+	return newCORSPolicy(config).middleware()
+}
+
+func (rg *RouterGroup) CORS(config CORSConfig) {
+	policy := newCORSPolicy(config)
+	policy.prefix = strings.TrimSuffix(cleanPath(rg.prefix), "/")
+	policy.host = rg.host
+
+	rg.router.corsPolicies = append(rg.router.corsPolicies, policy)
+	rg.Use(policy.middleware())
+}
+
+func newCORSPolicy(config CORSConfig) *corsPolicy {
+	if len(config.AllowOrigins) == 0 {
+		config.AllowOrigins = []string{"*"}
+	}
+	return &corsPolicy{config: config}
+}
+
+func (p *corsPolicy) middleware() MiddlewareFunc {
+	return func(c *Context, next func()) {
+		if c.Request.Method == http.MethodOptions && c.router != nil {
+			if scoped := c.router.corsPolicyFor(c); scoped != nil && scoped != p {
+				next()
+				return
+			}
+		}
+
+		p.apply(c)
+
+		if c.Request.Method == http.MethodOptions {
+			c.Response.WriteHeader(http.StatusNoContent)
+			return
+		}
+
+		next()
+	}
+}
+
+func (rt *Router) preflightHandler(c *Context) {
+	policy := rt.corsPolicyFor(c)
+	if policy == nil {
+		notFoundHandler(c)
+		return
+	}
+
+	policy.apply(c)
+	c.Response.WriteHeader(http.StatusNoContent)
+}
+
+func (p *corsPolicy) apply(c *Context) {
+	header := c.Response.Header()
+	header.Del("Access-Control-Allow-Origin")
+	header.Del("Access-Control-Allow-Methods")
+	header.Del("Access-Control-Allow-Headers")
+
+	origin := c.GetHeader("Origin")
+	if p.allowsOrigin(origin) {
+		if origin != "" {
+			header.Set("Access-Control-Allow-Origin", origin)
+			addVary(header, "Origin")
+		} else if len(p.config.AllowOrigins) == 1 && p.config.AllowOrigins[0] == "*" {
+			header.Set("Access-Control-Allow-Origin", "*")
+		}
+	}
+
+	if len(p.config.AllowMethods) > 0 {
+		header.Set("Access-Control-Allow-Methods", strings.Join(p.config.AllowMethods, ", "))
+	}
+	if len(p.config.AllowHeaders) > 0 {
+		header.Set("Access-Control-Allow-Headers", strings.Join(p.config.AllowHeaders, ", "))
+	}
+}
+
+func (p *corsPolicy) allowsOrigin(origin string) bool {
+	for _, allowed := range p.config.AllowOrigins {
+		if allowed == "*" || strings.EqualFold(allowed, origin) || matchOriginPattern(allowed, origin) {
+			return true
+		}
+	}
+	return false
+}
+
+func matchOriginPattern(pattern, origin string) bool {
+	star := strings.Index(pattern, "*")
+	if star < 0 || origin == "" {
+		return false
+	}
+
+	prefix := strings.ToLower(pattern[:star])
+	suffix := strings.ToLower(pattern[star+1:])
+	origin = strings.ToLower(origin)
+	if len(origin) <= len(prefix)+len(suffix) || !strings.HasPrefix(origin, prefix) || !strings.HasSuffix(origin, suffix) {
+		return false
+	}
+
+	middle := origin[len(prefix) : len(origin)-len(suffix)]
+	return !strings.ContainsAny(middle, "/:@?#")
+}
+
+func addVary(header http.Header, value string) {
+	for _, existing := range header.Values("Vary") {
+		for _, field := range strings.Split(existing, ",") {
+			if strings.EqualFold(strings.TrimSpace(field), value) {
+				return
+			}
+		}
+	}
+	header.Add("Vary", value)
+}
+
+func (rt *Router) corsPolicyFor(c *Context) *corsPolicy {
+	path := cleanPath(c.Request.URL.Path)
+
+	var best *corsPolicy
+	for _, policy := range rt.corsPolicies {
+		if policy.host != nil {
+			if _, _, ok := policy.host.match(strings.Split(normalizeHost(c.Request.Host), ".")); !ok {
+				continue
+			}
+		}
+		if policy.prefix != "" && path != policy.prefix && !strings.HasPrefix(path, policy.prefix+"/") {
+			continue
+		}
+		if best == nil || len(policy.prefix) > len(best.prefix) || (policy.host != nil && best.host == nil && len(policy.prefix) == len(best.prefix)) {
+			best = policy
+		}
+	}
+	return best
+}
@@ -0,0 +1,146 @@
+package goify
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"time"
+)
+
+var (
+	ErrInvalidCursor    = errors.New("goify: invalid cursor")
+	ErrExpiredCursor    = errors.New("goify: cursor has expired")
+	ErrCursorNotEnabled = errors.New("goify: cursor signer is not configured")
+)
+
+type CursorSigner struct {
+	TTL  time.Duration
+	keys []cipher.AEAD
+}
+
+type cursorPayload struct {
+	Value     json.RawMessage `json:"v"`
+	ExpiresAt int64           `json:"x,omitempty"`
+}
+
+func NewCursorSigner(keys ...[]byte) (*CursorSigner, error) {
+	if len(keys) == 0 {
+		return nil, errors.New("goify: at least one cursor key is required")
+	}
+
+	signer := &CursorSigner{}
+	for _, key := range keys {
+		if len(key) < 16 {
+			return nil, errors.New("goify: cursor key must be at least 16 bytes")
+		}
+
+		block, err := aes.NewCipher(deriveKey(key, "cursor"))
+		if err != nil {
+			return nil, err
+		}
+		aead, err := cipher.NewGCM(block)
+		if err != nil {
+			return nil, err
+		}
+		signer.keys = append(signer.keys, aead)
+	}
+
+	return signer, nil
+}
+
+func (s *CursorSigner) Encode(v interface{}) (string, error) {
+	value, err := json.Marshal(v)
+	if err != nil {
+		return "", err
+	}
+
+	payload := cursorPayload{Value: value}
+	if s.TTL > 0 {
+		payload.ExpiresAt = time.Now().Add(s.TTL).Unix()
+	}
+
+	plaintext, err := json.Marshal(payload)
+	if err != nil {
+		return "", err
+	}
+
+	aead := s.keys[0]
+	nonce := make([]byte, aead.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return "", err
+	}
+
+	sealed := aead.Seal(nonce, nonce, plaintext, []byte("goify-cursor"))
+	return base64.RawURLEncoding.EncodeToString(sealed), nil
+}
+
+func (s *CursorSigner) Decode(token string, v interface{}) error {
+	sealed, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return ErrInvalidCursor
+	}
+
+	for _, aead := range s.keys {
+		nonceSize := aead.NonceSize()
+		if len(sealed) < nonceSize {
+			return ErrInvalidCursor
+		}
+
+		plaintext, err := aead.Open(nil, sealed[:nonceSize], sealed[nonceSize:], []byte("goify-cursor"))
+		if err != nil {
+			continue
+		}
+
+		var payload cursorPayload
+		if err := json.Unmarshal(plaintext, &payload); err != nil {
+			return ErrInvalidCursor
+		}
+		if payload.ExpiresAt > 0 && time.Now().Unix() > payload.ExpiresAt {
+			return ErrExpiredCursor
+		}
+		if err := json.Unmarshal(payload.Value, v); err != nil {
+			return ErrInvalidCursor
+		}
+		return nil
+	}
+
+	return ErrInvalidCursor
+}
+
+func (rt *Router) SetCursorSigner(signer *CursorSigner) {
+	rt.cursors = signer
+}
+
+func (c *Context) cursorSigner() (*CursorSigner, error) {
+	if c.router == nil || c.router.cursors == nil {
+		return nil, ErrCursorNotEnabled
+	}
+	return c.router.cursors, nil
+}
+
+func (c *Context) EncodeCursor(v interface{}) (string, error) {
+	signer, err := c.cursorSigner()
+	if err != nil {
+		return "", err
+	}
+	return signer.Encode(v)
+}
+
+func (c *Context) DecodeCursor(token string, v interface{}) error {
+	signer, err := c.cursorSigner()
+	if err != nil {
+		return err
+	}
+	return signer.Decode(token, v)
+}
+
+func (c *Context) QueryCursor(key string, v interface{}) error {
+	token := c.Query(key)
+	if token == "" {
+		return nil
+	}
+	return c.DecodeCursor(token, v)
+}
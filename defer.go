@@ -0,0 +1,56 @@
+package goify
+
+import (
+	"context"
+	"net/http"
+	"runtime/debug"
+)
+
+func (c *Context) Defer(fn func(ctx context.Context)) {
+	c.deferred = append(c.deferred, fn)
+}
+
+func (c *Context) runDeferred() {
+	if len(c.deferred) == 0 {
+		return
+	}
+
+	if flusher, ok := c.Response.(http.Flusher); ok && c.Written() && !c.ClientGone() {
+		flusher.Flush()
+	}
+
+	tasks := c.deferred
+	c.deferred = nil
+	ctx := context.WithoutCancel(c.Request.Context())
+	logger := c.Logger()
+
+	c.router.deferred.Add(1)
+	go func() {
+		defer c.router.deferred.Done()
+		for _, task := range tasks {
+			func() {
+				defer func() {
+					if err := recover(); err != nil {
+						logger.Error("deferred task panicked", "error", err, "stack", string(debug.Stack()))
+					}
+				}()
+				task(ctx)
+			}()
+		}
+	}()
+}
+
+func (rt *Router) WaitDeferred(ctx context.Context) error {
+	done := make(chan struct{})
+	go func() {
+		rt.deferred.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
@@ -0,0 +1,98 @@
+package goify
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+)
+
+type dependency struct {
+	name  string
+	hard  bool
+	entry *healthEntry
+}
+
+func (rt *Router) Requires(name string, checker HealthChecker, timeout ...time.Duration) {
+	rt.addDependency(name, checker, true, timeout)
+}
+
+func (rt *Router) Wants(name string, checker HealthChecker, timeout ...time.Duration) {
+	rt.addDependency(name, checker, false, timeout)
+}
+
+func (rt *Router) addDependency(name string, checker HealthChecker, hard bool, timeout []time.Duration) {
+	dep := &dependency{name: name, hard: hard, entry: newHealthEntry(name, checker, !hard, timeout)}
+	rt.dependencies = append(rt.dependencies, dep)
+	rt.health.add(dep.entry)
+}
+
+func (rt *Router) CheckDependencies() (HealthStatus, map[string]HealthCheck) {
+	entries := make(map[string]*healthEntry, len(rt.dependencies))
+	for _, dep := range rt.dependencies {
+		entries[dep.name] = dep.entry
+	}
+
+	status := StatusHealthy
+	checks := runHealthChecks(entries, rt.health.defaultTimeout())
+
+	for _, dep := range rt.dependencies {
+		check := checks[dep.name]
+		if dep.hard && check.Status == StatusUnhealthy {
+			status = StatusUnhealthy
+		} else if check.Status != StatusHealthy && status == StatusHealthy {
+			status = StatusDegraded
+		}
+	}
+
+	return status, checks
+}
+
+func (rt *Router) WaitForDependencies(ctx context.Context, interval ...time.Duration) error {
+	every := time.Second
+	if len(interval) > 0 && interval[0] > 0 {
+		every = interval[0]
+	}
+
+	ticker := time.NewTicker(every)
+	defer ticker.Stop()
+
+	for {
+		status, checks := rt.CheckDependencies()
+		if status != StatusUnhealthy {
+			for name, check := range checks {
+				if check.Status != StatusHealthy {
+					rt.Logger().Warn("optional dependency is not healthy", "dependency", name, "status", check.Status, "message", check.Message)
+				}
+			}
+			return nil
+		}
+
+		var pending []string
+		for _, dep := range rt.dependencies {
+			if dep.hard && checks[dep.name].Status == StatusUnhealthy {
+				pending = append(pending, dep.name)
+			}
+		}
+		rt.Logger().Info("waiting for dependencies", "pending", pending)
+
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("dependencies not ready (%s): %w", strings.Join(pending, ", "), ctx.Err())
+		case <-ticker.C:
+		}
+	}
+}
+
+func (rt *Router) ReadinessHandler() HandlerFunc {
+	return func(c *Context) {
+		status, checks := rt.CheckDependencies()
+
+		code := 200
+		if status == StatusUnhealthy {
+			code = 503
+		}
+
+		c.JSON(code, rt.health.response(status, checks))
+	}
+}
@@ -0,0 +1,128 @@
+package goify
+
+import (
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+type DownloadOptions struct {
+	Inline       bool
+	ContentType  string
+	ModTime      time.Time
+	CacheControl string
+	ETag         string
+}
+
+func (c *Context) Download(path, filename string, opts ...DownloadOptions) error {
+	return c.serveFile(path, filename, "attachment", opts)
+}
+
+func (c *Context) DownloadFrom(root, name string, opts ...DownloadOptions) error {
+	path, err := SecureJoin(root, name)
+	if err != nil {
+		c.SendNotFound("File not found")
+		return err
+	}
+	return c.Download(path, "", opts...)
+}
+
+func (c *Context) DownloadReader(r io.ReadSeeker, filename string, opts ...DownloadOptions) error {
+	var options DownloadOptions
+	if len(opts) > 0 {
+		options = opts[0]
+	}
+	return c.serveContent(r, filename, "attachment", options)
+}
+
+func (c *Context) serveFile(path, filename, disposition string, opts []DownloadOptions) error {
+	file, err := os.Open(path)
+	if err != nil {
+		c.SendNotFound("File not found")
+		return err
+	}
+	defer file.Close()
+
+	info, err := file.Stat()
+	if err != nil {
+		c.SendInternalError("Failed to read file")
+		return err
+	}
+	if info.IsDir() {
+		c.SendNotFound("File not found")
+		return fmt.Errorf("%s is a directory", path)
+	}
+
+	if filename == "" {
+		filename = info.Name()
+	}
+
+	var options DownloadOptions
+	if len(opts) > 0 {
+		options = opts[0]
+	}
+	if options.ModTime.IsZero() {
+		options.ModTime = info.ModTime()
+	}
+	if options.ETag == "" {
+		options.ETag = fileETag(info.Size(), options.ModTime)
+	}
+
+	return c.serveContent(file, filename, disposition, options)
+}
+
+func (c *Context) serveContent(r io.ReadSeeker, filename, disposition string, options DownloadOptions) error {
+	if options.Inline {
+		disposition = "inline"
+	}
+	if disposition != "" {
+		if filename != "" {
+			c.SetHeader("Content-Disposition", mime.FormatMediaType(disposition, map[string]string{"filename": filepath.Base(filename)}))
+		} else {
+			c.SetHeader("Content-Disposition", disposition)
+		}
+	}
+
+	if options.ContentType == "" {
+		options.ContentType = LookupMimeType(filename)
+	}
+	if options.ContentType != "" {
+		c.SetHeader("Content-Type", options.ContentType)
+	}
+	if options.CacheControl != "" {
+		c.SetHeader("Cache-Control", options.CacheControl)
+	}
+
+	if options.ETag == "" && !options.ModTime.IsZero() {
+		if size, err := r.Seek(0, io.SeekEnd); err == nil {
+			options.ETag = fileETag(size, options.ModTime)
+		}
+		if _, err := r.Seek(0, io.SeekStart); err != nil {
+			c.SendInternalError("Failed to read file")
+			return err
+		}
+	}
+	if options.ETag != "" {
+		c.SetHeader("ETag", quoteETag(options.ETag))
+	}
+	c.SetHeader("Accept-Ranges", "bytes")
+
+	http.ServeContent(c.Response, c.Request, filename, options.ModTime, r)
+	return nil
+}
+
+func fileETag(size int64, modTime time.Time) string {
+	return fmt.Sprintf(`"%x-%x"`, modTime.UnixNano(), size)
+}
+
+func quoteETag(etag string) string {
+	if strings.HasPrefix(etag, `"`) || strings.HasPrefix(etag, `W/"`) {
+		return etag
+	}
+	return `"` + etag + `"`
+}
@@ -0,0 +1,144 @@
+package goify
+
+import (
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"net/http"
+)
+
+type HTTPError struct {
+	Code    int
+	Message string
+	Details interface{}
+	Err     error
+}
+
+func NewHTTPError(code int, message string, details ...interface{}) *HTTPError {
+	httpErr := &HTTPError{Code: code, Message: message}
+	if len(details) > 0 {
+		httpErr.Details = details[0]
+	}
+	return httpErr
+}
+
+func (e *HTTPError) Error() string {
+	if e.Err != nil {
+		return fmt.Sprintf("%d %s: %v", e.Code, e.Message, e.Err)
+	}
+	return fmt.Sprintf("%d %s", e.Code, e.Message)
+}
+
+func (e *HTTPError) Unwrap() error {
+	return e.Err
+}
+
+func (e *HTTPError) Wrap(err error) *HTTPError {
+	wrapped := *e
+	wrapped.Err = err
+	return &wrapped
+}
+
+type ErrorHandler func(c *Context, err error)
+
+func (rt *Router) SetErrorHandler(handler ErrorHandler) {
+	rt.errorHandler = handler
+}
+
+func Handle(fn func(*Context) error) HandlerFunc {
+	return func(c *Context) {
+		if err := fn(c); err != nil {
+			c.Error(err)
+		}
+	}
+}
+
+func (c *Context) Error(err error) {
+	if err == nil || err == c.err {
+		return
+	}
+	c.err = err
+
+	if c.writer != nil && c.writer.writeErr != nil && errors.Is(err, c.writer.writeErr) {
+		return
+	}
+
+	if c.inError {
+		c.writeErrorFallback()
+		return
+	}
+	c.inError = true
+	defer func() { c.inError = false }()
+
+	if c.router != nil && c.router.errorHandler != nil {
+		c.router.errorHandler(c, err)
+		return
+	}
+	DefaultErrorHandler(c, err)
+}
+
+func (c *Context) Err() error {
+	return c.err
+}
+
+func DefaultErrorHandler(c *Context, err error) {
+	if c.Written() {
+		c.Logger().Debug("handler error after response was sent",
+			"error", err,
+			"method", c.Request.Method,
+			"path", c.routeTemplate(),
+		)
+		return
+	}
+
+	var httpErr *HTTPError
+	var validationErrors ValidationErrors
+	var requestErrors *RequestErrors
+	var uploadErrors FileUploadErrors
+	var uploadErr FileUploadError
+	var maxBytesError *http.MaxBytesError
+	var encodeErr *EncodeError
+	switch {
+	case errors.As(err, &httpErr):
+		if httpErr.Code >= 500 {
+			c.Logger().Error("handler error", "error", err, "method", c.Request.Method, "path", c.routeTemplate())
+		}
+		c.SendError(httpErr.Code, httpErr.Message, httpErr.Details)
+	case errors.As(err, &validationErrors):
+		c.SendValidationError(validationErrors)
+	case errors.As(err, &requestErrors):
+		c.SendValidationError(requestErrors)
+	case errors.As(err, &uploadErrors):
+		c.SendFileUploadError(uploadErrors)
+	case errors.As(err, &uploadErr):
+		c.SendFileUploadError(uploadErr)
+	case errors.As(err, &maxBytesError):
+		c.SendError(http.StatusRequestEntityTooLarge, "Request body too large", H{"limit": FormatFileSize(maxBytesError.Limit)})
+	case errors.Is(err, ErrUnsupportedMediaType):
+		c.SendError(http.StatusUnsupportedMediaType, err.Error())
+	case errors.Is(err, sql.ErrNoRows), errors.Is(err, fs.ErrNotExist):
+		c.SendNotFound()
+	case errors.As(err, &encodeErr):
+		c.writeErrorFallback()
+	default:
+		c.Logger().Error("handler error", "error", err, "method", c.Request.Method, "path", c.routeTemplate())
+		c.SendInternalError()
+	}
+}
+
+func (c *Context) writeErrorFallback() {
+	if c.Written() {
+		return
+	}
+
+	body, _ := json.Marshal(ErrorResponse{
+		Error:   http.StatusText(http.StatusInternalServerError),
+		Message: "Internal server error",
+		Code:    http.StatusInternalServerError,
+	})
+	c.SetHeader("Content-Type", "application/json")
+	c.Response.WriteHeader(http.StatusInternalServerError)
+	c.Response.Write(append(body, '\n'))
+}
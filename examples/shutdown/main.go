@@ -14,15 +14,15 @@ func main() {
 
 	goify.SetAppInfo("1.0.0", "production")
 
-	goify.RegisterHealthCheck("database", goify.DatabaseHealthCheck(func() error {
+	app.Requires("database", goify.DatabaseHealthCheck(func() error {
 		if rand.Float32() < 0.1 {
 			return fmt.Errorf("database connection timeout")
 		}
 		return nil
 	}))
 
-	goify.RegisterHealthCheck("memory", goify.MemoryHealthCheck(100))
-	goify.RegisterHealthCheck("disk", goify.DiskSpaceHealthCheck("/", 1))
+	app.Wants("memory", goify.MemoryHealthCheck(100))
+	app.RegisterHealthCheck("disk", goify.DiskSpaceHealthCheck("/", 1))
 
 	app.Use(goify.Logger())
 	app.Use(goify.Recovery())
@@ -34,7 +34,7 @@ func main() {
 		})
 	})
 
-	app.GET("/health", goify.HealthCheckHandler())
+	app.GET("/health", app.HealthCheckHandler())
 
 	app.GET("/liveness", func(c *goify.Context) {
 		c.JSON(200, goify.H{
@@ -43,13 +43,7 @@ func main() {
 		})
 	})
 
-	app.GET("/readiness", func(c *goify.Context) {
-		response := goify.HealthResponse{
-			Status:    goify.StatusHealthy,
-			Timestamp: time.Now(),
-		}
-		c.JSON(200, response)
-	})
+	app.GET("/readiness", app.ReadinessHandler())
 
 	app.GET("/slow", func(c *goify.Context) {
 		time.Sleep(5 * time.Second)
@@ -68,9 +62,9 @@ func main() {
 		log.Println("Закрытие соединений с базой данных...")
 	})
 
-	config := goify.ShutdownConfig{
-		Timeout: 10 * time.Second,
-	}
+	config := goify.DefaultShutdownConfig()
+	config.Timeout = 10 * time.Second
+	config.DependencyTimeout = 30 * time.Second
 
 	log.Println("🚀 Сервер запущен с graceful shutdown поддержкой!")
 	log.Println("Endpoints:")
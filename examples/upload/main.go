@@ -339,7 +339,11 @@ func main() {
 
 	app.GET("/files/:filename", func(c *goify.Context) {
 		filename := c.Param("filename")
-		filePath := filepath.Join(uploadDir, filename)
+		filePath, err := goify.SecureJoin(uploadDir, filename)
+		if err != nil {
+			c.SendNotFound("File not found")
+			return
+		}
 
 		if !goify.FileExists(filePath) {
 			c.SendNotFound("File not found")
@@ -361,7 +365,11 @@ func main() {
 
 	app.DELETE("/files/:filename", func(c *goify.Context) {
 		filename := c.Param("filename")
-		filePath := filepath.Join(uploadDir, filename)
+		filePath, err := goify.SecureJoin(uploadDir, filename)
+		if err != nil {
+			c.SendNotFound("File not found")
+			return
+		}
 
 		if !goify.FileExists(filePath) {
 			c.SendNotFound("File not found")
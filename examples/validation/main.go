@@ -15,7 +15,7 @@ type User struct {
 	Email    string `json:"email" validate:"required,email"`
 	Age      int    `json:"age" validate:"required,min=18,max=120"`
 	Username string `json:"username" validate:"required,min=3,max=20,alphanum"`
-	Website  string `json:"website,omitempty" validate:"url"`
+	Website  string `json:"website,omitempty" validate:"omitempty,url"`
 	Role     string `json:"role" validate:"required,oneof=admin user moderator"`
 }
 
@@ -0,0 +1,54 @@
+package goify
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/VsRnA/goify/upload"
+)
+
+type (
+	SizeUnits     = upload.SizeUnits
+	Locale        = upload.Locale
+	FormatOptions = upload.FormatOptions
+)
+
+const (
+	UnitsJEDEC = upload.UnitsJEDEC
+	UnitsIEC   = upload.UnitsIEC
+	UnitsSI    = upload.UnitsSI
+)
+
+func RegisterLocale(name string, locale Locale) {
+	upload.RegisterLocale(name, locale)
+}
+
+func SetFormatDefaults(opts FormatOptions) {
+	upload.SetFormatDefaults(opts)
+}
+
+func FormatFileSize(size int64, opts ...FormatOptions) string {
+	return upload.FormatFileSize(size, opts...)
+}
+
+func FormatDuration(d time.Duration, opts ...FormatOptions) string {
+	_, locale := upload.ResolveFormat(opts...)
+
+	days := int(d.Hours()) / 24
+	hours := int(d.Hours()) % 24
+	minutes := int(d.Minutes()) % 60
+	seconds := int(d.Seconds()) % 60
+
+	parts := []string{fmt.Sprintf("%d%s", seconds, locale.Second)}
+	if days > 0 || hours > 0 || minutes > 0 {
+		parts = append([]string{fmt.Sprintf("%d%s", minutes, locale.Minute)}, parts...)
+	}
+	if days > 0 || hours > 0 {
+		parts = append([]string{fmt.Sprintf("%d%s", hours, locale.Hour)}, parts...)
+	}
+	if days > 0 {
+		parts = append([]string{fmt.Sprintf("%d%s", days, locale.Day)}, parts...)
+	}
+	return strings.Join(parts, " ")
+}
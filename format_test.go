@@ -0,0 +1,59 @@
+package goify
+
+import (
+	"mime/multipart"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/VsRnA/goify/upload"
+)
+
+func TestFormatFileSize(t *testing.T) {
+	cases := []struct {
+		size int64
+		opts []FormatOptions
+		want string
+	}{
+		{512, nil, "512 B"},
+		{1536, nil, "1.5 KB"},
+		{1048576, nil, "1.0 MB"},
+		{1536, []FormatOptions{{Units: UnitsIEC}}, "1.5 KiB"},
+		{1536, []FormatOptions{{Units: UnitsSI}}, "1.5 kB"},
+		{1536, []FormatOptions{{Locale: "ru", Units: UnitsIEC}}, "1,5 КиБ"},
+		{1536, []FormatOptions{{Locale: "ru-RU"}}, "1,5 КБ"},
+		{1536, []FormatOptions{{Locale: "xx", Precision: 2}}, "1.50 KB"},
+	}
+
+	for _, tc := range cases {
+		if got := FormatFileSize(tc.size, tc.opts...); got != tc.want {
+			t.Errorf("FormatFileSize(%d, %+v) = %q, want %q", tc.size, tc.opts, got, tc.want)
+		}
+	}
+}
+
+func TestFormatDuration(t *testing.T) {
+	if got := FormatDuration(3*time.Hour + 5*time.Second); got != "3h 0m 5s" {
+		t.Errorf("unexpected duration %q", got)
+	}
+	if got := FormatDuration(3*time.Hour+5*time.Second, FormatOptions{Locale: "ru"}); got != "3 ч 0 мин 5 с" {
+		t.Errorf("unexpected localized duration %q", got)
+	}
+}
+
+func TestUploadSizesFollowFormatDefaults(t *testing.T) {
+	SetFormatDefaults(FormatOptions{Locale: "ru", Units: UnitsIEC})
+	defer SetFormatDefaults(FormatOptions{Locale: "en", Units: UnitsJEDEC, Precision: 1})
+
+	if got := Size(1536).String(); got != "1,5 КиБ" {
+		t.Fatalf("Size.String() = %q, want localized size", got)
+	}
+	if got, want := upload.FormatFileSize(1536), FormatFileSize(1536); got != want {
+		t.Fatalf("upload.FormatFileSize = %q, goify.FormatFileSize = %q", got, want)
+	}
+
+	err := upload.Validate(&upload.FileHeader{FileHeader: &multipart.FileHeader{Size: 4096}}, upload.Validation{MaxSize: 2048})
+	if err == nil || !strings.Contains(err.Error(), "2,0 КиБ") {
+		t.Fatalf("expected localized size in validation error, got %v", err)
+	}
+}
@@ -0,0 +1,198 @@
+package goify
+
+import (
+	"mime/multipart"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+type FormFileInfo struct {
+	Filename    string `json:"filename"`
+	Size        int64  `json:"size"`
+	ContentType string `json:"content_type,omitempty"`
+}
+
+// maxFormKeyDepth limits bracket nesting in form keys. Deeper keys are kept
+// as literal names so that hostile input cannot exhaust the stack.
+const maxFormKeyDepth = 64
+
+type formNode struct {
+	children map[string]interface{}
+	next     int
+}
+
+func newFormNode() *formNode {
+	return &formNode{children: make(map[string]interface{})}
+}
+
+func FormToMap(values url.Values, files map[string][]*multipart.FileHeader) map[string]interface{} {
+	root := newFormNode()
+
+	for _, key := range sortedKeys(values) {
+		path := parseFormKey(key)
+		vals := values[key]
+		if path[len(path)-1] == "" || len(vals) == 1 {
+			for _, value := range vals {
+				root.insert(path, value)
+			}
+			continue
+		}
+		list := make([]interface{}, len(vals))
+		for i, value := range vals {
+			list[i] = value
+		}
+		root.insert(path, list)
+	}
+
+	fileKeys := make([]string, 0, len(files))
+	for key := range files {
+		fileKeys = append(fileKeys, key)
+	}
+	sort.Strings(fileKeys)
+
+	for _, key := range fileKeys {
+		path := parseFormKey(key)
+		headers := files[key]
+		if path[len(path)-1] == "" || len(headers) == 1 {
+			for _, header := range headers {
+				root.insert(path, fileInfo(header))
+			}
+			continue
+		}
+		list := make([]interface{}, len(headers))
+		for i, header := range headers {
+			list[i] = fileInfo(header)
+		}
+		root.insert(path, list)
+	}
+
+	return root.object()
+}
+
+func (c *Context) FormMap() (map[string]interface{}, error) {
+	if c.ContentType() == "multipart/form-data" {
+		if err := c.parseMultipartForm(); err != nil {
+			return nil, err
+		}
+		return FormToMap(c.Request.MultipartForm.Value, c.Request.MultipartForm.File), nil
+	}
+
+	if err := c.Request.ParseForm(); err != nil {
+		return nil, err
+	}
+	return FormToMap(c.Request.PostForm, nil), nil
+}
+
+func (c *Context) FormJSON() ([]byte, error) {
+	form, err := c.FormMap()
+	if err != nil {
+		return nil, err
+	}
+	return marshalJSON(form)
+}
+
+func fileInfo(header *multipart.FileHeader) FormFileInfo {
+	return FormFileInfo{
+		Filename:    header.Filename,
+		Size:        header.Size,
+		ContentType: header.Header.Get("Content-Type"),
+	}
+}
+
+func sortedKeys(values url.Values) []string {
+	keys := make([]string, 0, len(values))
+	for key := range values {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func parseFormKey(key string) []string {
+	open := strings.Index(key, "[")
+	if open <= 0 || !strings.HasSuffix(key, "]") {
+		return []string{key}
+	}
+
+	path := []string{key[:open]}
+	rest := key[open:]
+	for rest != "" {
+		if rest[0] != '[' {
+			return []string{key}
+		}
+		end := strings.Index(rest, "]")
+		if end < 0 {
+			return []string{key}
+		}
+		if len(path) > maxFormKeyDepth {
+			return []string{key}
+		}
+		path = append(path, rest[1:end])
+		rest = rest[end+1:]
+	}
+	return path
+}
+
+func (n *formNode) insert(path []string, value interface{}) {
+	key := path[0]
+	if key == "" {
+		key = strconv.Itoa(n.next)
+	}
+	if index, err := strconv.Atoi(key); err == nil && index >= n.next {
+		n.next = index + 1
+	}
+
+	if len(path) == 1 {
+		n.children[key] = value
+		return
+	}
+
+	child, ok := n.children[key].(*formNode)
+	if !ok {
+		child = newFormNode()
+		n.children[key] = child
+	}
+	child.insert(path[1:], value)
+}
+
+func (n *formNode) object() map[string]interface{} {
+	result := make(map[string]interface{}, len(n.children))
+	for key, value := range n.children {
+		result[key] = n.resolve(value)
+	}
+	return result
+}
+
+func (n *formNode) resolve(value interface{}) interface{} {
+	child, ok := value.(*formNode)
+	if !ok {
+		return value
+	}
+	if indexes, ok := child.indexes(); ok {
+		list := make([]interface{}, len(indexes))
+		for i, index := range indexes {
+			list[i] = child.resolve(child.children[strconv.Itoa(index)])
+		}
+		return list
+	}
+	return child.object()
+}
+
+func (n *formNode) indexes() ([]int, bool) {
+	if len(n.children) == 0 {
+		return nil, false
+	}
+
+	indexes := make([]int, 0, len(n.children))
+	for key := range n.children {
+		index, err := strconv.Atoi(key)
+		if err != nil || index < 0 || strconv.Itoa(index) != key {
+			return nil, false
+		}
+		indexes = append(indexes, index)
+	}
+	sort.Ints(indexes)
+	return indexes, true
+}
@@ -0,0 +1,112 @@
+package goify
+
+import (
+	"bytes"
+	"encoding/json"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"net/textproto"
+	"net/url"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestParseFormKey(t *testing.T) {
+	cases := []struct {
+		key  string
+		want []string
+	}{
+		{"name", []string{"name"}},
+		{"user[name]", []string{"user", "name"}},
+		{"user[address][city]", []string{"user", "address", "city"}},
+		{"tags[]", []string{"tags", ""}},
+		{"items[0][id]", []string{"items", "0", "id"}},
+		{"[name]", []string{"[name]"}},
+		{"user[name", []string{"user[name"}},
+		{"user[a]x[b]", []string{"user[a]x[b]"}},
+	}
+
+	for _, tc := range cases {
+		if got := parseFormKey(tc.key); !reflect.DeepEqual(got, tc.want) {
+			t.Errorf("parseFormKey(%q) = %q, want %q", tc.key, got, tc.want)
+		}
+	}
+}
+
+func TestFormToMap(t *testing.T) {
+	values, err := url.ParseQuery("user[name]=Bob&user[address][city]=Paris&tags[]=a&tags[]=b&items[1][id]=2&items[0][id]=1&color=red&color=blue")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := json.Marshal(FormToMap(values, nil))
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := `{"color":["red","blue"],"items":[{"id":"1"},{"id":"2"}],"tags":["a","b"],"user":{"address":{"city":"Paris"},"name":"Bob"}}`
+	if string(got) != want {
+		t.Fatalf("FormToMap = %s\nwant %s", got, want)
+	}
+}
+
+func TestFormToMapKeepsDeepKeysLiteral(t *testing.T) {
+	deep := "a" + strings.Repeat("[x]", maxFormKeyDepth+1)
+	atLimit := "b" + strings.Repeat("[x]", maxFormKeyDepth)
+
+	form := FormToMap(url.Values{deep: {"1"}, atLimit: {"2"}}, nil)
+	if form[deep] != "1" {
+		t.Fatalf("expected key deeper than the limit to stay literal, got %v", form[deep])
+	}
+	if _, ok := form["b"].(map[string]interface{}); !ok {
+		t.Fatalf("expected key at the limit to be nested, got %T", form["b"])
+	}
+}
+
+func TestFormToMapHugeKeyDoesNotCrash(t *testing.T) {
+	key := "a" + strings.Repeat("[x]", 3<<20)
+	if form := FormToMap(url.Values{key: {"v"}}, nil); form[key] != "v" {
+		t.Fatal("expected the oversized key to be kept literally")
+	}
+}
+
+func TestFormMapMultipart(t *testing.T) {
+	var body bytes.Buffer
+	writer := multipart.NewWriter(&body)
+	writer.WriteField("doc[title]", "Report")
+
+	header := make(textproto.MIMEHeader)
+	header.Set("Content-Disposition", `form-data; name="doc[files][]"; filename="report.pdf"`)
+	header.Set("Content-Type", "application/pdf")
+	part, err := writer.CreatePart(header)
+	if err != nil {
+		t.Fatal(err)
+	}
+	part.Write([]byte("%PDF-1.4"))
+	writer.Close()
+
+	rt := New()
+	var form map[string]interface{}
+	rt.POST("/upload", func(c *Context) {
+		var err error
+		if form, err = c.FormMap(); err != nil {
+			t.Errorf("FormMap: %v", err)
+		}
+		c.Status(http.StatusOK)
+	})
+
+	req := httptest.NewRequest(http.MethodPost, "/upload", &body)
+	req.Header.Set("Content-Type", writer.FormDataContentType())
+	rt.ServeHTTP(httptest.NewRecorder(), req)
+
+	doc, _ := form["doc"].(map[string]interface{})
+	files, _ := doc["files"].([]interface{})
+	if doc["title"] != "Report" || len(files) != 1 {
+		t.Fatalf("unexpected form: %v", form)
+	}
+	info, _ := files[0].(FormFileInfo)
+	if info.Filename != "report.pdf" || info.Size != 8 || info.ContentType != "application/pdf" {
+		t.Fatalf("unexpected file info: %+v", files[0])
+	}
+}
@@ -0,0 +1,444 @@
+package goify
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/VsRnA/goify/upload"
+)
+
+type replayKey struct{}
+
+// forwardCredentialHeaders are never written to a store unless they are
+// listed in StoreForwardConfig.Headers.
+var forwardCredentialHeaders = []string{"Authorization", "Proxy-Authorization", "Cookie"}
+
+var ErrForwardQueueFull = errors.New("goify: store-and-forward queue is full")
+
+type QueuedRequest struct {
+	ID       string      `json:"id"`
+	Method   string      `json:"method"`
+	URL      string      `json:"url"`
+	Header   http.Header `json:"header"`
+	Body     []byte      `json:"body"`
+	QueuedAt time.Time   `json:"queued_at"`
+	Attempts int         `json:"attempts"`
+}
+
+type ForwardStore interface {
+	Push(req *QueuedRequest) error
+	Pop() (*QueuedRequest, error)
+	Len() int
+}
+
+type StoreForwardConfig struct {
+	Dependency    string
+	Check         HealthChecker
+	Methods       []string
+	Headers       []string
+	MaxQueue      int
+	MaxBodySize   int64
+	CheckInterval time.Duration
+	RetryInterval time.Duration
+	MaxAttempts   int
+	Store         ForwardStore
+	OnDrop        func(req *QueuedRequest, err error)
+}
+
+type StoreAndForward struct {
+	config    StoreForwardConfig
+	mu        sync.Mutex
+	router    *Router
+	replaying bool
+	healthy   bool
+	checkedAt time.Time
+}
+
+func DefaultStoreForwardConfig() StoreForwardConfig {
+	return StoreForwardConfig{
+		Methods:       []string{http.MethodPut, http.MethodDelete},
+		MaxQueue:      1000,
+		MaxBodySize:   1 << 20,
+		CheckInterval: time.Second,
+		RetryInterval: 5 * time.Second,
+		MaxAttempts:   5,
+	}
+}
+
+func NewStoreAndForward(config StoreForwardConfig) *StoreAndForward {
+	defaults := DefaultStoreForwardConfig()
+	if len(config.Methods) == 0 {
+		config.Methods = defaults.Methods
+	}
+	if config.MaxQueue <= 0 {
+		config.MaxQueue = defaults.MaxQueue
+	}
+	if config.MaxBodySize <= 0 {
+		config.MaxBodySize = defaults.MaxBodySize
+	}
+	if config.CheckInterval <= 0 {
+		config.CheckInterval = defaults.CheckInterval
+	}
+	if config.RetryInterval <= 0 {
+		config.RetryInterval = defaults.RetryInterval
+	}
+	if config.MaxAttempts <= 0 {
+		config.MaxAttempts = defaults.MaxAttempts
+	}
+	if config.Store == nil {
+		config.Store = NewMemoryForwardStore(config.MaxQueue)
+	}
+
+	return &StoreAndForward{config: config}
+}
+
+func (sf *StoreAndForward) Len() int {
+	return sf.config.Store.Len()
+}
+
+func (sf *StoreAndForward) Middleware() MiddlewareFunc {
+	return func(c *Context, next func()) {
+		if c.ReplayID() != "" {
+			next()
+			return
+		}
+
+		if !sf.matches(c.Request.Method) || sf.dependencyHealthy(c.router) {
+			next()
+			return
+		}
+
+		body, err := io.ReadAll(io.LimitReader(c.Request.Body, sf.config.MaxBodySize+1))
+		if err != nil || int64(len(body)) > sf.config.MaxBodySize {
+			c.SendError(http.StatusServiceUnavailable, "Dependency unavailable")
+			return
+		}
+
+		id, err := upload.NewUUID()
+		if err != nil {
+			c.SendError(http.StatusServiceUnavailable, "Dependency unavailable")
+			return
+		}
+
+		queued := &QueuedRequest{
+			ID:       id,
+			Method:   c.Request.Method,
+			URL:      c.Request.URL.RequestURI(),
+			Header:   sf.queuedHeader(c.Request.Header),
+			Body:     body,
+			QueuedAt: time.Now(),
+		}
+
+		if err := sf.config.Store.Push(queued); err != nil {
+			c.SendError(http.StatusServiceUnavailable, "Dependency unavailable", H{"reason": err.Error()})
+			return
+		}
+
+		sf.Resume(c.router)
+
+		c.SendAccepted(H{"id": queued.ID}, "Request queued for delivery")
+	}
+}
+
+func (sf *StoreAndForward) matches(method string) bool {
+	for _, m := range sf.config.Methods {
+		if strings.EqualFold(m, method) {
+			return true
+		}
+	}
+	return false
+}
+
+func (sf *StoreAndForward) queuedHeader(header http.Header) http.Header {
+	if len(sf.config.Headers) == 0 {
+		kept := header.Clone()
+		for _, name := range forwardCredentialHeaders {
+			kept.Del(name)
+		}
+		return kept
+	}
+
+	kept := make(http.Header, len(sf.config.Headers))
+	for _, name := range sf.config.Headers {
+		if values := header.Values(name); len(values) > 0 {
+			kept[http.CanonicalHeaderKey(name)] = append([]string(nil), values...)
+		}
+	}
+	return kept
+}
+
+func (sf *StoreAndForward) dependencyHealthy(rt *Router) bool {
+	sf.mu.Lock()
+	defer sf.mu.Unlock()
+
+	if time.Since(sf.checkedAt) < sf.config.CheckInterval {
+		return sf.healthy
+	}
+
+	if rt == nil {
+		rt = sf.router
+	}
+
+	var check HealthCheck
+	var found bool
+	if sf.config.Check != nil {
+		check, found = sf.config.Check(), true
+	} else {
+		check, found = rt.Health().Run(sf.config.Dependency)
+	}
+
+	sf.healthy = !found || check.Status != StatusUnhealthy
+	sf.checkedAt = time.Now()
+	return sf.healthy
+}
+
+func (sf *StoreAndForward) Resume(rt *Router) {
+	if rt == nil {
+		return
+	}
+
+	sf.mu.Lock()
+	sf.router = rt
+	if sf.replaying {
+		sf.mu.Unlock()
+		return
+	}
+	sf.replaying = true
+	sf.mu.Unlock()
+
+	go sf.replayLoop()
+}
+
+func (sf *StoreAndForward) replayLoop() {
+	ticker := time.NewTicker(sf.config.RetryInterval)
+	defer ticker.Stop()
+
+	for range ticker.C {
+		if sf.dependencyHealthy(nil) {
+			sf.drain()
+		}
+
+		sf.mu.Lock()
+		if sf.config.Store.Len() == 0 {
+			sf.replaying = false
+			sf.mu.Unlock()
+			return
+		}
+		sf.mu.Unlock()
+	}
+}
+
+func (sf *StoreAndForward) drain() {
+	for pending := sf.config.Store.Len(); pending > 0; pending-- {
+		queued, err := sf.config.Store.Pop()
+		if err != nil || queued == nil {
+			return
+		}
+
+		status, err := sf.replay(queued)
+		if err == nil && status < http.StatusInternalServerError {
+			continue
+		}
+
+		queued.Attempts++
+		if err == nil {
+			err = fmt.Errorf("replay returned status %d", status)
+		}
+
+		if queued.Attempts >= sf.config.MaxAttempts {
+			sf.drop(queued, err)
+			continue
+		}
+		if pushErr := sf.config.Store.Push(queued); pushErr != nil {
+			sf.drop(queued, pushErr)
+		}
+		return
+	}
+}
+
+func (sf *StoreAndForward) replay(queued *QueuedRequest) (int, error) {
+	req, err := http.NewRequest(queued.Method, queued.URL, bytes.NewReader(queued.Body))
+	if err != nil {
+		return 0, err
+	}
+	req.Header = queued.Header.Clone()
+	req.RequestURI = queued.URL
+	req = req.WithContext(context.WithValue(req.Context(), replayKey{}, queued.ID))
+
+	recorder := httptest.NewRecorder()
+	sf.mu.Lock()
+	router := sf.router
+	sf.mu.Unlock()
+	router.ServeHTTP(recorder, req)
+
+	return recorder.Code, nil
+}
+
+func (c *Context) ReplayID() string {
+	id, _ := c.Request.Context().Value(replayKey{}).(string)
+	return id
+}
+
+func (sf *StoreAndForward) drop(queued *QueuedRequest, err error) {
+	if sf.config.OnDrop != nil {
+		sf.config.OnDrop(queued, err)
+		return
+	}
+	sf.router.Logger().Warn("store-and-forward: dropping request",
+		"method", queued.Method,
+		"url", queued.URL,
+		"id", queued.ID,
+		"attempts", queued.Attempts,
+		"error", err,
+	)
+}
+
+type MemoryForwardStore struct {
+	mu    sync.Mutex
+	queue []*QueuedRequest
+	limit int
+}
+
+func NewMemoryForwardStore(limit int) *MemoryForwardStore {
+	return &MemoryForwardStore{limit: limit}
+}
+
+func (s *MemoryForwardStore) Push(req *QueuedRequest) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.limit > 0 && len(s.queue) >= s.limit {
+		return ErrForwardQueueFull
+	}
+	s.queue = append(s.queue, req)
+	return nil
+}
+
+func (s *MemoryForwardStore) Pop() (*QueuedRequest, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if len(s.queue) == 0 {
+		return nil, nil
+	}
+	req := s.queue[0]
+	s.queue = s.queue[1:]
+	return req, nil
+}
+
+func (s *MemoryForwardStore) Len() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return len(s.queue)
+}
+
+type FileForwardStore struct {
+	mu    sync.Mutex
+	dir   string
+	limit int
+}
+
+func NewFileForwardStore(dir string, limit ...int) (*FileForwardStore, error) {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, fmt.Errorf("failed to create forward store directory: %v", err)
+	}
+	store := &FileForwardStore{dir: dir}
+	if len(limit) > 0 {
+		store.limit = limit[0]
+	}
+	return store, nil
+}
+
+func (s *FileForwardStore) Push(req *QueuedRequest) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.limit > 0 {
+		files, err := s.files()
+		if err != nil {
+			return err
+		}
+		if len(files) >= s.limit {
+			return ErrForwardQueueFull
+		}
+	}
+
+	data, err := json.Marshal(req)
+	if err != nil {
+		return err
+	}
+
+	name := fmt.Sprintf("%020d-%s.json", time.Now().UnixNano(), req.ID)
+	tmp := filepath.Join(s.dir, name+".tmp")
+	if err := os.WriteFile(tmp, data, 0600); err != nil {
+		return err
+	}
+	return os.Rename(tmp, filepath.Join(s.dir, name))
+}
+
+func (s *FileForwardStore) Pop() (*QueuedRequest, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	files, err := s.files()
+	if err != nil {
+		return nil, err
+	}
+
+	for _, name := range files {
+		path := filepath.Join(s.dir, name)
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return nil, err
+		}
+
+		var req QueuedRequest
+		if err := json.Unmarshal(data, &req); err != nil {
+			slog.Default().Error("store-and-forward: moving unreadable entry aside", "file", path+".bad", "error", err)
+			if err := os.Rename(path, path+".bad"); err != nil {
+				return nil, err
+			}
+			continue
+		}
+
+		if err := os.Remove(path); err != nil {
+			return nil, err
+		}
+		return &req, nil
+	}
+	return nil, nil
+}
+
+func (s *FileForwardStore) Len() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	files, _ := s.files()
+	return len(files)
+}
+
+func (s *FileForwardStore) files() ([]string, error) {
+	entries, err := os.ReadDir(s.dir)
+	if err != nil {
+		return nil, err
+	}
+
+	var files []string
+	for _, entry := range entries {
+		if !entry.IsDir() && strings.HasSuffix(entry.Name(), ".json") {
+			files = append(files, entry.Name())
+		}
+	}
+	sort.Strings(files)
+	return files, nil
+}
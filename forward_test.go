@@ -0,0 +1,93 @@
+package goify
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func queueOneRequest(t *testing.T, config StoreForwardConfig) *QueuedRequest {
+	t.Helper()
+
+	store := NewMemoryForwardStore(10)
+	config.Check = func() HealthCheck { return HealthCheck{Status: StatusUnhealthy} }
+	config.Store = store
+	config.RetryInterval = time.Hour
+	sf := NewStoreAndForward(config)
+
+	rt := New()
+	rt.Use(sf.Middleware())
+	rt.PUT("/items/:id", func(c *Context) {
+		t.Error("handler must not run while the dependency is down")
+	})
+
+	req := httptest.NewRequest(http.MethodPut, "/items/1", strings.NewReader(`{"a":1}`))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", "Bearer secret")
+	req.Header.Set("Cookie", "session=secret")
+	req.Header.Set("X-Tenant", "acme")
+	w := httptest.NewRecorder()
+	rt.ServeHTTP(w, req)
+
+	if w.Code != http.StatusAccepted {
+		t.Fatalf("expected 202, got %d", w.Code)
+	}
+	queued, err := store.Pop()
+	if err != nil || queued == nil {
+		t.Fatalf("expected a queued request, got %v %v", queued, err)
+	}
+	return queued
+}
+
+func TestStoreAndForwardDropsCredentialsByDefault(t *testing.T) {
+	queued := queueOneRequest(t, StoreForwardConfig{})
+
+	if queued.Header.Get("Authorization") != "" || queued.Header.Get("Cookie") != "" {
+		t.Fatalf("credentials were queued: %v", queued.Header)
+	}
+	if queued.Header.Get("X-Tenant") != "acme" || queued.Header.Get("Content-Type") != "application/json" {
+		t.Fatalf("regular headers were dropped: %v", queued.Header)
+	}
+	if string(queued.Body) != `{"a":1}` {
+		t.Fatalf("unexpected body %q", queued.Body)
+	}
+}
+
+func TestStoreAndForwardHeaderAllowlist(t *testing.T) {
+	queued := queueOneRequest(t, StoreForwardConfig{Headers: []string{"x-tenant", "Authorization"}})
+
+	if len(queued.Header) != 2 || queued.Header.Get("X-Tenant") != "acme" || queued.Header.Get("Authorization") != "Bearer secret" {
+		t.Fatalf("unexpected queued headers: %v", queued.Header)
+	}
+}
+
+func TestFileForwardStoreKeepsCorruptEntries(t *testing.T) {
+	dir := t.TempDir()
+	store, err := NewFileForwardStore(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	corrupt := filepath.Join(dir, "00000000000000000000-broken.json")
+	if err := os.WriteFile(corrupt, []byte("{not json"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	if err := store.Push(&QueuedRequest{ID: "good", Method: http.MethodPut, URL: "/items/1"}); err != nil {
+		t.Fatal(err)
+	}
+
+	queued, err := store.Pop()
+	if err != nil || queued == nil || queued.ID != "good" {
+		t.Fatalf("expected the valid entry, got %+v %v", queued, err)
+	}
+	if _, err := os.Stat(corrupt + ".bad"); err != nil {
+		t.Fatalf("corrupt entry was not moved aside: %v", err)
+	}
+	if store.Len() != 0 {
+		t.Fatalf("expected empty queue, got %d", store.Len())
+	}
+}
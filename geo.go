@@ -0,0 +1,104 @@
+package goify
+
+import (
+	"net"
+	"sync"
+	"time"
+)
+
+type GeoLocation struct {
+	CountryCode string  `json:"country_code"`
+	Country     string  `json:"country,omitempty"`
+	Region      string  `json:"region,omitempty"`
+	City        string  `json:"city,omitempty"`
+	Latitude    float64 `json:"latitude,omitempty"`
+	Longitude   float64 `json:"longitude,omitempty"`
+}
+
+type GeoResolver interface {
+	Resolve(ip net.IP) (*GeoLocation, error)
+}
+
+type GeoResolverFunc func(ip net.IP) (*GeoLocation, error)
+
+func (f GeoResolverFunc) Resolve(ip net.IP) (*GeoLocation, error) {
+	return f(ip)
+}
+
+type GeoConfig struct {
+	Resolver  GeoResolver
+	CacheTTL  time.Duration
+	CacheSize int
+	OnError   func(c *Context, err error)
+}
+
+type geoCacheEntry struct {
+	location  *GeoLocation
+	expiresAt time.Time
+}
+
+func Geo(resolver GeoResolver) MiddlewareFunc {
+	return GeoWithConfig(GeoConfig{Resolver: resolver, CacheTTL: 10 * time.Minute, CacheSize: 10000})
+}
+
+func GeoWithConfig(config GeoConfig) MiddlewareFunc {
+	var mu sync.Mutex
+	cache := make(map[string]geoCacheEntry)
+
+	return func(c *Context, next func()) {
+		ip := c.ClientIP()
+
+		if config.CacheTTL > 0 {
+			mu.Lock()
+			entry, ok := cache[ip]
+			mu.Unlock()
+
+			if ok && time.Now().Before(entry.expiresAt) {
+				if entry.location != nil {
+					c.Set("geo", entry.location)
+				}
+				next()
+				return
+			}
+		}
+
+		location, err := config.Resolver.Resolve(net.ParseIP(ip))
+		if err != nil {
+			if config.OnError != nil {
+				config.OnError(c, err)
+			}
+			location = nil
+		}
+
+		if config.CacheTTL > 0 && err == nil {
+			mu.Lock()
+			if config.CacheSize > 0 && len(cache) >= config.CacheSize {
+				cache = make(map[string]geoCacheEntry)
+			}
+			cache[ip] = geoCacheEntry{location: location, expiresAt: time.Now().Add(config.CacheTTL)}
+			mu.Unlock()
+		}
+
+		if location != nil {
+			c.Set("geo", location)
+		}
+
+		next()
+	}
+}
+
+func (c *Context) Geo() *GeoLocation {
+	if value, exists := c.Get("geo"); exists {
+		if location, ok := value.(*GeoLocation); ok {
+			return location
+		}
+	}
+	return nil
+}
+
+func (c *Context) Country() string {
+	if location := c.Geo(); location != nil {
+		return location.CountryCode
+	}
+	return ""
+}
@@ -2,6 +2,7 @@ package goify
 
 type RouterGroup struct {
 	router *Router
+	host *hostTable
 	prefix string
 	middleware []MiddlewareFunc
 }
@@ -17,6 +18,7 @@ func (rt *Router) Group (prefix string) *RouterGroup {
 func (rg *RouterGroup) Group (prefix string) *RouterGroup {
 	return &RouterGroup{
 		router:	rg.router,
+		host: rg.host,
 		prefix: rg.prefix + prefix,
 		middleware: append([]MiddlewareFunc{}, rg.middleware...),
 	}
@@ -26,34 +28,47 @@ func (rg *RouterGroup) Use(middleware ...MiddlewareFunc) {
 	rg.middleware = append(rg.middleware, middleware...)
 }
 
-func (rg *RouterGroup) GET(path string, handler HandlerFunc) {
-	rg.addRoute("GET", path, handler)
+func (rg *RouterGroup) GET(path string, handler HandlerFunc) *Route {
+	return rg.addRoute("GET", path, handler)
 }
 
-func (rg *RouterGroup) POST(path string, handler HandlerFunc) {
-	rg.addRoute("POST", path, handler)
+func (rg *RouterGroup) POST(path string, handler HandlerFunc) *Route {
+	return rg.addRoute("POST", path, handler)
 }
 
-func (rg *RouterGroup) PUT(path string, handler HandlerFunc) {
-	rg.addRoute("PUT", path, handler)
+func (rg *RouterGroup) PUT(path string, handler HandlerFunc) *Route {
+	return rg.addRoute("PUT", path, handler)
 }
 
-func (rg *RouterGroup) DELETE(path string, handler HandlerFunc) {
-	rg.addRoute("DELETE", path, handler)
+func (rg *RouterGroup) DELETE(path string, handler HandlerFunc) *Route {
+	return rg.addRoute("DELETE", path, handler)
 }
 
-func (rg *RouterGroup) PATCH(path string, handler HandlerFunc) {
-	rg.addRoute("PATCH", path, handler)
+func (rg *RouterGroup) PATCH(path string, handler HandlerFunc) *Route {
+	return rg.addRoute("PATCH", path, handler)
 }
 
-func (rg *RouterGroup) addRoute(method, path string, handler HandlerFunc) {
+func (rg *RouterGroup) HEAD(path string, handler HandlerFunc) *Route {
+	return rg.addRoute("HEAD", path, handler)
+}
+
+func (rg *RouterGroup) Any(path string, handler HandlerFunc) {
+	for _, method := range anyMethods {
+		rg.addRoute(method, path, handler)
+	}
+}
+
+func (rg *RouterGroup) addRoute(method, path string, handler HandlerFunc) *Route {
 	fullPath := rg.prefix + path
 
 	wrappedHandler := func(c *Context) {
 		rg.executeGroupMiddleware(c, handler)
 	}
 	
-	rg.router.addRoute(method, fullPath, wrappedHandler)
+	if rg.host != nil {
+		return rg.router.insertRoute(rg.host.routes, rg.host.tree, rg.host.pattern, method, fullPath, wrappedHandler)
+	}
+	return rg.router.addRoute(method, fullPath, wrappedHandler)
 }
 
 func (rg *RouterGroup) executeGroupMiddleware(ctx *Context, handler HandlerFunc) {
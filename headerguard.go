@@ -0,0 +1,104 @@
+package goify
+
+import (
+	"net/http"
+	"sync"
+)
+
+const (
+	RejectTooManyHeaders = "too_many_headers"
+	RejectHeaderTooLong  = "header_too_long"
+)
+
+type HeaderGuardConfig struct {
+	MaxHeaderCount       int
+	MaxHeaderValueLength int
+	OnReject             func(c *Context, reason string)
+}
+
+type HeaderGuard struct {
+	config HeaderGuardConfig
+	mu     sync.Mutex
+	stats  map[string]int64
+}
+
+func DefaultHeaderGuardConfig() HeaderGuardConfig {
+	return HeaderGuardConfig{
+		MaxHeaderCount:       100,
+		MaxHeaderValueLength: 8 << 10,
+	}
+}
+
+func NewHeaderGuard(config ...HeaderGuardConfig) *HeaderGuard {
+	cfg := DefaultHeaderGuardConfig()
+	if len(config) > 0 {
+		cfg = config[0]
+	}
+
+	return &HeaderGuard{
+		config: cfg,
+		stats:  make(map[string]int64),
+	}
+}
+
+func (hg *HeaderGuard) Stats() map[string]int64 {
+	hg.mu.Lock()
+	defer hg.mu.Unlock()
+
+	stats := make(map[string]int64, len(hg.stats))
+	for reason, count := range hg.stats {
+		stats[reason] = count
+	}
+	return stats
+}
+
+func (hg *HeaderGuard) Middleware() MiddlewareFunc {
+	return func(c *Context, next func()) {
+		if reason := hg.check(c.Request); reason != "" {
+			hg.mu.Lock()
+			hg.stats[reason]++
+			hg.mu.Unlock()
+
+			if hg.config.OnReject != nil {
+				hg.config.OnReject(c, reason)
+			}
+
+			c.SetHeader("Connection", "close")
+			c.SendBadRequest("Malformed request headers", H{"reason": reason})
+			return
+		}
+
+		next()
+	}
+}
+
+// check only covers limits that net/http leaves to the application. The
+// server itself answers 400 to conflicting or malformed Content-Length
+// values and to invalid header names or values, 501 to transfer codings
+// other than chunked, and drops Content-Length when Transfer-Encoding is
+// present, all before any middleware runs.
+func (hg *HeaderGuard) check(req *http.Request) string {
+	header := req.Header
+
+	if hg.config.MaxHeaderCount > 0 {
+		count := 0
+		for _, values := range header {
+			count += len(values)
+		}
+		if count > hg.config.MaxHeaderCount {
+			return RejectTooManyHeaders
+		}
+	}
+
+	if hg.config.MaxHeaderValueLength > 0 {
+		for _, values := range header {
+			for _, value := range values {
+				if len(value) > hg.config.MaxHeaderValueLength {
+					return RejectHeaderTooLong
+				}
+			}
+		}
+	}
+
+	return ""
+}
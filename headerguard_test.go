@@ -0,0 +1,128 @@
+package goify
+
+import (
+	"bufio"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func newHeaderGuardServer(t *testing.T, config HeaderGuardConfig) (*HeaderGuard, *httptest.Server) {
+	t.Helper()
+
+	guard := NewHeaderGuard(config)
+	rt := New()
+	rt.Use(guard.Middleware())
+	rt.POST("/", func(c *Context) {
+		c.String(http.StatusOK, "ok")
+	})
+	rt.GET("/", func(c *Context) {
+		c.String(http.StatusOK, "ok")
+	})
+
+	srv := httptest.NewServer(rt)
+	t.Cleanup(srv.Close)
+	return guard, srv
+}
+
+// sendRaw writes an unmodified HTTP/1.1 request so that the server sees the
+// exact header bytes, and returns the response status code.
+func sendRaw(t *testing.T, srv *httptest.Server, raw string) int {
+	t.Helper()
+
+	conn, err := net.Dial("tcp", srv.Listener.Addr().String())
+	if err != nil {
+		t.Fatalf("dial: %v", err)
+	}
+	defer conn.Close()
+	conn.SetDeadline(time.Now().Add(5 * time.Second))
+
+	if _, err := conn.Write([]byte(raw)); err != nil {
+		t.Fatalf("write: %v", err)
+	}
+	resp, err := http.ReadResponse(bufio.NewReader(conn), nil)
+	if err != nil {
+		t.Fatalf("read response: %v", err)
+	}
+	resp.Body.Close()
+	return resp.StatusCode
+}
+
+func TestHeaderGuardRejectsTooManyHeaders(t *testing.T) {
+	guard, srv := newHeaderGuardServer(t, HeaderGuardConfig{MaxHeaderCount: 5})
+
+	var b strings.Builder
+	b.WriteString("GET / HTTP/1.1\r\nHost: example.com\r\n")
+	for i := 0; i < 10; i++ {
+		b.WriteString("X-Extra: value\r\n")
+	}
+	b.WriteString("\r\n")
+
+	if code := sendRaw(t, srv, b.String()); code != http.StatusBadRequest {
+		t.Fatalf("expected 400, got %d", code)
+	}
+	if stats := guard.Stats(); stats[RejectTooManyHeaders] != 1 {
+		t.Fatalf("expected one too_many_headers rejection, got %v", stats)
+	}
+}
+
+func TestHeaderGuardRejectsLongHeaderValue(t *testing.T) {
+	var rejected string
+	guard, srv := newHeaderGuardServer(t, HeaderGuardConfig{
+		MaxHeaderValueLength: 16,
+		OnReject:             func(c *Context, reason string) { rejected = reason },
+	})
+
+	raw := "GET / HTTP/1.1\r\nHost: example.com\r\nX-Long: " + strings.Repeat("a", 64) + "\r\n\r\n"
+	if code := sendRaw(t, srv, raw); code != http.StatusBadRequest {
+		t.Fatalf("expected 400, got %d", code)
+	}
+	if rejected != RejectHeaderTooLong || guard.Stats()[RejectHeaderTooLong] != 1 {
+		t.Fatalf("expected header_too_long rejection, got %q %v", rejected, guard.Stats())
+	}
+}
+
+func TestHeaderGuardAllowsNormalRequests(t *testing.T) {
+	guard, srv := newHeaderGuardServer(t, DefaultHeaderGuardConfig())
+
+	raw := "POST / HTTP/1.1\r\nHost: example.com\r\nContent-Length: 5\r\n\r\nhello"
+	if code := sendRaw(t, srv, raw); code != http.StatusOK {
+		t.Fatalf("expected 200, got %d", code)
+	}
+	if stats := guard.Stats(); len(stats) != 0 {
+		t.Fatalf("expected no rejections, got %v", stats)
+	}
+}
+
+// The cases below never reach HeaderGuard: net/http handles them while
+// reading the request, which is why the guard does not check them.
+func TestHeaderGuardLeavesFramingToNetHTTP(t *testing.T) {
+	guard, srv := newHeaderGuardServer(t, DefaultHeaderGuardConfig())
+
+	cases := []struct {
+		name string
+		raw  string
+		code int
+	}{
+		{"conflicting content length", "POST / HTTP/1.1\r\nHost: x\r\nContent-Length: 5\r\nContent-Length: 6\r\n\r\nhello", http.StatusBadRequest},
+		{"invalid content length", "POST / HTTP/1.1\r\nHost: x\r\nContent-Length: abc\r\n\r\n", http.StatusBadRequest},
+		{"unsupported transfer encoding", "POST / HTTP/1.1\r\nHost: x\r\nTransfer-Encoding: gzip\r\n\r\n", http.StatusNotImplemented},
+		{"invalid header name", "GET / HTTP/1.1\r\nHost: x\r\nBad Name: v\r\n\r\n", http.StatusBadRequest},
+		{"invalid header value", "GET / HTTP/1.1\r\nHost: x\r\nX-A: a\x01b\r\n\r\n", http.StatusBadRequest},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			if code := sendRaw(t, srv, tc.raw); code != tc.code {
+				t.Fatalf("expected %d, got %d", tc.code, code)
+			}
+		})
+	}
+
+	if stats := guard.Stats(); len(stats) != 0 {
+		t.Fatalf("net/http rejections should not reach the guard, got %v", stats)
+	}
+}
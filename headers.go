@@ -0,0 +1,115 @@
+package goify
+
+import (
+	"net/http"
+	"strings"
+)
+
+var hopByHopHeaders = []string{
+	"Connection",
+	"Keep-Alive",
+	"Proxy-Authenticate",
+	"Proxy-Authorization",
+	"Proxy-Connection",
+	"TE",
+	"Trailer",
+	"Transfer-Encoding",
+	"Upgrade",
+}
+
+type ResponseHeadersConfig struct {
+	Strip     []string
+	Allow     []string
+	HopByHop  bool
+	Normalize bool
+}
+
+func DefaultResponseHeadersConfig() ResponseHeadersConfig {
+	return ResponseHeadersConfig{
+		Strip:     []string{"X-Internal-*"},
+		HopByHop:  true,
+		Normalize: true,
+	}
+}
+
+func StripHeaders(patterns ...string) MiddlewareFunc {
+	return ResponseHeaders(ResponseHeadersConfig{Strip: patterns})
+}
+
+func ResponseHeaders(config ...ResponseHeadersConfig) MiddlewareFunc {
+	cfg := DefaultResponseHeadersConfig()
+	if len(config) > 0 {
+		cfg = config[0]
+	}
+
+	return func(c *Context, next func()) {
+		filter := func() {
+			filterResponseHeaders(c.Response.Header(), cfg)
+		}
+
+		c.onBeforeWrite(filter)
+		next()
+
+		if !c.Written() {
+			filter()
+		}
+	}
+}
+
+func filterResponseHeaders(header http.Header, cfg ResponseHeadersConfig) {
+	if cfg.Normalize {
+		for key, values := range header {
+			canonical := http.CanonicalHeaderKey(strings.TrimSpace(key))
+			trimmed := make([]string, 0, len(values))
+			for _, value := range values {
+				trimmed = append(trimmed, strings.TrimSpace(value))
+			}
+			if canonical != key {
+				delete(header, key)
+				trimmed = append(header[canonical], trimmed...)
+			}
+			header[canonical] = trimmed
+		}
+	}
+
+	if cfg.HopByHop {
+		for _, value := range header.Values("Connection") {
+			for _, name := range strings.Split(value, ",") {
+				if name = strings.TrimSpace(name); name != "" && !strings.EqualFold(name, "close") {
+					header.Del(name)
+				}
+			}
+		}
+		for _, name := range hopByHopHeaders {
+			if name == "Connection" && strings.EqualFold(header.Get(name), "close") {
+				continue
+			}
+			header.Del(name)
+		}
+	}
+
+	for key := range header {
+		if matchHeaderPattern(key, cfg.Strip) {
+			delete(header, key)
+			continue
+		}
+		if len(cfg.Allow) > 0 && !matchHeaderPattern(key, cfg.Allow) {
+			delete(header, key)
+		}
+	}
+}
+
+func matchHeaderPattern(name string, patterns []string) bool {
+	for _, pattern := range patterns {
+		if prefix, ok := strings.CutSuffix(pattern, "*"); ok {
+			if len(name) >= len(prefix) && strings.EqualFold(name[:len(prefix)], prefix) {
+				return true
+			}
+			continue
+		}
+		if strings.EqualFold(name, pattern) {
+			return true
+		}
+	}
+	return false
+}
@@ -3,6 +3,7 @@ package goify
 import (
 	"fmt"
 	"runtime"
+	"sync"
 	"syscall"
 	"time"
 )
@@ -35,94 +36,297 @@ type HealthResponse struct {
 	Checks      map[string]HealthCheck `json:"checks,omitempty"`
 }
 
-var (
-	startTime     = time.Now()
-	healthChecks  = make(map[string]HealthChecker)
-	appVersion    = "1.0.0"
-	appEnv        = "development"
-)
+const DefaultHealthCheckTimeout = 5 * time.Second
 
-func SetAppInfo(version, environment string) {
-	appVersion = version
-	appEnv = environment
+type HealthRegistry struct {
+	mu          sync.RWMutex
+	checks      map[string]*healthEntry
+	timeout     time.Duration
+	version     string
+	environment string
+	startTime   time.Time
 }
 
-func RegisterHealthCheck(name string, checker HealthChecker) {
-	healthChecks[name] = checker
+type healthEntry struct {
+	name     string
+	checker  HealthChecker
+	timeout  time.Duration
+	optional bool
+
+	mu      sync.Mutex
+	pending *healthCall
 }
 
-func HealthCheckMiddleware() MiddlewareFunc {
-	return func(c *Context, next func()) {
-		response := getHealthResponse()
-		
-		status := 200
-		if response.Status != StatusHealthy {
-			status = 503
-		}
-		
-		c.JSON(status, response)
+type healthCall struct {
+	done  chan struct{}
+	check HealthCheck
+}
+
+var defaultHealth = &HealthRegistry{
+	checks:      make(map[string]*healthEntry),
+	timeout:     DefaultHealthCheckTimeout,
+	version:     "1.0.0",
+	environment: "development",
+	startTime:   time.Now(),
+}
+
+func NewHealthRegistry() *HealthRegistry {
+	return &HealthRegistry{
+		checks:      make(map[string]*healthEntry),
+		timeout:     DefaultHealthCheckTimeout,
+		version:     "1.0.0",
+		environment: "development",
+		startTime:   time.Now(),
 	}
 }
 
-func HealthCheckHandler() HandlerFunc {
-	return func(c *Context) {
-		response := getHealthResponse()
-		
-		status := 200
-		if response.Status != StatusHealthy {
-			status = 503
-		}
-		
-		c.JSON(status, response)
+func (h *HealthRegistry) SetTimeout(timeout time.Duration) {
+	if timeout <= 0 {
+		panic("goify: health check timeout must be positive")
+	}
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.timeout = timeout
+}
+
+func (h *HealthRegistry) SetAppInfo(version, environment string) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.version = version
+	h.environment = environment
+}
+
+func (h *HealthRegistry) Register(name string, checker HealthChecker, timeout ...time.Duration) {
+	h.add(newHealthEntry(name, checker, false, timeout))
+}
+
+func (h *HealthRegistry) add(entry *healthEntry) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.checks[entry.name] = entry
+}
+
+func (h *HealthRegistry) Checker(name string) HealthChecker {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+	if entry := h.checks[name]; entry != nil {
+		return entry.checker
 	}
+	return nil
 }
 
-func getHealthResponse() HealthResponse {
-	now := time.Now()
-	uptime := now.Sub(startTime)
-	
-	checks := make(map[string]HealthCheck)
+func (h *HealthRegistry) Run(name string) (HealthCheck, bool) {
+	h.mu.RLock()
+	entry, timeout := h.checks[name], h.timeout
+	h.mu.RUnlock()
+	if entry == nil {
+		return HealthCheck{}, false
+	}
+	return entry.run(timeout), true
+}
+
+func (h *HealthRegistry) Version() string {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+	return h.version
+}
+
+func (h *HealthRegistry) Environment() string {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+	return h.environment
+}
+
+func (h *HealthRegistry) StartTime() time.Time {
+	return h.startTime
+}
+
+func (h *HealthRegistry) Check() HealthResponse {
+	h.mu.RLock()
+	entries := make(map[string]*healthEntry, len(h.checks))
+	for name, entry := range h.checks {
+		entries[name] = entry
+	}
+	timeout := h.timeout
+	h.mu.RUnlock()
+
+	checks := runHealthChecks(entries, timeout)
 	overallStatus := StatusHealthy
-	
-	for name, checker := range healthChecks {
-		start := time.Now()
-		check := checker()
-		check.Duration = time.Since(start)
-		check.LastChecked = now
-		
-		checks[name] = check
-		
+
+	for _, check := range checks {
 		if check.Status == StatusUnhealthy {
 			overallStatus = StatusUnhealthy
 		} else if check.Status == StatusDegraded && overallStatus == StatusHealthy {
 			overallStatus = StatusDegraded
 		}
 	}
-	
+
+	return h.response(overallStatus, checks)
+}
+
+func (h *HealthRegistry) defaultTimeout() time.Duration {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+	return h.timeout
+}
+
+func newHealthEntry(name string, checker HealthChecker, optional bool, timeout []time.Duration) *healthEntry {
+	entry := &healthEntry{name: name, checker: checker, optional: optional}
+	if len(timeout) > 0 {
+		entry.timeout = timeout[0]
+	}
+	return entry
+}
+
+func runHealthChecks(entries map[string]*healthEntry, timeout time.Duration) map[string]HealthCheck {
+	checks := make(map[string]HealthCheck, len(entries))
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+
+	for name, entry := range entries {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			check := entry.run(timeout)
+			mu.Lock()
+			checks[name] = check
+			mu.Unlock()
+		}()
+	}
+
+	wg.Wait()
+	return checks
+}
+
+func (e *healthEntry) run(timeout time.Duration) HealthCheck {
+	if e.timeout > 0 {
+		timeout = e.timeout
+	}
+	start := time.Now()
+
+	e.mu.Lock()
+	call := e.pending
+	if call == nil {
+		call = &healthCall{done: make(chan struct{})}
+		e.pending = call
+		go e.execute(call)
+	}
+	e.mu.Unlock()
+
+	timer := time.NewTimer(timeout)
+	defer timer.Stop()
+
+	var check HealthCheck
+	select {
+	case <-call.done:
+		check = call.check
+	case <-timer.C:
+		check = HealthCheck{
+			Status:  StatusUnhealthy,
+			Message: fmt.Sprintf("Health check timed out after %s", timeout),
+		}
+	}
+
+	if check.Name == "" {
+		check.Name = e.name
+	}
+	if e.optional && check.Status == StatusUnhealthy {
+		check.Status = StatusDegraded
+	}
+	check.Duration = time.Since(start)
+	check.LastChecked = start
+	return check
+}
+
+func (e *healthEntry) execute(call *healthCall) {
+	defer func() {
+		if r := recover(); r != nil {
+			call.check = HealthCheck{
+				Status:  StatusUnhealthy,
+				Message: fmt.Sprintf("Health check panicked: %v", r),
+			}
+		}
+
+		e.mu.Lock()
+		e.pending = nil
+		e.mu.Unlock()
+		close(call.done)
+	}()
+
+	call.check = e.checker()
+}
+
+func (h *HealthRegistry) response(status HealthStatus, checks map[string]HealthCheck) HealthResponse {
 	return HealthResponse{
-		Status:      overallStatus,
-		Timestamp:   now,
-		Uptime:      formatDuration(uptime),
-		Version:     appVersion,
-		Environment: appEnv,
+		Status:      status,
+		Timestamp:   time.Now(),
+		Uptime:      FormatDuration(time.Since(h.startTime)),
+		Version:     h.Version(),
+		Environment: h.Environment(),
 		Checks:      checks,
 	}
 }
 
-func formatDuration(d time.Duration) string {
-	days := int(d.Hours()) / 24
-	hours := int(d.Hours()) % 24
-	minutes := int(d.Minutes()) % 60
-	seconds := int(d.Seconds()) % 60
-	
-	if days > 0 {
-		return fmt.Sprintf("%dd %dh %dm %ds", days, hours, minutes, seconds)
-	} else if hours > 0 {
-		return fmt.Sprintf("%dh %dm %ds", hours, minutes, seconds)
-	} else if minutes > 0 {
-		return fmt.Sprintf("%dm %ds", minutes, seconds)
+func (h *HealthRegistry) Handler() HandlerFunc {
+	return func(c *Context) {
+		h.serve(c)
+	}
+}
+
+func (h *HealthRegistry) serve(c *Context) {
+	response := h.Check()
+
+	status := 200
+	if response.Status != StatusHealthy {
+		status = 503
+	}
+
+	c.JSON(status, response)
+}
+
+func (rt *Router) Health() *HealthRegistry {
+	if rt == nil {
+		return defaultHealth
+	}
+	return rt.health
+}
+
+func (rt *Router) SetAppInfo(version, environment string) {
+	rt.health.SetAppInfo(version, environment)
+}
+
+func (rt *Router) RegisterHealthCheck(name string, checker HealthChecker, timeout ...time.Duration) {
+	rt.health.Register(name, checker, timeout...)
+}
+
+func (rt *Router) SetHealthCheckTimeout(timeout time.Duration) {
+	rt.health.SetTimeout(timeout)
+}
+
+func (rt *Router) HealthCheckHandler() HandlerFunc {
+	return rt.health.Handler()
+}
+
+func SetAppInfo(version, environment string) {
+	defaultHealth.SetAppInfo(version, environment)
+}
+
+func RegisterHealthCheck(name string, checker HealthChecker, timeout ...time.Duration) {
+	defaultHealth.Register(name, checker, timeout...)
+}
+
+func SetHealthCheckTimeout(timeout time.Duration) {
+	defaultHealth.SetTimeout(timeout)
+}
+
+func HealthCheckMiddleware() MiddlewareFunc {
+	return func(c *Context, next func()) {
+		defaultHealth.serve(c)
 	}
-	return fmt.Sprintf("%ds", seconds)
+}
+
+func HealthCheckHandler() HandlerFunc {
+	return defaultHealth.Handler()
 }
 
 func DatabaseHealthCheck(pingFunc func() error) HealthChecker {
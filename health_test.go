@@ -0,0 +1,88 @@
+package goify
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func serveHealth(t *testing.T, handler HandlerFunc) (int, HealthResponse) {
+	t.Helper()
+
+	rt := New()
+	rt.GET("/health", handler)
+	w := httptest.NewRecorder()
+	rt.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/health", nil))
+
+	var response HealthResponse
+	if err := json.Unmarshal(w.Body.Bytes(), &response); err != nil {
+		t.Fatalf("decode health response: %v", err)
+	}
+	return w.Code, response
+}
+
+func unhealthyCheck() HealthCheck {
+	return HealthCheck{Status: StatusUnhealthy, Message: "down"}
+}
+
+func TestHealthRegistryIsPerRouter(t *testing.T) {
+	a, b := New(), New()
+	a.SetAppInfo("2.0.0", "staging")
+	a.RegisterHealthCheck("db", unhealthyCheck)
+	a.Requires("queue", unhealthyCheck)
+	a.Wants("cache", unhealthyCheck)
+
+	code, response := serveHealth(t, b.HealthCheckHandler())
+	if code != http.StatusOK || response.Status != StatusHealthy {
+		t.Fatalf("router b affected by router a: %d %+v", code, response)
+	}
+	if len(response.Checks) != 0 {
+		t.Fatalf("router b sees checks of router a: %v", response.Checks)
+	}
+	if response.Version != "1.0.0" || response.Environment != "development" {
+		t.Fatalf("router b sees app info of router a: %s %s", response.Version, response.Environment)
+	}
+
+	code, response = serveHealth(t, a.HealthCheckHandler())
+	if code != http.StatusServiceUnavailable || len(response.Checks) != 3 {
+		t.Fatalf("router a lost its checks: %d %v", code, response.Checks)
+	}
+	if response.Version != "2.0.0" || response.Environment != "staging" {
+		t.Fatalf("router a app info: %s %s", response.Version, response.Environment)
+	}
+}
+
+func TestPackageHealthRegistryIsSeparateFromRouters(t *testing.T) {
+	RegisterHealthCheck("isolation-probe", unhealthyCheck)
+	defer func() {
+		defaultHealth.mu.Lock()
+		delete(defaultHealth.checks, "isolation-probe")
+		defaultHealth.mu.Unlock()
+	}()
+
+	if _, found := New().Health().Run("isolation-probe"); found {
+		t.Fatal("package-level check leaked into a new router")
+	}
+	if code, _ := serveHealth(t, HealthCheckHandler()); code != http.StatusServiceUnavailable {
+		t.Fatalf("expected package handler to report the check, got %d", code)
+	}
+}
+
+func TestHealthCheckTimeout(t *testing.T) {
+	rt := New()
+	rt.RegisterHealthCheck("slow", func() HealthCheck {
+		time.Sleep(time.Second)
+		return HealthCheck{Status: StatusHealthy}
+	}, 20*time.Millisecond)
+
+	start := time.Now()
+	check, found := rt.Health().Run("slow")
+	if !found || check.Status != StatusUnhealthy {
+		t.Fatalf("expected timed out check to be unhealthy, got %+v", check)
+	}
+	if elapsed := time.Since(start); elapsed > 500*time.Millisecond {
+		t.Fatalf("check was not cut off by its timeout: %v", elapsed)
+	}
+}
@@ -0,0 +1,104 @@
+package goify
+
+import (
+	"net"
+	"strings"
+)
+
+type hostTable struct {
+	pattern string
+	labels  []string
+	routes  map[string]map[string]HandlerFunc
+	tree    *RouteNode
+}
+
+func (rt *Router) Host(pattern string) *RouterGroup {
+	labels := strings.Split(strings.TrimSuffix(pattern, "."), ".")
+	for i, label := range labels {
+		if !strings.HasPrefix(label, "{") {
+			labels[i] = strings.ToLower(label)
+		}
+	}
+	pattern = strings.Join(labels, ".")
+
+	var table *hostTable
+	for _, existing := range rt.hosts {
+		if existing.pattern == pattern {
+			table = existing
+			break
+		}
+	}
+
+	if table == nil {
+		table = &hostTable{
+			pattern: pattern,
+			labels:  labels,
+			routes:  make(map[string]map[string]HandlerFunc),
+			tree:    NewRouteNode(),
+		}
+		rt.hosts = append(rt.hosts, table)
+	}
+
+	return &RouterGroup{
+		router:     rt,
+		host:       table,
+		middleware: make([]MiddlewareFunc, 0),
+	}
+}
+
+func (rt *Router) matchHost(host, method, path string) (HandlerFunc, map[string]string, []string, string) {
+	if len(rt.hosts) == 0 {
+		return nil, nil, nil, ""
+	}
+
+	labels := strings.Split(normalizeHost(host), ".")
+	for _, table := range rt.hosts {
+		hostParams, hostKeys, ok := table.match(labels)
+		if !ok {
+			continue
+		}
+
+		handler, params, keys, fullPath := lookupRoute(table.routes, table.tree, method, path)
+		if handler == nil {
+			continue
+		}
+
+		for key, value := range hostParams {
+			params[key] = value
+		}
+		return handler, params, append(hostKeys, keys...), fullPath
+	}
+
+	return nil, nil, nil, ""
+}
+
+func (table *hostTable) match(labels []string) (map[string]string, []string, bool) {
+	if len(labels) != len(table.labels) {
+		return nil, nil, false
+	}
+
+	params := make(map[string]string)
+	var keys []string
+	for i, label := range table.labels {
+		if strings.HasPrefix(label, "{") && strings.HasSuffix(label, "}") {
+			if labels[i] == "" {
+				return nil, nil, false
+			}
+			key := label[1 : len(label)-1]
+			params[key] = labels[i]
+			keys = append(keys, key)
+			continue
+		}
+		if label != "*" && label != labels[i] {
+			return nil, nil, false
+		}
+	}
+	return params, keys, true
+}
+
+func normalizeHost(host string) string {
+	if h, _, err := net.SplitHostPort(host); err == nil {
+		host = h
+	}
+	return strings.TrimSuffix(strings.ToLower(host), ".")
+}
@@ -0,0 +1,547 @@
+package goify
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+type Translations struct {
+	mu       sync.RWMutex
+	fallback string
+	messages map[string]map[string]string
+}
+
+func NewTranslations(fallback string) *Translations {
+	if fallback == "" {
+		fallback = "en"
+	}
+	return &Translations{
+		fallback: normalizeLanguageTag(fallback),
+		messages: make(map[string]map[string]string),
+	}
+}
+
+func (t *Translations) AddMessages(locale string, messages map[string]string) {
+	locale = normalizeLanguageTag(locale)
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if t.messages[locale] == nil {
+		t.messages[locale] = make(map[string]string, len(messages))
+	}
+	for key, message := range messages {
+		t.messages[locale][key] = message
+	}
+}
+
+func (t *Translations) Load(locale string, data []byte, format string) error {
+	var messages map[string]string
+	var err error
+
+	switch strings.ToLower(strings.TrimPrefix(format, ".")) {
+	case "json":
+		messages, err = parseJSONMessages(data)
+	case "toml":
+		messages, err = parseTOMLMessages(data)
+	default:
+		return fmt.Errorf("goify: unsupported translation format %q", format)
+	}
+	if err != nil {
+		return fmt.Errorf("goify: invalid %s translations for %q: %v", format, locale, err)
+	}
+
+	t.AddMessages(locale, messages)
+	return nil
+}
+
+func (t *Translations) LoadFile(name string) error {
+	data, err := os.ReadFile(name)
+	if err != nil {
+		return err
+	}
+	ext := filepath.Ext(name)
+	return t.Load(strings.TrimSuffix(filepath.Base(name), ext), data, ext)
+}
+
+func (t *Translations) LoadFS(fsys fs.FS, dir string) error {
+	entries, err := fs.ReadDir(fsys, dir)
+	if err != nil {
+		return err
+	}
+
+	for _, entry := range entries {
+		ext := path.Ext(entry.Name())
+		if entry.IsDir() || (ext != ".json" && ext != ".toml") {
+			continue
+		}
+		data, err := fs.ReadFile(fsys, path.Join(dir, entry.Name()))
+		if err != nil {
+			return err
+		}
+		if err := t.Load(strings.TrimSuffix(entry.Name(), ext), data, ext); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (t *Translations) LoadDir(dir string) error {
+	return t.LoadFS(os.DirFS(dir), ".")
+}
+
+func (t *Translations) Locales() []string {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+
+	locales := make([]string, 0, len(t.messages)+1)
+	locales = append(locales, t.fallback)
+	for locale := range t.messages {
+		if locale != t.fallback {
+			locales = append(locales, locale)
+		}
+	}
+	sort.Strings(locales[1:])
+	return locales
+}
+
+func (t *Translations) Messages(locale, prefix string) map[string]string {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+
+	messages := make(map[string]string)
+	for key, message := range t.messages[normalizeLanguageTag(locale)] {
+		if prefix == "" {
+			messages[key] = message
+		} else if name, ok := strings.CutPrefix(key, prefix+"."); ok {
+			messages[name] = message
+		}
+	}
+	return messages
+}
+
+func (t *Translations) Has(locale, key string) bool {
+	_, ok := t.lookup(locale, key)
+	return ok
+}
+
+func (t *Translations) Translate(locale, key string, args ...interface{}) string {
+	var params map[string]interface{}
+	if len(args) == 1 {
+		params = translationParams(args[0])
+	}
+
+	message, ok := "", false
+	if count, exists := params["count"]; exists {
+		if n, isNumber := pluralCount(count); isNumber {
+			message, ok = t.lookup(locale, key+"."+pluralForm(locale, n))
+			if !ok {
+				message, ok = t.lookup(locale, key+".other")
+			}
+		}
+	}
+	if !ok {
+		message, ok = t.lookup(locale, key)
+	}
+	if !ok {
+		return key
+	}
+
+	switch {
+	case params != nil:
+		return expandTranslation(message, params)
+	case len(args) > 0:
+		return fmt.Sprintf(message, args...)
+	}
+	return message
+}
+
+func (t *Translations) lookup(locale, key string) (string, bool) {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+
+	locale = normalizeLanguageTag(locale)
+	for _, candidate := range []string{locale, baseLanguage(locale), t.fallback} {
+		if message, ok := t.messages[candidate][key]; ok {
+			return message, true
+		}
+	}
+	return "", false
+}
+
+func translationParams(arg interface{}) map[string]interface{} {
+	switch params := arg.(type) {
+	case H:
+		return params
+	case map[string]interface{}:
+		return params
+	case map[string]string:
+		converted := make(map[string]interface{}, len(params))
+		for key, value := range params {
+			converted[key] = value
+		}
+		return converted
+	}
+	return nil
+}
+
+func expandTranslation(message string, params map[string]interface{}) string {
+	var b strings.Builder
+	for {
+		start := strings.IndexByte(message, '{')
+		if start < 0 {
+			break
+		}
+		end := strings.IndexByte(message[start:], '}')
+		if end < 0 {
+			break
+		}
+		end += start
+
+		b.WriteString(message[:start])
+		if value, exists := params[message[start+1:end]]; exists {
+			fmt.Fprint(&b, value)
+		} else {
+			b.WriteString(message[start : end+1])
+		}
+		message = message[end+1:]
+	}
+	b.WriteString(message)
+	return b.String()
+}
+
+func pluralCount(value interface{}) (int64, bool) {
+	switch n := value.(type) {
+	case int:
+		return int64(n), true
+	case int32:
+		return int64(n), true
+	case int64:
+		return n, true
+	case uint:
+		return int64(n), true
+	case uint32:
+		return int64(n), true
+	case uint64:
+		return int64(n), true
+	case float64:
+		return int64(n), n == float64(int64(n))
+	}
+	return 0, false
+}
+
+func pluralForm(locale string, n int64) string {
+	if n < 0 {
+		n = -n
+	}
+	mod10, mod100 := n%10, n%100
+
+	switch baseLanguage(normalizeLanguageTag(locale)) {
+	case "ru", "uk", "be":
+		switch {
+		case mod10 == 1 && mod100 != 11:
+			return "one"
+		case mod10 >= 2 && mod10 <= 4 && (mod100 < 12 || mod100 > 14):
+			return "few"
+		}
+		return "many"
+	case "pl":
+		switch {
+		case n == 1:
+			return "one"
+		case mod10 >= 2 && mod10 <= 4 && (mod100 < 12 || mod100 > 14):
+			return "few"
+		}
+		return "many"
+	case "ja", "zh", "ko", "vi", "th":
+		return "other"
+	}
+	if n == 1 {
+		return "one"
+	}
+	return "other"
+}
+
+func (rt *Router) SetTranslations(translations *Translations) {
+	rt.translations = translations
+}
+
+func (rt *Router) Translations() *Translations {
+	return rt.translations
+}
+
+func (c *Context) T(key string, args ...interface{}) string {
+	if c.router == nil || c.router.translations == nil {
+		return key
+	}
+	return c.router.translations.Translate(c.Locale(), key, args...)
+}
+
+func parseJSONMessages(data []byte) (map[string]string, error) {
+	var tree map[string]interface{}
+	if err := json.Unmarshal(data, &tree); err != nil {
+		return nil, err
+	}
+
+	messages := make(map[string]string)
+	if err := flattenMessages(messages, "", tree); err != nil {
+		return nil, err
+	}
+	return messages, nil
+}
+
+func flattenMessages(messages map[string]string, prefix string, tree map[string]interface{}) error {
+	for key, value := range tree {
+		if prefix != "" {
+			key = prefix + "." + key
+		}
+		switch v := value.(type) {
+		case string:
+			messages[key] = v
+		case map[string]interface{}:
+			if err := flattenMessages(messages, key, v); err != nil {
+				return err
+			}
+		default:
+			return fmt.Errorf("message %q must be a string", key)
+		}
+	}
+	return nil
+}
+
+// TOML support covers what translation files need: [tables], dotted and
+// quoted keys, basic, literal and multi-line strings, and comments.
+
+func parseTOMLMessages(data []byte) (map[string]string, error) {
+	messages := make(map[string]string)
+	lines := strings.Split(strings.ReplaceAll(string(data), "\r\n", "\n"), "\n")
+	table := ""
+
+	for i := 0; i < len(lines); i++ {
+		line := strings.TrimSpace(lines[i])
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		if strings.HasPrefix(line, "[") {
+			end := tomlTableEnd(line)
+			if end < 0 || strings.HasPrefix(line, "[[") {
+				return nil, fmt.Errorf("line %d: invalid table header", i+1)
+			}
+			key, err := parseTOMLKey(line[1:end])
+			if err != nil {
+				return nil, fmt.Errorf("line %d: %v", i+1, err)
+			}
+			table = key
+			continue
+		}
+
+		rawKey, rawValue, found := cutTOMLKey(line)
+		if !found {
+			return nil, fmt.Errorf("line %d: expected key = value", i+1)
+		}
+		key, err := parseTOMLKey(rawKey)
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %v", i+1, err)
+		}
+		if table != "" {
+			key = table + "." + key
+		}
+
+		rawValue = strings.TrimSpace(rawValue)
+		var value string
+		switch {
+		case strings.HasPrefix(rawValue, `"""`) || strings.HasPrefix(rawValue, "'''"):
+			delim := rawValue[:3]
+			text := rawValue[3:]
+			for !strings.Contains(text, delim) {
+				i++
+				if i >= len(lines) {
+					return nil, fmt.Errorf("unterminated multi-line string for %q", key)
+				}
+				text += "\n" + lines[i]
+			}
+			text = strings.TrimPrefix(text[:strings.Index(text, delim)], "\n")
+			if delim == `"""` {
+				text, err = unescapeTOML(text)
+				if err != nil {
+					return nil, fmt.Errorf("line %d: %v", i+1, err)
+				}
+			}
+			value = text
+		default:
+			value, _, err = parseTOMLString(rawValue)
+			if err != nil {
+				return nil, fmt.Errorf("line %d: %v", i+1, err)
+			}
+		}
+		messages[key] = value
+	}
+	return messages, nil
+}
+
+// tomlTableEnd returns the index of the "]" closing a table header, or -1
+// when the header is unterminated or followed by anything but a comment.
+func tomlTableEnd(line string) int {
+	quote := byte(0)
+	for i := 1; i < len(line); i++ {
+		switch ch := line[i]; {
+		case quote != 0:
+			if ch == quote {
+				quote = 0
+			}
+		case ch == '"' || ch == '\'':
+			quote = ch
+		case ch == ']':
+			if rest := strings.TrimSpace(line[i+1:]); rest != "" && !strings.HasPrefix(rest, "#") {
+				return -1
+			}
+			return i
+		}
+	}
+	return -1
+}
+
+func cutTOMLKey(line string) (string, string, bool) {
+	quote := byte(0)
+	for i := 0; i < len(line); i++ {
+		switch ch := line[i]; {
+		case quote != 0:
+			if ch == quote {
+				quote = 0
+			}
+		case ch == '"' || ch == '\'':
+			quote = ch
+		case ch == '=':
+			return line[:i], line[i+1:], true
+		}
+	}
+	return "", "", false
+}
+
+func parseTOMLKey(raw string) (string, error) {
+	var parts []string
+	raw = strings.TrimSpace(raw)
+	for raw != "" {
+		var part string
+		if raw[0] == '"' || raw[0] == '\'' {
+			value, rest, err := parseTOMLString(raw)
+			if err != nil {
+				return "", err
+			}
+			part, raw = value, strings.TrimSpace(rest)
+		} else {
+			end := strings.IndexByte(raw, '.')
+			if end < 0 {
+				end = len(raw)
+			}
+			part, raw = strings.TrimSpace(raw[:end]), raw[end:]
+			if part == "" || strings.ContainsAny(part, " \t") {
+				return "", fmt.Errorf("invalid key %q", part)
+			}
+		}
+		parts = append(parts, part)
+
+		if raw != "" {
+			if raw[0] != '.' {
+				return "", fmt.Errorf("invalid key near %q", raw)
+			}
+			raw = strings.TrimSpace(raw[1:])
+		}
+	}
+	if len(parts) == 0 {
+		return "", fmt.Errorf("empty key")
+	}
+	return strings.Join(parts, "."), nil
+}
+
+func parseTOMLString(raw string) (string, string, error) {
+	if raw == "" || (raw[0] != '"' && raw[0] != '\'') {
+		return "", "", fmt.Errorf("expected a string, got %q", raw)
+	}
+
+	quote := raw[0]
+	for i := 1; i < len(raw); i++ {
+		if quote == '"' && raw[i] == '\\' {
+			i++
+			continue
+		}
+		if raw[i] != quote {
+			continue
+		}
+
+		rest := strings.TrimSpace(raw[i+1:])
+		if rest != "" && !strings.HasPrefix(rest, "#") && !strings.HasPrefix(rest, ".") {
+			return "", "", fmt.Errorf("unexpected %q after string", rest)
+		}
+		if strings.HasPrefix(rest, "#") {
+			rest = ""
+		}
+		if quote == '\'' {
+			return raw[1:i], rest, nil
+		}
+		value, err := unescapeTOML(raw[1:i])
+		return value, rest, err
+	}
+	return "", "", fmt.Errorf("unterminated string %q", raw)
+}
+
+func unescapeTOML(s string) (string, error) {
+	if !strings.Contains(s, `\`) {
+		return s, nil
+	}
+
+	var b strings.Builder
+	for i := 0; i < len(s); i++ {
+		if s[i] != '\\' {
+			b.WriteByte(s[i])
+			continue
+		}
+		if i+1 >= len(s) {
+			return "", fmt.Errorf("invalid escape at end of %q", s)
+		}
+		i++
+		switch s[i] {
+		case 'n':
+			b.WriteByte('\n')
+		case 't':
+			b.WriteByte('\t')
+		case 'r':
+			b.WriteByte('\r')
+		case 'b':
+			b.WriteByte('\b')
+		case 'f':
+			b.WriteByte('\f')
+		case '"', '\\':
+			b.WriteByte(s[i])
+		case 'u', 'U':
+			size := 4
+			if s[i] == 'U' {
+				size = 8
+			}
+			if i+size >= len(s) {
+				return "", fmt.Errorf("invalid unicode escape in %q", s)
+			}
+			code, err := strconv.ParseUint(s[i+1:i+1+size], 16, 32)
+			if err != nil {
+				return "", fmt.Errorf("invalid unicode escape in %q", s)
+			}
+			b.WriteRune(rune(code))
+			i += size
+		case '\n':
+			for i+1 < len(s) && strings.ContainsRune(" \t\n", rune(s[i+1])) {
+				i++
+			}
+		default:
+			return "", fmt.Errorf("invalid escape \\%c in %q", s[i], s)
+		}
+	}
+	return b.String(), nil
+}
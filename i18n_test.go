@@ -0,0 +1,116 @@
+package goify
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestParseTOMLMessages(t *testing.T) {
+	input := `
+# comment
+title = "Привет"   # trailing comment
+literal = 'C:\path\no-escape'
+escaped = "line\nnext \"quoted\" \u00e9"
+"quoted.key" = "q"
+site.name = "goify"
+
+[errors]
+not_found = "Not found"
+"with space" = 'ws'
+
+[errors.validation] # see [docs]
+required = """
+Field {field}
+is required"""
+raw = '''
+keep \n as is'''
+
+[items]
+one = "{count} item"
+other = "{count} items"
+
+["a]b"]
+k = "v"
+`
+
+	messages, err := parseTOMLMessages([]byte(input))
+	if err != nil {
+		t.Fatalf("parse: %v", err)
+	}
+
+	want := map[string]string{
+		"title":                      "Привет",
+		"literal":                    `C:\path\no-escape`,
+		"escaped":                    "line\nnext \"quoted\" é",
+		"quoted.key":                 "q",
+		"site.name":                  "goify",
+		"errors.not_found":           "Not found",
+		"errors.with space":          "ws",
+		"errors.validation.required": "Field {field}\nis required",
+		"errors.validation.raw":      `keep \n as is`,
+		"items.one":                  "{count} item",
+		"items.other":                "{count} items",
+		"a]b.k":                      "v",
+	}
+	if !reflect.DeepEqual(messages, want) {
+		t.Fatalf("unexpected messages:\n got %q\nwant %q", messages, want)
+	}
+}
+
+func TestParseTOMLMessagesErrors(t *testing.T) {
+	cases := map[string]string{
+		"missing equals":       "title \"x\"",
+		"unterminated string":  `title = "abc`,
+		"unterminated table":   "[errors",
+		"table trailing text":  "[errors] x",
+		"array of tables":      "[[items]]",
+		"non-string value":     "count = 3",
+		"trailing garbage":     `title = "a" b`,
+		"bad escape":           `title = "\q"`,
+		"unterminated block":   "title = \"\"\"\nabc",
+		"space in bare key":    `bad key = "x"`,
+		"short unicode escape": `title = "\u00"`,
+	}
+
+	for name, input := range cases {
+		t.Run(name, func(t *testing.T) {
+			if _, err := parseTOMLMessages([]byte(input)); err == nil {
+				t.Fatalf("expected an error for %q", input)
+			}
+		})
+	}
+}
+
+func TestTranslationsLoadTOMLAndJSON(t *testing.T) {
+	tr := NewTranslations("en")
+	if err := tr.Load("en", []byte(`{"greeting": "Hello, {name}", "items": {"one": "{count} item", "other": "{count} items"}}`), "json"); err != nil {
+		t.Fatal(err)
+	}
+	if err := tr.Load("ru", []byte("greeting = \"Привет, {name}\"\n[items]\none = \"{count} файл\"\nfew = \"{count} файла\"\nmany = \"{count} файлов\"\n"), ".toml"); err != nil {
+		t.Fatal(err)
+	}
+
+	cases := []struct {
+		locale, key string
+		args        []interface{}
+		want        string
+	}{
+		{"ru-RU", "greeting", []interface{}{H{"name": "Боб"}}, "Привет, Боб"},
+		{"en", "items", []interface{}{H{"count": 1}}, "1 item"},
+		{"en", "items", []interface{}{H{"count": 5}}, "5 items"},
+		{"ru", "items", []interface{}{H{"count": 3}}, "3 файла"},
+		{"ru", "items", []interface{}{H{"count": 11}}, "11 файлов"},
+		{"de", "greeting", []interface{}{H{"name": "Ann"}}, "Hello, Ann"},
+		{"en", "missing.key", nil, "missing.key"},
+	}
+	for _, tc := range cases {
+		if got := tr.Translate(tc.locale, tc.key, tc.args...); got != tc.want {
+			t.Errorf("Translate(%q, %q) = %q, want %q", tc.locale, tc.key, got, tc.want)
+		}
+	}
+
+	if err := tr.Load("en", []byte("a = 1"), "toml"); err == nil || !strings.Contains(err.Error(), "toml") {
+		t.Fatalf("expected a TOML error, got %v", err)
+	}
+}
@@ -0,0 +1,37 @@
+package goify
+
+import "regexp"
+
+const DefaultJSONPCallbackParam = "callback"
+
+var jsonpCallbackRegex = regexp.MustCompile(`^[a-zA-Z_$][0-9a-zA-Z_$]*(\.[a-zA-Z_$][0-9a-zA-Z_$]*)*$`)
+
+func (rt *Router) SetJSONPCallbackParam(param string) {
+	rt.jsonpParam = param
+}
+
+func (c *Context) JSONP(code int, obj interface{}) error {
+	param := DefaultJSONPCallbackParam
+	if c.router != nil && c.router.jsonpParam != "" {
+		param = c.router.jsonpParam
+	}
+
+	callback := c.Query(param)
+	if callback == "" {
+		return c.JSON(code, obj)
+	}
+	if len(callback) > 128 || !jsonpCallbackRegex.MatchString(callback) {
+		return c.SendBadRequest("Invalid JSONP callback name")
+	}
+
+	data, err := marshalJSON(obj)
+	if err != nil {
+		return c.encodeFailed(obj, err)
+	}
+
+	c.SetHeader("Content-Type", "application/javascript; charset=utf-8")
+	c.SetHeader("X-Content-Type-Options", "nosniff")
+	c.Response.WriteHeader(code)
+	_, err = c.Response.Write([]byte("/**/" + callback + "(" + string(data) + ");"))
+	return err
+}
@@ -0,0 +1,386 @@
+package goify
+
+import (
+	"bytes"
+	"encoding"
+	"encoding/json"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	JSONTimeDefault      = ""
+	JSONTimeRFC3339      = time.RFC3339
+	JSONTimeRFC3339Milli = "2006-01-02T15:04:05.000Z07:00"
+	JSONTimeUnix         = "unix"
+	JSONTimeUnixMilli    = "unixmilli"
+)
+
+var (
+	jsonTimeFormat string
+	jsonTimeUTC    bool
+)
+
+var (
+	jsonMarshalerType = reflect.TypeOf((*json.Marshaler)(nil)).Elem()
+	textMarshalerType = reflect.TypeOf((*encoding.TextMarshaler)(nil)).Elem()
+	jsonTimeTypes     sync.Map
+	jsonFieldCache    sync.Map
+)
+
+func SetJSONTimeFormat(format string, utc ...bool) {
+	codecsMu.Lock()
+	defer codecsMu.Unlock()
+	jsonTimeFormat = format
+	jsonTimeUTC = len(utc) > 0 && utc[0]
+}
+
+type jsonTimeWriter struct {
+	format  string
+	utc     bool
+	marshal func(v interface{}) ([]byte, error)
+}
+
+func (w jsonTimeWriter) time(t time.Time) interface{} {
+	if w.utc {
+		t = t.UTC()
+	}
+	switch w.format {
+	case JSONTimeUnix:
+		return t.Unix()
+	case JSONTimeUnixMilli:
+		return t.UnixMilli()
+	default:
+		return t.Format(w.format)
+	}
+}
+
+func (w jsonTimeWriter) value(rv reflect.Value) interface{} {
+	if !rv.IsValid() {
+		return nil
+	}
+
+	t := rv.Type()
+	if t == timeType {
+		return w.time(rv.Interface().(time.Time))
+	}
+	if !jsonTimeWalkNeeded(t) {
+		if rv.CanAddr() && !implementsMarshaler(t) && implementsMarshaler(reflect.PointerTo(t)) {
+			return rv.Addr().Interface()
+		}
+		return rv.Interface()
+	}
+
+	switch rv.Kind() {
+	case reflect.Interface, reflect.Pointer:
+		if rv.IsNil() {
+			return nil
+		}
+		return w.value(rv.Elem())
+	case reflect.Slice:
+		if rv.IsNil() {
+			return nil
+		}
+		fallthrough
+	case reflect.Array:
+		items := make([]interface{}, rv.Len())
+		for i := range items {
+			items[i] = w.value(rv.Index(i))
+		}
+		return items
+	case reflect.Map:
+		if rv.IsNil() {
+			return nil
+		}
+		obj := make(map[string]interface{}, rv.Len())
+		iter := rv.MapRange()
+		for iter.Next() {
+			key, ok := jsonMapKey(iter.Key())
+			if !ok {
+				return rv.Interface()
+			}
+			obj[key] = w.value(iter.Value())
+		}
+		return obj
+	case reflect.Struct:
+		obj := jsonObject{marshal: w.marshal}
+		for _, field := range jsonFieldsOf(t) {
+			fv, ok := jsonFieldByIndex(rv, field.index)
+			if !ok || (field.omitEmpty && isEmptyJSONValue(fv)) {
+				continue
+			}
+
+			value := w.value(fv)
+			if field.quoted {
+				if data, err := w.marshal(value); err == nil {
+					value = string(data)
+				}
+			}
+			obj.keys = append(obj.keys, field.name)
+			obj.values = append(obj.values, value)
+		}
+		return obj
+	}
+
+	return rv.Interface()
+}
+
+type jsonObject struct {
+	keys    []string
+	values  []interface{}
+	marshal func(v interface{}) ([]byte, error)
+}
+
+func (o jsonObject) MarshalJSON() ([]byte, error) {
+	var buf bytes.Buffer
+	buf.WriteByte('{')
+	for i, key := range o.keys {
+		if i > 0 {
+			buf.WriteByte(',')
+		}
+		name, err := json.Marshal(key)
+		if err != nil {
+			return nil, err
+		}
+		value, err := o.marshal(o.values[i])
+		if err != nil {
+			return nil, err
+		}
+		buf.Write(name)
+		buf.WriteByte(':')
+		buf.Write(value)
+	}
+	buf.WriteByte('}')
+	return buf.Bytes(), nil
+}
+
+func jsonTimeWalkNeeded(t reflect.Type) bool {
+	if cached, ok := jsonTimeTypes.Load(t); ok {
+		return cached.(bool)
+	}
+	needed := containsJSONTime(t, make(map[reflect.Type]bool))
+	jsonTimeTypes.Store(t, needed)
+	return needed
+}
+
+func containsJSONTime(t reflect.Type, visiting map[reflect.Type]bool) bool {
+	if t == timeType || t.Kind() == reflect.Interface {
+		return true
+	}
+	if implementsMarshaler(t) || implementsMarshaler(reflect.PointerTo(t)) || visiting[t] {
+		return false
+	}
+	visiting[t] = true
+
+	switch t.Kind() {
+	case reflect.Pointer, reflect.Slice, reflect.Array, reflect.Map:
+		return containsJSONTime(t.Elem(), visiting)
+	case reflect.Struct:
+		found := false
+		for i := 0; i < t.NumField(); i++ {
+			field := t.Field(i)
+			if field.Anonymous && !field.IsExported() {
+				return false
+			}
+			if field.IsExported() && containsJSONTime(field.Type, visiting) {
+				found = true
+			}
+		}
+		return found
+	}
+	return false
+}
+
+func implementsMarshaler(t reflect.Type) bool {
+	return t.Implements(jsonMarshalerType) || t.Implements(textMarshalerType)
+}
+
+type jsonField struct {
+	name      string
+	index     []int
+	tagged    bool
+	omitEmpty bool
+	quoted    bool
+}
+
+func jsonFieldsOf(t reflect.Type) []jsonField {
+	if cached, ok := jsonFieldCache.Load(t); ok {
+		return cached.([]jsonField)
+	}
+
+	type embedded struct {
+		typ   reflect.Type
+		index []int
+	}
+
+	byName := make(map[string][]jsonField)
+	var names []string
+	visited := make(map[reflect.Type]bool)
+	current := []embedded{{typ: t}}
+
+	for len(current) > 0 {
+		var next []embedded
+		level := make(map[string][]jsonField)
+
+		for _, parent := range current {
+			if visited[parent.typ] {
+				continue
+			}
+			visited[parent.typ] = true
+
+			for i := 0; i < parent.typ.NumField(); i++ {
+				field := parent.typ.Field(i)
+				if !field.IsExported() && !field.Anonymous {
+					continue
+				}
+
+				tag := field.Tag.Get("json")
+				if tag == "-" {
+					continue
+				}
+				name, opts, _ := strings.Cut(tag, ",")
+				index := append(append([]int(nil), parent.index...), i)
+
+				fieldType := field.Type
+				if fieldType.Name() == "" && fieldType.Kind() == reflect.Pointer {
+					fieldType = fieldType.Elem()
+				}
+				if field.Anonymous && name == "" && fieldType.Kind() == reflect.Struct {
+					next = append(next, embedded{typ: fieldType, index: index})
+					continue
+				}
+				if !field.IsExported() {
+					continue
+				}
+
+				tagged := name != ""
+				if !tagged {
+					name = field.Name
+				}
+				level[name] = append(level[name], jsonField{
+					name:      name,
+					index:     index,
+					tagged:    tagged,
+					omitEmpty: hasJSONOption(opts, "omitempty"),
+					quoted:    hasJSONOption(opts, "string") && isQuotableKind(fieldType.Kind()),
+				})
+			}
+		}
+
+		for name, fields := range level {
+			if _, exists := byName[name]; exists {
+				continue
+			}
+			names = append(names, name)
+			byName[name] = fields
+		}
+		current = next
+	}
+
+	var fields []jsonField
+	for _, name := range names {
+		candidates := byName[name]
+		if len(candidates) == 1 {
+			fields = append(fields, candidates[0])
+			continue
+		}
+
+		var tagged []jsonField
+		for _, candidate := range candidates {
+			if candidate.tagged {
+				tagged = append(tagged, candidate)
+			}
+		}
+		if len(tagged) == 1 {
+			fields = append(fields, tagged[0])
+		}
+	}
+
+	sort.Slice(fields, func(i, j int) bool {
+		a, b := fields[i].index, fields[j].index
+		for k := 0; k < len(a) && k < len(b); k++ {
+			if a[k] != b[k] {
+				return a[k] < b[k]
+			}
+		}
+		return len(a) < len(b)
+	})
+
+	jsonFieldCache.Store(t, fields)
+	return fields
+}
+
+func jsonFieldByIndex(rv reflect.Value, index []int) (reflect.Value, bool) {
+	for i, position := range index {
+		if i > 0 && rv.Kind() == reflect.Pointer {
+			if rv.IsNil() {
+				return reflect.Value{}, false
+			}
+			rv = rv.Elem()
+		}
+		rv = rv.Field(position)
+	}
+	return rv, true
+}
+
+func jsonMapKey(key reflect.Value) (string, bool) {
+	if key.Kind() == reflect.String {
+		return key.String(), true
+	}
+	if marshaler, ok := key.Interface().(encoding.TextMarshaler); ok {
+		text, err := marshaler.MarshalText()
+		return string(text), err == nil
+	}
+
+	switch key.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return strconv.FormatInt(key.Int(), 10), true
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return strconv.FormatUint(key.Uint(), 10), true
+	}
+	return "", false
+}
+
+func isEmptyJSONValue(v reflect.Value) bool {
+	switch v.Kind() {
+	case reflect.Array, reflect.Map, reflect.Slice, reflect.String:
+		return v.Len() == 0
+	case reflect.Bool:
+		return !v.Bool()
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return v.Int() == 0
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return v.Uint() == 0
+	case reflect.Float32, reflect.Float64:
+		return v.Float() == 0
+	case reflect.Interface, reflect.Pointer:
+		return v.IsNil()
+	}
+	return false
+}
+
+func hasJSONOption(opts, option string) bool {
+	for opts != "" {
+		var current string
+		current, opts, _ = strings.Cut(opts, ",")
+		if current == option {
+			return true
+		}
+	}
+	return false
+}
+
+func isQuotableKind(kind reflect.Kind) bool {
+	switch kind {
+	case reflect.Bool, reflect.String,
+		reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr,
+		reflect.Float32, reflect.Float64:
+		return true
+	}
+	return false
+}
@@ -0,0 +1,176 @@
+package goify
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+)
+
+const localeKey = "locale"
+
+type LanguageTag struct {
+	Tag     string  `json:"tag"`
+	Quality float64 `json:"q"`
+}
+
+func ParseAcceptLanguage(header string) []LanguageTag {
+	var tags []LanguageTag
+
+	for _, part := range strings.Split(header, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+
+		tag, quality := part, 1.0
+		if i := strings.Index(part, ";"); i >= 0 {
+			tag = strings.TrimSpace(part[:i])
+			quality = parseQuality(part[i+1:])
+		}
+		if quality <= 0 || !validLanguageTag(tag) {
+			continue
+		}
+
+		tags = append(tags, LanguageTag{Tag: normalizeLanguageTag(tag), Quality: quality})
+	}
+
+	sort.SliceStable(tags, func(i, j int) bool {
+		return tags[i].Quality > tags[j].Quality
+	})
+	return tags
+}
+
+func parseQuality(params string) float64 {
+	for _, param := range strings.Split(params, ";") {
+		name, value, found := strings.Cut(strings.TrimSpace(param), "=")
+		if !found || !strings.EqualFold(strings.TrimSpace(name), "q") {
+			continue
+		}
+		q, err := strconv.ParseFloat(strings.TrimSpace(value), 64)
+		if err != nil || q < 0 || q > 1 {
+			return 0
+		}
+		return q
+	}
+	return 1
+}
+
+func validLanguageTag(tag string) bool {
+	if tag == "*" {
+		return true
+	}
+	if tag == "" || len(tag) > 35 {
+		return false
+	}
+	for _, subtag := range strings.FieldsFunc(tag, func(r rune) bool { return r == '-' || r == '_' }) {
+		if len(subtag) > 8 {
+			return false
+		}
+		for _, r := range subtag {
+			if !(r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r >= '0' && r <= '9') {
+				return false
+			}
+		}
+	}
+	return !strings.HasPrefix(tag, "-") && !strings.HasSuffix(tag, "-")
+}
+
+func normalizeLanguageTag(tag string) string {
+	subtags := strings.FieldsFunc(tag, func(r rune) bool { return r == '-' || r == '_' })
+	for i, subtag := range subtags {
+		switch {
+		case i == 0:
+			subtags[i] = strings.ToLower(subtag)
+		case len(subtag) == 2:
+			subtags[i] = strings.ToUpper(subtag)
+		case len(subtag) == 4:
+			subtags[i] = strings.ToUpper(subtag[:1]) + strings.ToLower(subtag[1:])
+		default:
+			subtags[i] = strings.ToLower(subtag)
+		}
+	}
+	return strings.Join(subtags, "-")
+}
+
+func baseLanguage(tag string) string {
+	if i := strings.Index(tag, "-"); i > 0 {
+		return tag[:i]
+	}
+	return tag
+}
+
+func MatchLocale(header string, supported []string) string {
+	if len(supported) == 0 {
+		return ""
+	}
+
+	for _, tag := range ParseAcceptLanguage(header) {
+		if tag.Tag == "*" {
+			return supported[0]
+		}
+		if match := matchLanguageTag(tag.Tag, supported); match != "" {
+			return match
+		}
+	}
+	return supported[0]
+}
+
+func matchLanguageTag(tag string, supported []string) string {
+	for _, locale := range supported {
+		if strings.EqualFold(locale, tag) {
+			return locale
+		}
+	}
+
+	for prefix := tag; strings.Contains(prefix, "-"); {
+		prefix = prefix[:strings.LastIndex(prefix, "-")]
+		for _, locale := range supported {
+			if strings.EqualFold(locale, prefix) {
+				return locale
+			}
+		}
+	}
+
+	base := baseLanguage(tag)
+	for _, locale := range supported {
+		if strings.EqualFold(baseLanguage(normalizeLanguageTag(locale)), base) {
+			return locale
+		}
+	}
+	return ""
+}
+
+func (rt *Router) SetSupportedLocales(locales ...string) {
+	rt.locales = locales
+}
+
+func (rt *Router) SupportedLocales() []string {
+	if len(rt.locales) == 0 {
+		if rt.translations != nil {
+			return rt.translations.Locales()
+		}
+		return []string{"en"}
+	}
+	return rt.locales
+}
+
+func (c *Context) Locale() string {
+	if value, exists := c.Get(localeKey); exists {
+		if locale, ok := value.(string); ok {
+			return locale
+		}
+	}
+
+	supported := []string{"en"}
+	if c.router != nil {
+		supported = c.router.SupportedLocales()
+	}
+
+	locale := MatchLocale(c.GetHeader("Accept-Language"), supported)
+	c.Set(localeKey, locale)
+	return locale
+}
+
+func (c *Context) SetLocale(locale string) {
+	c.Set(localeKey, locale)
+}
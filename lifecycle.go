@@ -0,0 +1,112 @@
+package goify
+
+import (
+	"context"
+	"errors"
+	"net"
+	"net/http"
+	"sync"
+)
+
+var ErrServerRunning = errors.New("goify: server is already running")
+
+type lifecycleState struct {
+	mu      sync.Mutex
+	server  *http.Server
+	running bool
+	ready   []func()
+	stop    []func()
+}
+
+func (rt *Router) OnReady(fn func()) {
+	rt.lifecycle.mu.Lock()
+	defer rt.lifecycle.mu.Unlock()
+	rt.lifecycle.ready = append(rt.lifecycle.ready, fn)
+}
+
+func (rt *Router) onStop(fn func()) {
+	rt.lifecycle.mu.Lock()
+	defer rt.lifecycle.mu.Unlock()
+	rt.lifecycle.stop = append(rt.lifecycle.stop, fn)
+}
+
+func runHooks(hooks []func()) {
+	for _, fn := range hooks {
+		fn()
+	}
+}
+
+func (rt *Router) IsRunning() bool {
+	rt.lifecycle.mu.Lock()
+	defer rt.lifecycle.mu.Unlock()
+	return rt.lifecycle.running
+}
+
+func (rt *Router) serve(server *http.Server, listen func() (net.Listener, error)) error {
+	rt.lifecycle.mu.Lock()
+	if rt.lifecycle.server != nil {
+		rt.lifecycle.mu.Unlock()
+		return ErrServerRunning
+	}
+	rt.lifecycle.server = server
+	rt.lifecycle.mu.Unlock()
+
+	listener, err := listen()
+	if err != nil {
+		rt.releaseServer(server)
+		return err
+	}
+
+	rt.lifecycle.mu.Lock()
+	if rt.lifecycle.server != server {
+		rt.lifecycle.mu.Unlock()
+		listener.Close()
+		return http.ErrServerClosed
+	}
+	rt.lifecycle.running = true
+	ready := append([]func(){}, rt.lifecycle.ready...)
+	rt.lifecycle.mu.Unlock()
+
+	defer rt.releaseServer(server)
+	runHooks(ready)
+	return server.Serve(listener)
+}
+
+func (rt *Router) releaseServer(server *http.Server) {
+	rt.lifecycle.mu.Lock()
+	var stop []func()
+	if rt.lifecycle.server == server {
+		if rt.lifecycle.running {
+			stop = append(stop, rt.lifecycle.stop...)
+		}
+		rt.lifecycle.server = nil
+		rt.lifecycle.running = false
+	}
+	rt.lifecycle.mu.Unlock()
+
+	runHooks(stop)
+}
+
+func (rt *Router) Shutdown(ctx ...context.Context) error {
+	rt.lifecycle.mu.Lock()
+	server := rt.lifecycle.server
+	var stop []func()
+	if rt.lifecycle.running {
+		stop = append(stop, rt.lifecycle.stop...)
+	}
+	rt.lifecycle.server = nil
+	rt.lifecycle.running = false
+	rt.lifecycle.mu.Unlock()
+
+	if server == nil {
+		return nil
+	}
+	runHooks(stop)
+	if len(ctx) > 0 {
+		if err := server.Shutdown(ctx[0]); err != nil {
+			return err
+		}
+		return rt.WaitDeferred(ctx[0])
+	}
+	return server.Close()
+}
@@ -0,0 +1,213 @@
+package goify
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+func (rt *Router) SetLogger(logger *slog.Logger) {
+	rt.logger = logger
+}
+
+func (rt *Router) Logger() *slog.Logger {
+	if rt.logger != nil {
+		return rt.logger
+	}
+	return slog.Default()
+}
+
+func (c *Context) Logger() *slog.Logger {
+	logger := slog.Default()
+	if c.router != nil {
+		logger = c.router.Logger()
+	}
+	if requestID := c.RequestID(); requestID != "" {
+		logger = logger.With("request_id", requestID)
+	}
+	return logger
+}
+
+func (c *Context) RequestID() string {
+	if value, exists := c.Get("requestID"); exists {
+		if requestID, ok := value.(string); ok {
+			return requestID
+		}
+	}
+	return RequestIDFromContext(c.Request.Context())
+}
+
+func (c *Context) routeTemplate() string {
+	if c.fullPath != "" {
+		return c.fullPath
+	}
+	return c.Request.URL.Path
+}
+
+type LogFormat string
+
+const (
+	LogFormatText     LogFormat = "text"
+	LogFormatJSON     LogFormat = "json"
+	LogFormatCommon   LogFormat = "common"
+	LogFormatCombined LogFormat = "combined"
+)
+
+const apacheTimeFormat = "02/Jan/2006:15:04:05 -0700"
+
+type LoggerConfig struct {
+	Format     LogFormat
+	Output     io.Writer
+	SkipPaths  []string
+	TimeFormat string
+}
+
+func Logger() MiddlewareFunc {
+	return LoggerWithConfig(LoggerConfig{})
+}
+
+func LoggerWithConfig(config LoggerConfig) MiddlewareFunc {
+	if config.Format == "" && config.Output != nil {
+		config.Format = LogFormatText
+	}
+	if config.Format != "" && config.Output == nil {
+		config.Output = os.Stderr
+	}
+
+	var write func(c *Context, start time.Time)
+	switch config.Format {
+	case "":
+		write = func(c *Context, start time.Time) {
+			logRequest(c.Logger(), c, time.Since(start))
+		}
+	case LogFormatText, LogFormatJSON:
+		logger := slog.New(newLogHandler(config))
+		write = func(c *Context, start time.Time) {
+			if requestID := c.RequestID(); requestID != "" {
+				logRequest(logger.With("request_id", requestID), c, time.Since(start))
+				return
+			}
+			logRequest(logger, c, time.Since(start))
+		}
+	case LogFormatCommon, LogFormatCombined:
+		if config.TimeFormat == "" {
+			config.TimeFormat = apacheTimeFormat
+		}
+		var mu sync.Mutex
+		write = func(c *Context, start time.Time) {
+			line := apacheLogLine(c, start, config)
+			mu.Lock()
+			io.WriteString(config.Output, line)
+			mu.Unlock()
+		}
+	default:
+		panic(fmt.Sprintf("goify: unknown log format %q", config.Format))
+	}
+
+	return func(c *Context, next func()) {
+		start := time.Now()
+
+		next()
+
+		if matchPaths(c, config.SkipPaths) {
+			return
+		}
+		write(c, start)
+	}
+}
+
+func newLogHandler(config LoggerConfig) slog.Handler {
+	options := &slog.HandlerOptions{}
+	if config.TimeFormat != "" {
+		options.ReplaceAttr = func(groups []string, attr slog.Attr) slog.Attr {
+			if len(groups) == 0 && attr.Key == slog.TimeKey && attr.Value.Kind() == slog.KindTime {
+				return slog.String(slog.TimeKey, attr.Value.Time().Format(config.TimeFormat))
+			}
+			return attr
+		}
+	}
+
+	if config.Format == LogFormatJSON {
+		return slog.NewJSONHandler(config.Output, options)
+	}
+	return slog.NewTextHandler(config.Output, options)
+}
+
+func logRequest(logger *slog.Logger, c *Context, latency time.Duration) {
+	status := c.StatusCode()
+
+	level := slog.LevelInfo
+	if status >= 500 {
+		level = slog.LevelError
+	} else if status >= 400 {
+		level = slog.LevelWarn
+	}
+
+	logger.LogAttrs(context.Background(), level, "request",
+		slog.String("method", c.Request.Method),
+		slog.String("path", c.routeTemplate()),
+		slog.Int("status", status),
+		slog.Duration("latency", latency),
+		slog.String("client_ip", c.ClientIP()),
+	)
+}
+
+func matchPaths(c *Context, paths []string) bool {
+	path := c.Request.URL.Path
+	for _, skip := range paths {
+		if prefix, ok := strings.CutSuffix(skip, "*"); ok {
+			if strings.HasPrefix(path, prefix) {
+				return true
+			}
+		} else if skip == path || skip == c.fullPath {
+			return true
+		}
+	}
+	return false
+}
+
+func apacheLogLine(c *Context, start time.Time, config LoggerConfig) string {
+	user := "-"
+	if username, _, ok := c.Request.BasicAuth(); ok && username != "" {
+		user = username
+	}
+
+	size := "-"
+	if written := c.BytesWritten(); written > 0 {
+		size = strconv.Itoa(written)
+	}
+
+	uri := c.Request.RequestURI
+	if uri == "" {
+		uri = c.Request.URL.RequestURI()
+	}
+
+	line := fmt.Sprintf("%s - %s [%s] \"%s %s %s\" %d %s",
+		c.ClientIP(),
+		user,
+		start.Format(config.TimeFormat),
+		c.Request.Method,
+		uri,
+		c.Request.Proto,
+		c.StatusCode(),
+		size,
+	)
+
+	if config.Format == LogFormatCombined {
+		line += fmt.Sprintf(" %s %s", quoteLogField(c.Request.Referer()), quoteLogField(c.Request.UserAgent()))
+	}
+	return line + "\n"
+}
+
+func quoteLogField(value string) string {
+	if value == "" {
+		return `"-"`
+	}
+	return strconv.Quote(value)
+}
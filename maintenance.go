@@ -0,0 +1,197 @@
+package goify
+
+import (
+	"net/http"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+type MaintenanceConfig struct {
+	RetryAfter time.Duration
+	Message    string
+	AllowPaths []string
+	AllowIPs   []string
+	Allow      func(c *Context) bool
+}
+
+type MaintenanceStatus struct {
+	Enabled bool       `json:"enabled"`
+	Message string     `json:"message,omitempty"`
+	Since   *time.Time `json:"since,omitempty"`
+	Until   *time.Time `json:"until,omitempty"`
+}
+
+func DefaultMaintenanceConfig() MaintenanceConfig {
+	return MaintenanceConfig{
+		RetryAfter: 5 * time.Minute,
+		Message:    "Service is under maintenance",
+	}
+}
+
+type Maintenance struct {
+	enabled int32
+	mu      sync.RWMutex
+	config  MaintenanceConfig
+	allow   func(c *Context) bool
+	message string
+	since   time.Time
+	until   time.Time
+}
+
+func NewMaintenance(config MaintenanceConfig) *Maintenance {
+	defaults := DefaultMaintenanceConfig()
+	if config.RetryAfter <= 0 {
+		config.RetryAfter = defaults.RetryAfter
+	}
+	if config.Message == "" {
+		config.Message = defaults.Message
+	}
+
+	var exemptions []func(c *Context) bool
+	if len(config.AllowPaths) > 0 {
+		exemptions = append(exemptions, ExemptPaths(config.AllowPaths...))
+	}
+	if len(config.AllowIPs) > 0 {
+		exemptions = append(exemptions, ExemptIPs(config.AllowIPs...))
+	}
+	if config.Allow != nil {
+		exemptions = append(exemptions, config.Allow)
+	}
+
+	return &Maintenance{
+		config: config,
+		allow:  ExemptAny(exemptions...),
+	}
+}
+
+func (m *Maintenance) Enable(message ...string) {
+	m.enable(time.Time{}, message)
+}
+
+func (m *Maintenance) EnableFor(duration time.Duration, message ...string) {
+	m.enable(time.Now().Add(duration), message)
+}
+
+func (m *Maintenance) enable(until time.Time, message []string) {
+	m.mu.Lock()
+	m.message = m.config.Message
+	if len(message) > 0 && message[0] != "" {
+		m.message = message[0]
+	}
+	if atomic.LoadInt32(&m.enabled) == 0 {
+		m.since = time.Now()
+	}
+	m.until = until
+	atomic.StoreInt32(&m.enabled, 1)
+	m.mu.Unlock()
+}
+
+func (m *Maintenance) Disable() {
+	m.mu.Lock()
+	atomic.StoreInt32(&m.enabled, 0)
+	m.since = time.Time{}
+	m.until = time.Time{}
+	m.mu.Unlock()
+}
+
+func (m *Maintenance) Enabled() bool {
+	if atomic.LoadInt32(&m.enabled) == 0 {
+		return false
+	}
+
+	m.mu.RLock()
+	until := m.until
+	m.mu.RUnlock()
+
+	if !until.IsZero() && !time.Now().Before(until) {
+		m.mu.Lock()
+		if m.until.Equal(until) {
+			atomic.StoreInt32(&m.enabled, 0)
+			m.since = time.Time{}
+			m.until = time.Time{}
+		}
+		m.mu.Unlock()
+		return atomic.LoadInt32(&m.enabled) == 1
+	}
+	return true
+}
+
+func (m *Maintenance) Status() MaintenanceStatus {
+	if !m.Enabled() {
+		return MaintenanceStatus{}
+	}
+
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	status := MaintenanceStatus{Enabled: true, Message: m.message}
+	if !m.since.IsZero() {
+		since := m.since
+		status.Since = &since
+	}
+	if !m.until.IsZero() {
+		until := m.until
+		status.Until = &until
+	}
+	return status
+}
+
+func (m *Maintenance) Middleware() MiddlewareFunc {
+	return func(c *Context, next func()) {
+		if !m.Enabled() || m.allow(c) {
+			next()
+			return
+		}
+
+		m.mu.RLock()
+		message := m.message
+		retryAfter := m.config.RetryAfter
+		if !m.until.IsZero() {
+			retryAfter = time.Until(m.until)
+		}
+		m.mu.RUnlock()
+
+		c.SetHeader("Retry-After", strconv.Itoa(ceilSeconds(retryAfter)))
+		c.SendError(http.StatusServiceUnavailable, message)
+	}
+}
+
+func (m *Maintenance) Handler() HandlerFunc {
+	return func(c *Context) {
+		switch c.Request.Method {
+		case http.MethodGet, http.MethodHead:
+		case http.MethodPost, http.MethodPut:
+			var req struct {
+				Message  string `json:"message"`
+				Duration string `json:"duration"`
+			}
+			if c.Request.ContentLength != 0 {
+				if err := c.BindJSON(&req); err != nil {
+					c.SendBadRequest("Invalid maintenance request", err.Error())
+					return
+				}
+			}
+
+			if req.Duration == "" {
+				m.Enable(req.Message)
+				break
+			}
+			duration, err := time.ParseDuration(req.Duration)
+			if err != nil || duration <= 0 {
+				c.SendBadRequest("Invalid maintenance duration", req.Duration)
+				return
+			}
+			m.EnableFor(duration, req.Message)
+		case http.MethodDelete:
+			m.Disable()
+		default:
+			c.SetHeader("Allow", "GET, POST, PUT, DELETE")
+			c.SendError(http.StatusMethodNotAllowed, "Method not allowed")
+			return
+		}
+
+		c.JSON(http.StatusOK, m.Status())
+	}
+}
@@ -0,0 +1,193 @@
+package goify
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+)
+
+type NamedMiddleware struct {
+	Name       string
+	Middleware MiddlewareFunc
+}
+
+type MiddlewareRun struct {
+	Order         []string
+	Status        int
+	Header        http.Header
+	Body          []byte
+	HeaderWrites  int
+	HandlerCalled bool
+	Panic         interface{}
+	Errors        []error
+}
+
+type MiddlewareInvariant func(run MiddlewareRun) error
+
+type TestingT interface {
+	Helper()
+	Errorf(format string, args ...interface{})
+}
+
+type MiddlewareMatrix struct {
+	Middleware []NamedMiddleware
+	Method     string
+	Path       string
+	Handler    HandlerFunc
+	Request    func() *http.Request
+	Invariants []MiddlewareInvariant
+}
+
+func (m *MiddlewareMatrix) Run() []MiddlewareRun {
+	if len(m.Middleware) > 8 {
+		panic("goify: MiddlewareMatrix supports at most 8 middleware (8! orderings)")
+	}
+
+	var runs []MiddlewareRun
+	for _, order := range permuteMiddleware(m.Middleware) {
+		run := m.runOrder(order)
+		for _, invariant := range m.Invariants {
+			if err := invariant(run); err != nil {
+				run.Errors = append(run.Errors, err)
+			}
+		}
+		runs = append(runs, run)
+	}
+	return runs
+}
+
+func (m *MiddlewareMatrix) Check(t TestingT) {
+	t.Helper()
+	for _, run := range m.Run() {
+		for _, err := range run.Errors {
+			t.Errorf("middleware order [%s]: %v", strings.Join(run.Order, " -> "), err)
+		}
+	}
+}
+
+func (m *MiddlewareMatrix) runOrder(order []NamedMiddleware) MiddlewareRun {
+	run := MiddlewareRun{Order: make([]string, len(order))}
+	for i, middleware := range order {
+		run.Order[i] = middleware.Name
+	}
+
+	method := m.Method
+	if method == "" {
+		method = http.MethodGet
+	}
+	path := m.Path
+	if path == "" {
+		path = "/"
+	}
+	handler := m.Handler
+	if handler == nil {
+		handler = func(c *Context) {
+			c.String(http.StatusOK, "ok")
+		}
+	}
+
+	rt := New()
+	rt.DisableWarnings()
+	rt.Use(func(c *Context, next func()) {
+		defer func() {
+			run.HeaderWrites = c.writer.headerCalls
+			if err := recover(); err != nil {
+				run.Panic = err
+			}
+		}()
+		next()
+	})
+	for _, middleware := range order {
+		rt.Use(middleware.Middleware)
+	}
+	rt.addRoute(method, path, func(c *Context) {
+		run.HandlerCalled = true
+		handler(c)
+	})
+
+	var req *http.Request
+	if m.Request != nil {
+		req = m.Request()
+	} else {
+		req = httptest.NewRequest(method, path, nil)
+	}
+
+	recorder := httptest.NewRecorder()
+	rt.ServeHTTP(recorder, req)
+
+	run.Status = recorder.Code
+	run.Header = recorder.Header()
+	run.Body = recorder.Body.Bytes()
+	return run
+}
+
+func permuteMiddleware(middleware []NamedMiddleware) [][]NamedMiddleware {
+	if len(middleware) <= 1 {
+		return [][]NamedMiddleware{append([]NamedMiddleware(nil), middleware...)}
+	}
+
+	var orders [][]NamedMiddleware
+	for i := range middleware {
+		rest := make([]NamedMiddleware, 0, len(middleware)-1)
+		rest = append(rest, middleware[:i]...)
+		rest = append(rest, middleware[i+1:]...)
+		for _, order := range permuteMiddleware(rest) {
+			orders = append(orders, append([]NamedMiddleware{middleware[i]}, order...))
+		}
+	}
+	return orders
+}
+
+func SingleWrite() MiddlewareInvariant {
+	return func(run MiddlewareRun) error {
+		if run.HeaderWrites > 1 {
+			return fmt.Errorf("response status was written %d times", run.HeaderWrites)
+		}
+		return nil
+	}
+}
+
+func NoPanic() MiddlewareInvariant {
+	return func(run MiddlewareRun) error {
+		if run.Panic != nil {
+			return fmt.Errorf("panic escaped the middleware chain: %v", run.Panic)
+		}
+		return nil
+	}
+}
+
+func HeadersPresent(names ...string) MiddlewareInvariant {
+	return func(run MiddlewareRun) error {
+		var missing []string
+		for _, name := range names {
+			if run.Header.Get(name) == "" {
+				missing = append(missing, name)
+			}
+		}
+		if len(missing) > 0 {
+			return fmt.Errorf("missing response headers: %s", strings.Join(missing, ", "))
+		}
+		return nil
+	}
+}
+
+func StatusIn(codes ...int) MiddlewareInvariant {
+	return func(run MiddlewareRun) error {
+		for _, code := range codes {
+			if run.Status == code {
+				return nil
+			}
+		}
+		return fmt.Errorf("unexpected status %d, expected one of %v", run.Status, codes)
+	}
+}
+
+func HandlerReached(expected bool) MiddlewareInvariant {
+	return func(run MiddlewareRun) error {
+		if run.HandlerCalled != expected {
+			return fmt.Errorf("handler reached = %t, expected %t", run.HandlerCalled, expected)
+		}
+		return nil
+	}
+}
@@ -1,10 +1,14 @@
 package goify
 
 import (
+	"context"
 	"fmt"
-	"log"
-	"strings"
+	"net/http"
+	"runtime/debug"
+	"sync/atomic"
 	"time"
+
+	"github.com/VsRnA/goify/upload"
 )
 
 type MiddlewareFunc func(*Context, func())
@@ -30,94 +34,34 @@ func (rt *Router) executeMiddleware(ctx *Context, handler HandlerFunc) {
 	next()
 }
 
-func Logger() MiddlewareFunc {
-	return func(c *Context, next func()) {
-		start := time.Now()
-		method := c.Request.Method
-		path := c.Request.URL.Path
-		
-		next()
-		
-		duration := time.Since(start)
-		log.Printf("%s %s - %v", method, path, duration)
-	}
-}
-
-func CORS() MiddlewareFunc {
-	return CORSWithConfig(CORSConfig{
-		AllowOrigins: []string{"*"},
-		AllowMethods: []string{"GET", "POST", "PUT", "DELETE", "PATCH", "OPTIONS"},
-		AllowHeaders: []string{"Content-Type", "Authorization"},
-	})
-}
-
-type CORSConfig struct {
-	AllowOrigins []string
-	AllowMethods []string
-	AllowHeaders []string
-}
+type PanicHandler func(c *Context, err interface{}, stack []byte)
 
-func CORSWithConfig(config CORSConfig) MiddlewareFunc {
+func Recovery(handlers ...PanicHandler) MiddlewareFunc {
 	return func(c *Context, next func()) {
-		origin := c.GetHeader("Origin")
-
-		if len(config.AllowOrigins) == 0 {
-			config.AllowOrigins = []string{"*"}
-		}
-
-		allowed := false
-		for _, allowedOrigin := range config.AllowOrigins {
-			if allowedOrigin == "*" || allowedOrigin == origin {
-				allowed = true
-				break
-			}
-		}
-		
-		if allowed {
-			if origin != "" {
-				c.SetHeader("Access-Control-Allow-Origin", origin)
-			} else if len(config.AllowOrigins) == 1 && config.AllowOrigins[0] == "*" {
-				c.SetHeader("Access-Control-Allow-Origin", "*")
-			}
-		}
-
-		if len(config.AllowMethods) > 0 {
-			methods := ""
-			for i, method := range config.AllowMethods {
-				if i > 0 {
-					methods += ", "
+		c.recovery = true
+		defer func() {
+			if err := recover(); err != nil {
+				if err == http.ErrAbortHandler {
+					panic(err)
 				}
-				methods += method
-			}
-			c.SetHeader("Access-Control-Allow-Methods", methods)
-		}
-		
-		if len(config.AllowHeaders) > 0 {
-			headers := ""
-			for i, header := range config.AllowHeaders {
-				if i > 0 {
-					headers += ", "
+				c.panicked = true
+
+				stack := debug.Stack()
+				c.Logger().Error("panic recovered",
+					"error", err,
+					"method", c.Request.Method,
+					"path", c.routeTemplate(),
+					"client_ip", c.ClientIP(),
+					"stack", string(stack),
+				)
+
+				for _, handler := range handlers {
+					handler(c, err, stack)
 				}
-				headers += header
-			}
-			c.SetHeader("Access-Control-Allow-Headers", headers)
-		}
 
-		if c.Request.Method == "OPTIONS" {
-			c.Response.WriteHeader(204)
-			return
-		}
-		
-		next()
-	}
-}
-
-func Recovery() MiddlewareFunc {
-	return func(c *Context, next func()) {
-		defer func() {
-			if err := recover(); err != nil {
-				log.Printf("Panic recovered: %v", err)
-				c.SendInternalError("Internal server error")
+				if !c.Written() {
+					c.SendInternalError("Internal server error")
+				}
 			}
 		}()
 		
@@ -125,95 +69,70 @@ func Recovery() MiddlewareFunc {
 	}
 }
 
-func BasicAuth(username, password string) MiddlewareFunc {
-	return func(c *Context, next func()) {
-		user, pass, ok := c.Request.BasicAuth()
-		if !ok || user != username || pass != password {
-			c.SetHeader("WWW-Authenticate", `Basic realm="Restricted"`)
-			c.SendUnauthorized("Authentication required")
-			return
-		}
-		
-		next()
+type RequestIDConfig struct {
+	Header    string
+	Generator func() string
+}
+
+type requestIDContextKey struct{}
+
+func DefaultRequestIDConfig() RequestIDConfig {
+	return RequestIDConfig{
+		Header:    "X-Request-ID",
+		Generator: generateRequestID,
 	}
 }
 
-type RateLimiter struct {
-	requests map[string][]time.Time
-	limit    int
-	window   time.Duration
+func RequestID() MiddlewareFunc {
+	return RequestIDWithConfig(DefaultRequestIDConfig())
 }
 
-func NewRateLimiter(limit int, window time.Duration) *RateLimiter {
-	return &RateLimiter{
-		requests: make(map[string][]time.Time),
-		limit:    limit,
-		window:   window,
+func RequestIDWithConfig(config RequestIDConfig) MiddlewareFunc {
+	if config.Header == "" {
+		config.Header = "X-Request-ID"
+	}
+	if config.Generator == nil {
+		config.Generator = generateRequestID
 	}
-}
 
-func (rl *RateLimiter) Middleware() MiddlewareFunc {
 	return func(c *Context, next func()) {
-		ip := c.Request.RemoteAddr
-		now := time.Now()
-
-		if requests, exists := rl.requests[ip]; exists {
-			var validRequests []time.Time
-			for _, reqTime := range requests {
-				if now.Sub(reqTime) < rl.window {
-					validRequests = append(validRequests, reqTime)
-				}
-			}
-			rl.requests[ip] = validRequests
+		requestID := c.GetHeader(config.Header)
+		if !validRequestID(requestID) {
+			requestID = config.Generator()
 		}
-
-		if len(rl.requests[ip]) >= rl.limit {
-			c.SendError(429, "Too many requests", "Rate limit exceeded")
-			return
-		}
-
-		rl.requests[ip] = append(rl.requests[ip], now)
+		
+		c.SetHeader(config.Header, requestID)
+		c.Set("requestID", requestID)
+		c.Request.Header.Set(config.Header, requestID)
+		c.Request = c.Request.WithContext(context.WithValue(c.Request.Context(), requestIDContextKey{}, requestID))
 		
 		next()
 	}
 }
 
-func Static(prefix, root string) MiddlewareFunc {
-	return func(c *Context, next func()) {
-		if c.Request.Method != "GET" && c.Request.Method != "HEAD" {
-			next()
-			return
-		}
-		
-		path := c.Request.URL.Path
-		if len(prefix) > 0 && !strings.HasPrefix(path, prefix) {
-			next()
-			return
-		}
+func RequestIDFromContext(ctx context.Context) string {
+	requestID, _ := ctx.Value(requestIDContextKey{}).(string)
+	return requestID
+}
 
-		if len(prefix) > 0 {
-			path = strings.TrimPrefix(path, prefix)
+func validRequestID(requestID string) bool {
+	if requestID == "" || len(requestID) > 128 {
+		return false
+	}
+	for i := 0; i < len(requestID); i++ {
+		if requestID[i] < 0x21 || requestID[i] > 0x7e {
+			return false
 		}
-
-		fullPath := root + path
-		c.SendFile(fullPath)
 	}
+	return true
 }
 
-func RequestID() MiddlewareFunc {
-	return func(c *Context, next func()) {
-		requestID := c.GetHeader("X-Request-ID")
-		if requestID == "" {
-			requestID = generateRequestID()
-		}
-		
-		c.SetHeader("X-Request-ID", requestID)
-		c.Set("requestID", requestID)
-		
-		next()
+func generateRequestID() string {
+	id, err := upload.NewUUID()
+	if err != nil {
+		return fmt.Sprintf("%d-%d", time.Now().UnixNano(), atomic.AddUint64(&requestIDFallback, 1))
 	}
+	return id
 }
 
-func generateRequestID() string {
-	return fmt.Sprintf("%d", time.Now().UnixNano())
-}
\ No newline at end of file
+var requestIDFallback uint64
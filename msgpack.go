@@ -0,0 +1,587 @@
+package goify
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"math"
+	"reflect"
+	"strings"
+	"sync"
+	"time"
+)
+
+func marshalMsgPack(v interface{}) ([]byte, error) {
+	var buf bytes.Buffer
+	if err := encodeMsgPack(&buf, reflect.ValueOf(v)); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+func unmarshalMsgPack(data []byte, v interface{}) error {
+	rv := reflect.ValueOf(v)
+	if rv.Kind() != reflect.Ptr || rv.IsNil() {
+		return fmt.Errorf("msgpack: unmarshal target must be a non-nil pointer")
+	}
+
+	d := &msgPackDecoder{data: data}
+	value, err := d.decode()
+	if err != nil {
+		return err
+	}
+	if d.pos != len(data) {
+		return fmt.Errorf("msgpack: %d trailing bytes", len(data)-d.pos)
+	}
+	return assignMsgPack(rv.Elem(), value)
+}
+
+type msgPackField struct {
+	name      string
+	index     []int
+	omitEmpty bool
+}
+
+var msgPackFieldCache sync.Map
+
+func msgPackFields(t reflect.Type) []msgPackField {
+	if cached, ok := msgPackFieldCache.Load(t); ok {
+		return cached.([]msgPackField)
+	}
+
+	var fields []msgPackField
+	for _, sf := range reflect.VisibleFields(t) {
+		if !sf.IsExported() || (sf.Anonymous && sf.Type.Kind() == reflect.Struct) {
+			continue
+		}
+
+		tag := sf.Tag.Get("msgpack")
+		if tag == "" {
+			tag = sf.Tag.Get("json")
+		}
+		if tag == "-" {
+			continue
+		}
+
+		name := sf.Name
+		parts := strings.Split(tag, ",")
+		if parts[0] != "" {
+			name = parts[0]
+		}
+
+		field := msgPackField{name: name, index: sf.Index}
+		for _, opt := range parts[1:] {
+			if opt == "omitempty" {
+				field.omitEmpty = true
+			}
+		}
+		fields = append(fields, field)
+	}
+
+	msgPackFieldCache.Store(t, fields)
+	return fields
+}
+
+func encodeMsgPack(buf *bytes.Buffer, v reflect.Value) error {
+	if !v.IsValid() {
+		buf.WriteByte(0xc0)
+		return nil
+	}
+
+	if v.Type() == timeType {
+		return encodeMsgPack(buf, reflect.ValueOf(v.Interface().(time.Time).Format(time.RFC3339Nano)))
+	}
+
+	switch v.Kind() {
+	case reflect.Ptr, reflect.Interface:
+		if v.IsNil() {
+			buf.WriteByte(0xc0)
+			return nil
+		}
+		return encodeMsgPack(buf, v.Elem())
+	case reflect.Bool:
+		if v.Bool() {
+			buf.WriteByte(0xc3)
+		} else {
+			buf.WriteByte(0xc2)
+		}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		writeMsgPackInt(buf, v.Int())
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		writeMsgPackUint(buf, v.Uint())
+	case reflect.Float32:
+		buf.WriteByte(0xca)
+		binary.Write(buf, binary.BigEndian, math.Float32bits(float32(v.Float())))
+	case reflect.Float64:
+		buf.WriteByte(0xcb)
+		binary.Write(buf, binary.BigEndian, math.Float64bits(v.Float()))
+	case reflect.String:
+		writeMsgPackString(buf, v.String())
+	case reflect.Slice, reflect.Array:
+		if v.Kind() == reflect.Slice && v.IsNil() {
+			buf.WriteByte(0xc0)
+			return nil
+		}
+		if v.Type().Elem().Kind() == reflect.Uint8 {
+			data := make([]byte, v.Len())
+			reflect.Copy(reflect.ValueOf(data), v)
+			writeMsgPackBytes(buf, data)
+			return nil
+		}
+		writeMsgPackHeader(buf, v.Len(), 0x90, 0xdc, 0xdd)
+		for i := 0; i < v.Len(); i++ {
+			if err := encodeMsgPack(buf, v.Index(i)); err != nil {
+				return err
+			}
+		}
+	case reflect.Map:
+		if v.IsNil() {
+			buf.WriteByte(0xc0)
+			return nil
+		}
+		writeMsgPackHeader(buf, v.Len(), 0x80, 0xde, 0xdf)
+		iter := v.MapRange()
+		for iter.Next() {
+			if err := encodeMsgPack(buf, iter.Key()); err != nil {
+				return err
+			}
+			if err := encodeMsgPack(buf, iter.Value()); err != nil {
+				return err
+			}
+		}
+	case reflect.Struct:
+		var fields []msgPackField
+		for _, field := range msgPackFields(v.Type()) {
+			fv, err := v.FieldByIndexErr(field.index)
+			if err != nil || (field.omitEmpty && fv.IsZero()) {
+				continue
+			}
+			fields = append(fields, field)
+		}
+
+		writeMsgPackHeader(buf, len(fields), 0x80, 0xde, 0xdf)
+		for _, field := range fields {
+			writeMsgPackString(buf, field.name)
+			if err := encodeMsgPack(buf, v.FieldByIndex(field.index)); err != nil {
+				return err
+			}
+		}
+	default:
+		return fmt.Errorf("msgpack: unsupported type %s", v.Type())
+	}
+	return nil
+}
+
+func writeMsgPackHeader(buf *bytes.Buffer, n int, fix, code16, code32 byte) {
+	switch {
+	case n < 16:
+		buf.WriteByte(fix | byte(n))
+	case n <= math.MaxUint16:
+		buf.WriteByte(code16)
+		binary.Write(buf, binary.BigEndian, uint16(n))
+	default:
+		buf.WriteByte(code32)
+		binary.Write(buf, binary.BigEndian, uint32(n))
+	}
+}
+
+func writeMsgPackInt(buf *bytes.Buffer, n int64) {
+	switch {
+	case n >= 0:
+		writeMsgPackUint(buf, uint64(n))
+	case n >= -32:
+		buf.WriteByte(byte(int8(n)))
+	case n >= math.MinInt8:
+		buf.WriteByte(0xd0)
+		buf.WriteByte(byte(int8(n)))
+	case n >= math.MinInt16:
+		buf.WriteByte(0xd1)
+		binary.Write(buf, binary.BigEndian, int16(n))
+	case n >= math.MinInt32:
+		buf.WriteByte(0xd2)
+		binary.Write(buf, binary.BigEndian, int32(n))
+	default:
+		buf.WriteByte(0xd3)
+		binary.Write(buf, binary.BigEndian, n)
+	}
+}
+
+func writeMsgPackUint(buf *bytes.Buffer, n uint64) {
+	switch {
+	case n <= 0x7f:
+		buf.WriteByte(byte(n))
+	case n <= math.MaxUint8:
+		buf.WriteByte(0xcc)
+		buf.WriteByte(byte(n))
+	case n <= math.MaxUint16:
+		buf.WriteByte(0xcd)
+		binary.Write(buf, binary.BigEndian, uint16(n))
+	case n <= math.MaxUint32:
+		buf.WriteByte(0xce)
+		binary.Write(buf, binary.BigEndian, uint32(n))
+	default:
+		buf.WriteByte(0xcf)
+		binary.Write(buf, binary.BigEndian, n)
+	}
+}
+
+func writeMsgPackString(buf *bytes.Buffer, s string) {
+	n := len(s)
+	switch {
+	case n < 32:
+		buf.WriteByte(0xa0 | byte(n))
+	case n <= math.MaxUint8:
+		buf.WriteByte(0xd9)
+		buf.WriteByte(byte(n))
+	case n <= math.MaxUint16:
+		buf.WriteByte(0xda)
+		binary.Write(buf, binary.BigEndian, uint16(n))
+	default:
+		buf.WriteByte(0xdb)
+		binary.Write(buf, binary.BigEndian, uint32(n))
+	}
+	buf.WriteString(s)
+}
+
+func writeMsgPackBytes(buf *bytes.Buffer, data []byte) {
+	n := len(data)
+	switch {
+	case n <= math.MaxUint8:
+		buf.WriteByte(0xc4)
+		buf.WriteByte(byte(n))
+	case n <= math.MaxUint16:
+		buf.WriteByte(0xc5)
+		binary.Write(buf, binary.BigEndian, uint16(n))
+	default:
+		buf.WriteByte(0xc6)
+		binary.Write(buf, binary.BigEndian, uint32(n))
+	}
+	buf.Write(data)
+}
+
+// maxMsgPackDepth limits array and map nesting so hostile input cannot
+// exhaust the goroutine stack.
+const maxMsgPackDepth = 10000
+
+type msgPackDecoder struct {
+	data  []byte
+	pos   int
+	depth int
+}
+
+func (d *msgPackDecoder) enter() error {
+	d.depth++
+	if d.depth > maxMsgPackDepth {
+		return fmt.Errorf("msgpack: exceeded max depth of %d", maxMsgPackDepth)
+	}
+	return nil
+}
+
+func (d *msgPackDecoder) read(n int) ([]byte, error) {
+	if n < 0 || d.pos+n > len(d.data) {
+		return nil, fmt.Errorf("msgpack: unexpected end of data")
+	}
+	b := d.data[d.pos : d.pos+n]
+	d.pos += n
+	return b, nil
+}
+
+func (d *msgPackDecoder) readUint(size int) (uint64, error) {
+	b, err := d.read(size)
+	if err != nil {
+		return 0, err
+	}
+	switch size {
+	case 1:
+		return uint64(b[0]), nil
+	case 2:
+		return uint64(binary.BigEndian.Uint16(b)), nil
+	case 4:
+		return uint64(binary.BigEndian.Uint32(b)), nil
+	default:
+		return binary.BigEndian.Uint64(b), nil
+	}
+}
+
+func (d *msgPackDecoder) decode() (interface{}, error) {
+	b, err := d.read(1)
+	if err != nil {
+		return nil, err
+	}
+	code := b[0]
+
+	switch {
+	case code <= 0x7f:
+		return int64(code), nil
+	case code >= 0xe0:
+		return int64(int8(code)), nil
+	case code&0xf0 == 0x80:
+		return d.decodeMap(int(code & 0x0f))
+	case code&0xf0 == 0x90:
+		return d.decodeArray(int(code & 0x0f))
+	case code&0xe0 == 0xa0:
+		return d.decodeString(int(code & 0x1f))
+	}
+
+	switch code {
+	case 0xc0:
+		return nil, nil
+	case 0xc2:
+		return false, nil
+	case 0xc3:
+		return true, nil
+	case 0xc4, 0xc5, 0xc6:
+		n, err := d.readUint(1 << (code - 0xc4))
+		if err != nil {
+			return nil, err
+		}
+		data, err := d.read(int(n))
+		if err != nil {
+			return nil, err
+		}
+		return append([]byte(nil), data...), nil
+	case 0xca:
+		n, err := d.readUint(4)
+		return float64(math.Float32frombits(uint32(n))), err
+	case 0xcb:
+		n, err := d.readUint(8)
+		return math.Float64frombits(n), err
+	case 0xcc, 0xcd, 0xce, 0xcf:
+		return d.readUint(1 << (code - 0xcc))
+	case 0xd0:
+		n, err := d.readUint(1)
+		return int64(int8(n)), err
+	case 0xd1:
+		n, err := d.readUint(2)
+		return int64(int16(n)), err
+	case 0xd2:
+		n, err := d.readUint(4)
+		return int64(int32(n)), err
+	case 0xd3:
+		n, err := d.readUint(8)
+		return int64(n), err
+	case 0xd9, 0xda, 0xdb:
+		n, err := d.readUint(1 << (code - 0xd9))
+		if err != nil {
+			return nil, err
+		}
+		return d.decodeString(int(n))
+	case 0xdc, 0xdd:
+		n, err := d.readUint(2 << (code - 0xdc))
+		if err != nil {
+			return nil, err
+		}
+		return d.decodeArray(int(n))
+	case 0xde, 0xdf:
+		n, err := d.readUint(2 << (code - 0xde))
+		if err != nil {
+			return nil, err
+		}
+		return d.decodeMap(int(n))
+	}
+
+	return nil, fmt.Errorf("msgpack: unsupported type code 0x%02x", code)
+}
+
+func (d *msgPackDecoder) decodeString(n int) (interface{}, error) {
+	b, err := d.read(n)
+	if err != nil {
+		return nil, err
+	}
+	return string(b), nil
+}
+
+func (d *msgPackDecoder) decodeArray(n int) (interface{}, error) {
+	if n > len(d.data)-d.pos {
+		return nil, fmt.Errorf("msgpack: array length %d exceeds data size", n)
+	}
+	if err := d.enter(); err != nil {
+		return nil, err
+	}
+	defer func() { d.depth-- }()
+
+	list := make([]interface{}, n)
+	for i := range list {
+		value, err := d.decode()
+		if err != nil {
+			return nil, err
+		}
+		list[i] = value
+	}
+	return list, nil
+}
+
+func (d *msgPackDecoder) decodeMap(n int) (interface{}, error) {
+	if n > len(d.data)-d.pos {
+		return nil, fmt.Errorf("msgpack: map length %d exceeds data size", n)
+	}
+	if err := d.enter(); err != nil {
+		return nil, err
+	}
+	defer func() { d.depth-- }()
+
+	m := make(map[string]interface{}, n)
+	for i := 0; i < n; i++ {
+		key, err := d.decode()
+		if err != nil {
+			return nil, err
+		}
+		value, err := d.decode()
+		if err != nil {
+			return nil, err
+		}
+		m[fmt.Sprint(key)] = value
+	}
+	return m, nil
+}
+
+func assignMsgPack(v reflect.Value, value interface{}) error {
+	if value == nil {
+		v.Set(reflect.Zero(v.Type()))
+		return nil
+	}
+
+	if v.Kind() == reflect.Interface && v.NumMethod() == 0 {
+		v.Set(reflect.ValueOf(value))
+		return nil
+	}
+
+	if v.Type() == timeType {
+		s, ok := value.(string)
+		if !ok {
+			return fmt.Errorf("msgpack: cannot decode %T into time.Time", value)
+		}
+		t, err := time.Parse(time.RFC3339Nano, s)
+		if err != nil {
+			return err
+		}
+		v.Set(reflect.ValueOf(t))
+		return nil
+	}
+
+	switch v.Kind() {
+	case reflect.Ptr:
+		if v.IsNil() {
+			v.Set(reflect.New(v.Type().Elem()))
+		}
+		return assignMsgPack(v.Elem(), value)
+	case reflect.Bool:
+		b, ok := value.(bool)
+		if !ok {
+			return fmt.Errorf("msgpack: cannot decode %T into bool", value)
+		}
+		v.SetBool(b)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		switch n := value.(type) {
+		case int64:
+			v.SetInt(n)
+		case uint64:
+			v.SetInt(int64(n))
+		case float64:
+			v.SetInt(int64(n))
+		default:
+			return fmt.Errorf("msgpack: cannot decode %T into %s", value, v.Type())
+		}
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		switch n := value.(type) {
+		case int64:
+			v.SetUint(uint64(n))
+		case uint64:
+			v.SetUint(n)
+		case float64:
+			v.SetUint(uint64(n))
+		default:
+			return fmt.Errorf("msgpack: cannot decode %T into %s", value, v.Type())
+		}
+	case reflect.Float32, reflect.Float64:
+		switch n := value.(type) {
+		case int64:
+			v.SetFloat(float64(n))
+		case uint64:
+			v.SetFloat(float64(n))
+		case float64:
+			v.SetFloat(n)
+		default:
+			return fmt.Errorf("msgpack: cannot decode %T into %s", value, v.Type())
+		}
+	case reflect.String:
+		switch s := value.(type) {
+		case string:
+			v.SetString(s)
+		case []byte:
+			v.SetString(string(s))
+		default:
+			return fmt.Errorf("msgpack: cannot decode %T into string", value)
+		}
+	case reflect.Slice:
+		if data, ok := value.([]byte); ok && v.Type().Elem().Kind() == reflect.Uint8 {
+			v.SetBytes(append([]byte(nil), data...))
+			return nil
+		}
+		list, ok := value.([]interface{})
+		if !ok {
+			return fmt.Errorf("msgpack: cannot decode %T into %s", value, v.Type())
+		}
+		slice := reflect.MakeSlice(v.Type(), len(list), len(list))
+		for i, item := range list {
+			if err := assignMsgPack(slice.Index(i), item); err != nil {
+				return err
+			}
+		}
+		v.Set(slice)
+	case reflect.Array:
+		list, ok := value.([]interface{})
+		if !ok {
+			return fmt.Errorf("msgpack: cannot decode %T into %s", value, v.Type())
+		}
+		for i := 0; i < v.Len() && i < len(list); i++ {
+			if err := assignMsgPack(v.Index(i), list[i]); err != nil {
+				return err
+			}
+		}
+	case reflect.Map:
+		m, ok := value.(map[string]interface{})
+		if !ok {
+			return fmt.Errorf("msgpack: cannot decode %T into %s", value, v.Type())
+		}
+		if v.Type().Key().Kind() != reflect.String {
+			return fmt.Errorf("msgpack: unsupported map key type %s", v.Type().Key())
+		}
+		result := reflect.MakeMapWithSize(v.Type(), len(m))
+		for key, item := range m {
+			elem := reflect.New(v.Type().Elem()).Elem()
+			if err := assignMsgPack(elem, item); err != nil {
+				return err
+			}
+			result.SetMapIndex(reflect.ValueOf(key).Convert(v.Type().Key()), elem)
+		}
+		v.Set(result)
+	case reflect.Struct:
+		m, ok := value.(map[string]interface{})
+		if !ok {
+			return fmt.Errorf("msgpack: cannot decode %T into %s", value, v.Type())
+		}
+		for _, field := range msgPackFields(v.Type()) {
+			item, exists := m[field.name]
+			if !exists {
+				continue
+			}
+			fv, err := v.FieldByIndexErr(field.index)
+			if err != nil {
+				for i := range field.index[:len(field.index)-1] {
+					parent := v.FieldByIndex(field.index[:i+1])
+					if parent.Kind() == reflect.Ptr && parent.IsNil() {
+						parent.Set(reflect.New(parent.Type().Elem()))
+					}
+				}
+				fv = v.FieldByIndex(field.index)
+			}
+			if err := assignMsgPack(fv, item); err != nil {
+				return fmt.Errorf("msgpack: field %s: %v", field.name, err)
+			}
+		}
+	default:
+		return fmt.Errorf("msgpack: unsupported type %s", v.Type())
+	}
+	return nil
+}
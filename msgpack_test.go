@@ -0,0 +1,73 @@
+package goify
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestMsgPackRoundTrip(t *testing.T) {
+	type item struct {
+		Name  string            `json:"name"`
+		Count int               `json:"count"`
+		Tags  []string          `json:"tags"`
+		Meta  map[string]string `json:"meta"`
+	}
+
+	in := item{Name: "widget", Count: 42, Tags: []string{"a", "b"}, Meta: map[string]string{"k": "v"}}
+	data, err := marshalMsgPack(in)
+	if err != nil {
+		t.Fatalf("marshal: %v", err)
+	}
+
+	var out item
+	if err := unmarshalMsgPack(data, &out); err != nil {
+		t.Fatalf("unmarshal: %v", err)
+	}
+	if out.Name != in.Name || out.Count != in.Count || len(out.Tags) != 2 || out.Meta["k"] != "v" {
+		t.Fatalf("round trip mismatch: %+v", out)
+	}
+}
+
+func TestMsgPackRejectsDeepNesting(t *testing.T) {
+	data := append(bytes.Repeat([]byte{0x91}, maxMsgPackDepth+1), 0x01)
+
+	var out interface{}
+	err := unmarshalMsgPack(data, &out)
+	if err == nil || !strings.Contains(err.Error(), "max depth") {
+		t.Fatalf("expected depth error, got %v", err)
+	}
+}
+
+func TestMsgPackAllowsDepthAtLimit(t *testing.T) {
+	data := append(bytes.Repeat([]byte{0x91}, maxMsgPackDepth), 0x01)
+
+	var out interface{}
+	if err := unmarshalMsgPack(data, &out); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+func TestMsgPackDeepBodyDoesNotCrashBinding(t *testing.T) {
+	rt := New()
+	rt.Use(Recovery())
+	rt.POST("/items", func(c *Context) {
+		var body map[string]interface{}
+		if err := c.ShouldBind(&body); err != nil {
+			c.Status(http.StatusBadRequest)
+			return
+		}
+		c.Status(http.StatusOK)
+	})
+
+	req := httptest.NewRequest(http.MethodPost, "/items", bytes.NewReader(bytes.Repeat([]byte{0x91}, 4<<20)))
+	req.Header.Set("Content-Type", MIMEMsgPack)
+	w := httptest.NewRecorder()
+	rt.ServeHTTP(w, req)
+
+	if w.Code != http.StatusBadRequest {
+		t.Fatalf("expected 400, got %d", w.Code)
+	}
+}
@@ -0,0 +1,83 @@
+package goify
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+)
+
+const DefaultMaxMultipartMemory = 32 << 20
+
+type MultipartConfig struct {
+	MaxMemory     int64
+	MaxUploadSize int64
+}
+
+func (rt *Router) SetMaxMultipartMemory(size int64) {
+	if size <= 0 {
+		panic("goify: max multipart memory must be positive")
+	}
+	rt.multipart.MaxMemory = size
+}
+
+func (rt *Router) SetMaxUploadSize(size int64) {
+	if size < 0 {
+		panic("goify: max upload size must not be negative")
+	}
+	rt.multipart.MaxUploadSize = size
+}
+
+func MultipartLimits(config MultipartConfig) MiddlewareFunc {
+	if config.MaxMemory < 0 || config.MaxUploadSize < 0 {
+		panic("goify: multipart limits must not be negative")
+	}
+
+	return func(c *Context, next func()) {
+		if config.MaxMemory > 0 {
+			c.multipart.MaxMemory = config.MaxMemory
+		}
+		if config.MaxUploadSize > 0 {
+			c.multipart.MaxUploadSize = config.MaxUploadSize
+		}
+		next()
+	}
+}
+
+func (c *Context) parseMultipartForm() error {
+	if err := c.limitUpload(); err != nil {
+		return err
+	}
+
+	memory := c.multipart.MaxMemory
+	if memory <= 0 {
+		memory = DefaultMaxMultipartMemory
+	}
+	return uploadError(c.Request.ParseMultipartForm(memory))
+}
+
+func (c *Context) limitUpload() error {
+	limit := c.multipart.MaxUploadSize
+	if limit <= 0 || c.Request.Body == nil {
+		return nil
+	}
+	if c.Request.ContentLength > limit {
+		return uploadTooLarge(limit)
+	}
+	c.Request.Body = http.MaxBytesReader(c.Response, c.Request.Body, limit)
+	return nil
+}
+
+func uploadError(err error) error {
+	var maxBytesErr *http.MaxBytesError
+	if errors.As(err, &maxBytesErr) {
+		return uploadTooLarge(maxBytesErr.Limit)
+	}
+	return err
+}
+
+func uploadTooLarge(limit int64) FileUploadError {
+	return FileUploadError{
+		Message: fmt.Sprintf("Upload exceeds maximum allowed size of %s", FormatFileSize(limit)),
+		Code:    "upload_too_large",
+	}
+}
@@ -0,0 +1,58 @@
+package goify
+
+import "net/http"
+
+const MIMENDJSON = "application/x-ndjson"
+
+type NDJSONWriter struct {
+	ctx     *Context
+	flusher http.Flusher
+}
+
+func (c *Context) NDJSONWriter(code int) *NDJSONWriter {
+	c.SetHeader("Content-Type", MIMENDJSON)
+	c.SetHeader("X-Content-Type-Options", "nosniff")
+	c.Response.WriteHeader(code)
+
+	flusher, _ := c.Response.(http.Flusher)
+	return &NDJSONWriter{
+		ctx:     c,
+		flusher: flusher,
+	}
+}
+
+func (w *NDJSONWriter) Write(item interface{}) error {
+	if err := w.ctx.Request.Context().Err(); err != nil {
+		return err
+	}
+	data, err := marshalJSON(item)
+	if err != nil {
+		return w.ctx.encodeFailed(item, err)
+	}
+	if _, err := w.ctx.Response.Write(append(data, '\n')); err != nil {
+		return err
+	}
+	if w.flusher != nil {
+		w.flusher.Flush()
+	}
+	return nil
+}
+
+func (c *Context) NDJSON(code int, items <-chan interface{}) error {
+	writer := c.NDJSONWriter(code)
+	done := c.Request.Context().Done()
+
+	for {
+		select {
+		case <-done:
+			return c.Request.Context().Err()
+		case item, ok := <-items:
+			if !ok {
+				return nil
+			}
+			if err := writer.Write(item); err != nil {
+				return err
+			}
+		}
+	}
+}
@@ -0,0 +1,366 @@
+package goify
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+)
+
+var (
+	ErrOAuthState     = errors.New("goify: invalid or expired oauth state")
+	ErrOAuthNoIDToken = errors.New("goify: token response has no id_token")
+)
+
+type OAuthConfig struct {
+	ClientID       string
+	ClientSecret   string
+	RedirectURL    string
+	Scopes         []string
+	Issuer         string
+	AuthURL        string
+	TokenURL       string
+	JWKSURL        string
+	AuthParams     map[string]string
+	SecretInParams bool
+	DisablePKCE    bool
+	CookieName     string
+	StateTTL       time.Duration
+	HTTPClient     *http.Client
+	OnSuccess      func(c *Context, result *OAuthResult)
+	OnError        func(c *Context, err error)
+}
+
+type OAuthToken struct {
+	AccessToken  string    `json:"access_token"`
+	TokenType    string    `json:"token_type"`
+	RefreshToken string    `json:"refresh_token,omitempty"`
+	ExpiresIn    int64     `json:"expires_in,omitempty"`
+	Expiry       time.Time `json:"expiry,omitempty"`
+	Scope        string    `json:"scope,omitempty"`
+	IDToken      string    `json:"id_token,omitempty"`
+}
+
+type OAuthResult struct {
+	Token    *OAuthToken
+	Claims   *OIDCClaims
+	ReturnTo string
+}
+
+type OAuthError struct {
+	Code        string `json:"error"`
+	Description string `json:"error_description,omitempty"`
+	Status      int    `json:"-"`
+}
+
+func (e *OAuthError) Error() string {
+	if e.Description != "" {
+		return fmt.Sprintf("oauth: %s: %s", e.Code, e.Description)
+	}
+	return "oauth: " + e.Code
+}
+
+type OAuthProvider struct {
+	config OAuthConfig
+	client *http.Client
+
+	mu       sync.Mutex
+	metadata *oidcMetadata
+	keys     *jwksCache
+}
+
+type oauthFlow struct {
+	State    string    `json:"s"`
+	Verifier string    `json:"v,omitempty"`
+	Nonce    string    `json:"n,omitempty"`
+	ReturnTo string    `json:"r,omitempty"`
+	Expires  time.Time `json:"e"`
+}
+
+func NewOAuthProvider(config OAuthConfig) *OAuthProvider {
+	if config.ClientID == "" || config.RedirectURL == "" {
+		panic("goify: OAuthConfig requires ClientID and RedirectURL")
+	}
+	if config.Issuer == "" && (config.AuthURL == "" || config.TokenURL == "") {
+		panic("goify: OAuthConfig requires Issuer or both AuthURL and TokenURL")
+	}
+	if config.OnSuccess == nil {
+		panic("goify: OAuthConfig requires OnSuccess")
+	}
+	if config.Issuer != "" && len(config.Scopes) == 0 {
+		config.Scopes = []string{"openid", "profile", "email"}
+	}
+	if config.CookieName == "" {
+		config.CookieName = "goify_oauth"
+	}
+	if config.StateTTL <= 0 {
+		config.StateTTL = 10 * time.Minute
+	}
+
+	client := config.HTTPClient
+	if client == nil {
+		client = &http.Client{Timeout: 10 * time.Second}
+	}
+
+	return &OAuthProvider{
+		config: config,
+		client: client,
+		keys:   &jwksCache{},
+	}
+}
+
+func (p *OAuthProvider) LoginHandler() HandlerFunc {
+	return func(c *Context) {
+		flow := oauthFlow{
+			State:    randomToken(),
+			Expires:  time.Now().Add(p.config.StateTTL),
+			ReturnTo: safeReturnTo(c.Query("return_to")),
+		}
+		if !p.config.DisablePKCE {
+			flow.Verifier = randomToken() + randomToken()
+		}
+		if p.config.Issuer != "" {
+			flow.Nonce = randomToken()
+		}
+
+		authURL, err := p.AuthCodeURL(c.Request.Context(), flow.State, flow.Verifier, flow.Nonce)
+		if err != nil {
+			p.fail(c, NewHTTPError(http.StatusBadGateway, "Login provider unavailable").Wrap(err))
+			return
+		}
+
+		data, _ := json.Marshal(flow)
+		if err := c.SetCookieValue(p.config.CookieName, string(data), CookieOptions{
+			MaxAge:    int(p.config.StateTTL / time.Second),
+			Encrypted: true,
+		}); err != nil {
+			p.fail(c, err)
+			return
+		}
+
+		c.Redirect(http.StatusFound, authURL)
+	}
+}
+
+func (p *OAuthProvider) CallbackHandler() HandlerFunc {
+	return func(c *Context) {
+		flow, err := p.readFlow(c)
+		c.DeleteCookie(p.config.CookieName)
+		if err != nil {
+			p.fail(c, NewHTTPError(http.StatusBadRequest, "Login failed").Wrap(err))
+			return
+		}
+
+		if code := c.Query("error"); code != "" {
+			p.fail(c, NewHTTPError(http.StatusBadRequest, "Login failed").Wrap(&OAuthError{
+				Code:        code,
+				Description: c.Query("error_description"),
+			}))
+			return
+		}
+
+		code := c.Query("code")
+		if code == "" {
+			p.fail(c, NewHTTPError(http.StatusBadRequest, "Login failed").Wrap(&OAuthError{Code: "invalid_request", Description: "missing code"}))
+			return
+		}
+
+		token, err := p.Exchange(c.Request.Context(), code, flow.Verifier)
+		if err != nil {
+			p.fail(c, NewHTTPError(http.StatusBadGateway, "Login failed").Wrap(err))
+			return
+		}
+
+		result := &OAuthResult{Token: token, ReturnTo: flow.ReturnTo}
+		if result.ReturnTo == "" {
+			result.ReturnTo = "/"
+		}
+		if p.config.Issuer != "" {
+			if token.IDToken == "" {
+				p.fail(c, NewHTTPError(http.StatusBadGateway, "Login failed").Wrap(ErrOAuthNoIDToken))
+				return
+			}
+			claims, err := p.VerifyIDToken(c.Request.Context(), token.IDToken, flow.Nonce)
+			if err != nil {
+				p.fail(c, NewHTTPError(http.StatusUnauthorized, "Login failed").Wrap(err))
+				return
+			}
+			result.Claims = claims
+			c.Set("oidcClaims", claims)
+		}
+
+		p.config.OnSuccess(c, result)
+	}
+}
+
+func (p *OAuthProvider) readFlow(c *Context) (*oauthFlow, error) {
+	value, err := c.GetEncryptedCookie(p.config.CookieName)
+	if err != nil {
+		return nil, ErrOAuthState
+	}
+
+	var flow oauthFlow
+	if err := json.Unmarshal([]byte(value), &flow); err != nil {
+		return nil, ErrOAuthState
+	}
+	if time.Now().After(flow.Expires) || !constantTimeEqual(flow.State, c.Query("state")) {
+		return nil, ErrOAuthState
+	}
+	return &flow, nil
+}
+
+func (p *OAuthProvider) fail(c *Context, err error) {
+	if p.config.OnError != nil {
+		p.config.OnError(c, err)
+		return
+	}
+	c.Error(err)
+}
+
+func (p *OAuthProvider) AuthCodeURL(ctx context.Context, state, verifier, nonce string) (string, error) {
+	endpoint := p.config.AuthURL
+	if endpoint == "" {
+		metadata, err := p.discover(ctx)
+		if err != nil {
+			return "", err
+		}
+		endpoint = metadata.AuthorizationEndpoint
+	}
+
+	params := url.Values{
+		"response_type": {"code"},
+		"client_id":     {p.config.ClientID},
+		"redirect_uri":  {p.config.RedirectURL},
+		"state":         {state},
+	}
+	if len(p.config.Scopes) > 0 {
+		params.Set("scope", strings.Join(p.config.Scopes, " "))
+	}
+	if verifier != "" {
+		sum := sha256.Sum256([]byte(verifier))
+		params.Set("code_challenge", base64.RawURLEncoding.EncodeToString(sum[:]))
+		params.Set("code_challenge_method", "S256")
+	}
+	if nonce != "" {
+		params.Set("nonce", nonce)
+	}
+	for key, value := range p.config.AuthParams {
+		params.Set(key, value)
+	}
+
+	separator := "?"
+	if strings.Contains(endpoint, "?") {
+		separator = "&"
+	}
+	return endpoint + separator + params.Encode(), nil
+}
+
+func (p *OAuthProvider) Exchange(ctx context.Context, code, verifier string) (*OAuthToken, error) {
+	params := url.Values{
+		"grant_type":   {"authorization_code"},
+		"code":         {code},
+		"redirect_uri": {p.config.RedirectURL},
+	}
+	if verifier != "" {
+		params.Set("code_verifier", verifier)
+	}
+	return p.requestToken(ctx, params)
+}
+
+func (p *OAuthProvider) Refresh(ctx context.Context, refreshToken string) (*OAuthToken, error) {
+	return p.requestToken(ctx, url.Values{
+		"grant_type":    {"refresh_token"},
+		"refresh_token": {refreshToken},
+	})
+}
+
+func (p *OAuthProvider) requestToken(ctx context.Context, params url.Values) (*OAuthToken, error) {
+	endpoint := p.config.TokenURL
+	if endpoint == "" {
+		metadata, err := p.discover(ctx)
+		if err != nil {
+			return nil, err
+		}
+		endpoint = metadata.TokenEndpoint
+	}
+
+	if p.config.SecretInParams {
+		params.Set("client_id", p.config.ClientID)
+		params.Set("client_secret", p.config.ClientSecret)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, strings.NewReader(params.Encode()))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("Accept", "application/json")
+	if !p.config.SecretInParams {
+		req.SetBasicAuth(url.QueryEscape(p.config.ClientID), url.QueryEscape(p.config.ClientSecret))
+	}
+
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, 1<<20))
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		oauthErr := &OAuthError{Status: resp.StatusCode}
+		if json.Unmarshal(body, oauthErr) != nil || oauthErr.Code == "" {
+			oauthErr.Code = "token_request_failed"
+			oauthErr.Description = http.StatusText(resp.StatusCode)
+		}
+		return nil, oauthErr
+	}
+
+	var token OAuthToken
+	if err := json.Unmarshal(body, &token); err != nil {
+		return nil, fmt.Errorf("oauth: invalid token response: %v", err)
+	}
+	if token.AccessToken == "" {
+		return nil, &OAuthError{Code: "invalid_token_response", Description: "missing access_token"}
+	}
+	if token.ExpiresIn > 0 {
+		token.Expiry = time.Now().Add(time.Duration(token.ExpiresIn) * time.Second)
+	}
+	return &token, nil
+}
+
+func (c *Context) OIDCClaims() *OIDCClaims {
+	if value, exists := c.Get("oidcClaims"); exists {
+		if claims, ok := value.(*OIDCClaims); ok {
+			return claims
+		}
+	}
+	return nil
+}
+
+func randomToken() string {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		panic(fmt.Sprintf("goify: failed to generate random token: %v", err))
+	}
+	return base64.RawURLEncoding.EncodeToString(b)
+}
+
+func safeReturnTo(value string) string {
+	if !strings.HasPrefix(value, "/") || strings.HasPrefix(value, "//") || strings.HasPrefix(value, "/\\") {
+		return ""
+	}
+	return value
+}
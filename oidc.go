@@ -0,0 +1,374 @@
+package goify
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/hmac"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/sha512"
+	"crypto/subtle"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+)
+
+var ErrInvalidIDToken = errors.New("goify: invalid id token")
+
+type OIDCClaims struct {
+	Issuer        string                 `json:"iss"`
+	Subject       string                 `json:"sub"`
+	Audience      []string               `json:"-"`
+	ExpiresAt     int64                  `json:"exp"`
+	IssuedAt      int64                  `json:"iat"`
+	Nonce         string                 `json:"nonce,omitempty"`
+	Email         string                 `json:"email,omitempty"`
+	EmailVerified bool                   `json:"email_verified,omitempty"`
+	Name          string                 `json:"name,omitempty"`
+	Picture       string                 `json:"picture,omitempty"`
+	Raw           map[string]interface{} `json:"-"`
+}
+
+func (claims *OIDCClaims) Claim(name string) interface{} {
+	return claims.Raw[name]
+}
+
+type oidcMetadata struct {
+	Issuer                string `json:"issuer"`
+	AuthorizationEndpoint string `json:"authorization_endpoint"`
+	TokenEndpoint         string `json:"token_endpoint"`
+	JWKSURI               string `json:"jwks_uri"`
+}
+
+type jwksCache struct {
+	mu      sync.Mutex
+	keys    map[string]crypto.PublicKey
+	fetched time.Time
+}
+
+type jsonWebKey struct {
+	Kid string `json:"kid"`
+	Kty string `json:"kty"`
+	Use string `json:"use"`
+	N   string `json:"n"`
+	E   string `json:"e"`
+	Crv string `json:"crv"`
+	X   string `json:"x"`
+	Y   string `json:"y"`
+}
+
+func (p *OAuthProvider) discover(ctx context.Context) (*oidcMetadata, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.metadata != nil {
+		return p.metadata, nil
+	}
+	if p.config.Issuer == "" {
+		return nil, errors.New("oauth: discovery requires an Issuer")
+	}
+
+	var metadata oidcMetadata
+	endpoint := strings.TrimSuffix(p.config.Issuer, "/") + "/.well-known/openid-configuration"
+	if err := p.fetchJSON(ctx, endpoint, &metadata); err != nil {
+		return nil, fmt.Errorf("oauth: discovery failed: %v", err)
+	}
+	if metadata.Issuer != p.config.Issuer {
+		return nil, fmt.Errorf("oauth: discovery issuer %q does not match %q", metadata.Issuer, p.config.Issuer)
+	}
+
+	p.metadata = &metadata
+	return p.metadata, nil
+}
+
+func (p *OAuthProvider) fetchJSON(ctx context.Context, endpoint string, v interface{}) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint, nil)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("%s returned %d", endpoint, resp.StatusCode)
+	}
+	return json.NewDecoder(io.LimitReader(resp.Body, 1<<20)).Decode(v)
+}
+
+func (p *OAuthProvider) VerifyIDToken(ctx context.Context, rawToken, nonce string) (*OIDCClaims, error) {
+	parts := strings.Split(rawToken, ".")
+	if len(parts) != 3 {
+		return nil, fmt.Errorf("%w: malformed token", ErrInvalidIDToken)
+	}
+
+	var header struct {
+		Alg string `json:"alg"`
+		Kid string `json:"kid"`
+	}
+	if err := decodeSegment(parts[0], &header); err != nil {
+		return nil, fmt.Errorf("%w: malformed header", ErrInvalidIDToken)
+	}
+	signature, err := base64.RawURLEncoding.DecodeString(parts[2])
+	if err != nil {
+		return nil, fmt.Errorf("%w: malformed signature", ErrInvalidIDToken)
+	}
+
+	if err := p.verifySignature(ctx, header.Alg, header.Kid, parts[0]+"."+parts[1], signature); err != nil {
+		return nil, err
+	}
+
+	var raw map[string]interface{}
+	if err := decodeSegment(parts[1], &raw); err != nil {
+		return nil, fmt.Errorf("%w: malformed claims", ErrInvalidIDToken)
+	}
+	var claims OIDCClaims
+	if err := decodeSegment(parts[1], &claims); err != nil {
+		return nil, fmt.Errorf("%w: malformed claims", ErrInvalidIDToken)
+	}
+	claims.Raw = raw
+	switch aud := raw["aud"].(type) {
+	case string:
+		claims.Audience = []string{aud}
+	case []interface{}:
+		for _, value := range aud {
+			if s, ok := value.(string); ok {
+				claims.Audience = append(claims.Audience, s)
+			}
+		}
+	}
+
+	if err := p.validateClaims(&claims, nonce); err != nil {
+		return nil, err
+	}
+	return &claims, nil
+}
+
+func (p *OAuthProvider) validateClaims(claims *OIDCClaims, nonce string) error {
+	const leeway = time.Minute
+	now := time.Now()
+
+	if claims.Issuer != p.config.Issuer {
+		return fmt.Errorf("%w: unexpected issuer %q", ErrInvalidIDToken, claims.Issuer)
+	}
+
+	audienceOK := false
+	for _, audience := range claims.Audience {
+		if audience == p.config.ClientID {
+			audienceOK = true
+		}
+	}
+	if !audienceOK {
+		return fmt.Errorf("%w: token is not issued for this client", ErrInvalidIDToken)
+	}
+	if azp, _ := claims.Raw["azp"].(string); len(claims.Audience) > 1 && azp != p.config.ClientID {
+		return fmt.Errorf("%w: unexpected authorized party", ErrInvalidIDToken)
+	}
+
+	if claims.ExpiresAt == 0 || now.After(time.Unix(claims.ExpiresAt, 0).Add(leeway)) {
+		return fmt.Errorf("%w: token is expired", ErrInvalidIDToken)
+	}
+	if claims.IssuedAt != 0 && time.Unix(claims.IssuedAt, 0).After(now.Add(leeway)) {
+		return fmt.Errorf("%w: token is issued in the future", ErrInvalidIDToken)
+	}
+	if nonce != "" && !constantTimeEqual(claims.Nonce, nonce) {
+		return fmt.Errorf("%w: nonce mismatch", ErrInvalidIDToken)
+	}
+	return nil
+}
+
+func (p *OAuthProvider) verifySignature(ctx context.Context, alg, kid, signed string, signature []byte) error {
+	switch alg {
+	case "HS256":
+		mac := hmac.New(sha256.New, []byte(p.config.ClientSecret))
+		mac.Write([]byte(signed))
+		if p.config.ClientSecret == "" || !hmac.Equal(mac.Sum(nil), signature) {
+			return fmt.Errorf("%w: bad signature", ErrInvalidIDToken)
+		}
+		return nil
+	case "RS256", "RS384", "RS512", "PS256", "ES256", "ES384":
+	default:
+		return fmt.Errorf("%w: unsupported algorithm %q", ErrInvalidIDToken, alg)
+	}
+
+	key, err := p.publicKey(ctx, kid)
+	if err != nil {
+		return err
+	}
+
+	hashFunc := crypto.SHA256
+	switch alg {
+	case "RS384", "ES384":
+		hashFunc = crypto.SHA384
+	case "RS512":
+		hashFunc = crypto.SHA512
+	}
+	digest := hashBytes(hashFunc, []byte(signed))
+
+	switch pub := key.(type) {
+	case *rsa.PublicKey:
+		if strings.HasPrefix(alg, "RS") {
+			err = rsa.VerifyPKCS1v15(pub, hashFunc, digest, signature)
+		} else if strings.HasPrefix(alg, "PS") {
+			err = rsa.VerifyPSS(pub, hashFunc, digest, signature, nil)
+		} else {
+			err = errors.New("key type mismatch")
+		}
+	case *ecdsa.PublicKey:
+		size := (pub.Curve.Params().BitSize + 7) / 8
+		if !strings.HasPrefix(alg, "ES") || len(signature) != 2*size {
+			err = errors.New("key type mismatch")
+		} else {
+			r := new(big.Int).SetBytes(signature[:size])
+			s := new(big.Int).SetBytes(signature[size:])
+			if !ecdsa.Verify(pub, digest, r, s) {
+				err = errors.New("verification failed")
+			}
+		}
+	default:
+		err = errors.New("unsupported key type")
+	}
+	if err != nil {
+		return fmt.Errorf("%w: bad signature", ErrInvalidIDToken)
+	}
+	return nil
+}
+
+func (p *OAuthProvider) publicKey(ctx context.Context, kid string) (crypto.PublicKey, error) {
+	p.keys.mu.Lock()
+	defer p.keys.mu.Unlock()
+
+	if key, ok := p.keys.lookup(kid); ok {
+		return key, nil
+	}
+	if !p.keys.fetched.IsZero() && time.Since(p.keys.fetched) < time.Minute {
+		return nil, fmt.Errorf("%w: unknown signing key %q", ErrInvalidIDToken, kid)
+	}
+
+	endpoint := p.config.JWKSURL
+	if endpoint == "" {
+		p.keys.mu.Unlock()
+		metadata, err := p.discover(ctx)
+		p.keys.mu.Lock()
+		if err != nil {
+			return nil, err
+		}
+		endpoint = metadata.JWKSURI
+	}
+
+	var set struct {
+		Keys []jsonWebKey `json:"keys"`
+	}
+	if err := p.fetchJSON(ctx, endpoint, &set); err != nil {
+		return nil, fmt.Errorf("oauth: failed to fetch signing keys: %v", err)
+	}
+
+	keys := make(map[string]crypto.PublicKey, len(set.Keys))
+	for _, jwk := range set.Keys {
+		if jwk.Use != "" && jwk.Use != "sig" {
+			continue
+		}
+		if key, err := jwk.publicKey(); err == nil {
+			keys[jwk.Kid] = key
+		}
+	}
+	p.keys.keys = keys
+	p.keys.fetched = time.Now()
+
+	if key, ok := p.keys.lookup(kid); ok {
+		return key, nil
+	}
+	return nil, fmt.Errorf("%w: unknown signing key %q", ErrInvalidIDToken, kid)
+}
+
+func (cache *jwksCache) lookup(kid string) (crypto.PublicKey, bool) {
+	if key, ok := cache.keys[kid]; ok {
+		return key, true
+	}
+	if kid == "" && len(cache.keys) == 1 {
+		for _, key := range cache.keys {
+			return key, true
+		}
+	}
+	return nil, false
+}
+
+func (jwk jsonWebKey) publicKey() (crypto.PublicKey, error) {
+	switch jwk.Kty {
+	case "RSA":
+		n, err := base64.RawURLEncoding.DecodeString(jwk.N)
+		if err != nil {
+			return nil, err
+		}
+		e, err := base64.RawURLEncoding.DecodeString(jwk.E)
+		if err != nil {
+			return nil, err
+		}
+		return &rsa.PublicKey{
+			N: new(big.Int).SetBytes(n),
+			E: int(new(big.Int).SetBytes(e).Int64()),
+		}, nil
+	case "EC":
+		var curve elliptic.Curve
+		switch jwk.Crv {
+		case "P-256":
+			curve = elliptic.P256()
+		case "P-384":
+			curve = elliptic.P384()
+		default:
+			return nil, fmt.Errorf("unsupported curve %q", jwk.Crv)
+		}
+		x, err := base64.RawURLEncoding.DecodeString(jwk.X)
+		if err != nil {
+			return nil, err
+		}
+		y, err := base64.RawURLEncoding.DecodeString(jwk.Y)
+		if err != nil {
+			return nil, err
+		}
+		key := &ecdsa.PublicKey{Curve: curve, X: new(big.Int).SetBytes(x), Y: new(big.Int).SetBytes(y)}
+		if !curve.IsOnCurve(key.X, key.Y) {
+			return nil, errors.New("point is not on curve")
+		}
+		return key, nil
+	}
+	return nil, fmt.Errorf("unsupported key type %q", jwk.Kty)
+}
+
+func decodeSegment(segment string, v interface{}) error {
+	data, err := base64.RawURLEncoding.DecodeString(segment)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(data, v)
+}
+
+func hashBytes(hashFunc crypto.Hash, data []byte) []byte {
+	switch hashFunc {
+	case crypto.SHA384:
+		sum := sha512.Sum384(data)
+		return sum[:]
+	case crypto.SHA512:
+		sum := sha512.Sum512(data)
+		return sum[:]
+	}
+	sum := sha256.Sum256(data)
+	return sum[:]
+}
+
+func constantTimeEqual(a, b string) bool {
+	return subtle.ConstantTimeCompare([]byte(a), []byte(b)) == 1
+}
@@ -0,0 +1,131 @@
+package goify
+
+import (
+	"strings"
+)
+
+const OpenAPIVersion = "3.0.3"
+
+type OpenAPIInfo struct {
+	Title       string `json:"title"`
+	Version     string `json:"version"`
+	Description string `json:"description,omitempty"`
+}
+
+type OpenAPISpec struct {
+	OpenAPI string                                 `json:"openapi"`
+	Info    OpenAPIInfo                            `json:"info"`
+	Paths   map[string]map[string]OpenAPIOperation `json:"paths"`
+	Tags    []OpenAPITag                           `json:"tags,omitempty"`
+}
+
+type OpenAPIOperation struct {
+	Summary     string                     `json:"summary,omitempty"`
+	Description string                     `json:"description,omitempty"`
+	Tags        []string                   `json:"tags,omitempty"`
+	OperationID string                     `json:"operationId,omitempty"`
+	Parameters  []OpenAPIParameter         `json:"parameters,omitempty"`
+	Responses   map[string]OpenAPIResponse `json:"responses"`
+}
+
+type OpenAPIParameter struct {
+	Name     string `json:"name"`
+	In       string `json:"in"`
+	Required bool   `json:"required"`
+	Schema   H      `json:"schema"`
+}
+
+type OpenAPIResponse struct {
+	Description string `json:"description"`
+}
+
+type OpenAPITag struct {
+	Name string `json:"name"`
+}
+
+func (rt *Router) OpenAPI(info OpenAPIInfo) *OpenAPISpec {
+	if info.Title == "" {
+		info.Title = "API"
+	}
+	if info.Version == "" {
+		info.Version = rt.health.Version()
+	}
+
+	spec := &OpenAPISpec{
+		OpenAPI: OpenAPIVersion,
+		Info:    info,
+		Paths:   make(map[string]map[string]OpenAPIOperation),
+	}
+
+	seenTags := make(map[string]bool)
+	for _, route := range rt.Routes() {
+		path, params := openAPIPath(route.Path)
+
+		operations := spec.Paths[path]
+		if operations == nil {
+			operations = make(map[string]OpenAPIOperation)
+			spec.Paths[path] = operations
+		}
+
+		operations[strings.ToLower(route.Method)] = OpenAPIOperation{
+			Summary:     route.Summary,
+			Description: route.Description,
+			Tags:        route.Tags,
+			OperationID: operationID(route.Method, route.Path),
+			Parameters:  params,
+			Responses: map[string]OpenAPIResponse{
+				"default": {Description: "Response"},
+			},
+		}
+
+		for _, tag := range route.Tags {
+			if !seenTags[tag] {
+				seenTags[tag] = true
+				spec.Tags = append(spec.Tags, OpenAPITag{Name: tag})
+			}
+		}
+	}
+
+	return spec
+}
+
+func (rt *Router) ServeOpenAPI(path string, info OpenAPIInfo) *Route {
+	return rt.GET(path, func(c *Context) {
+		c.JSON(200, rt.OpenAPI(info))
+	})
+}
+
+func openAPIPath(path string) (string, []OpenAPIParameter) {
+	var params []OpenAPIParameter
+
+	segments := strings.Split(path, "/")
+	for i, segment := range segments {
+		if strings.HasPrefix(segment, ":") || strings.HasPrefix(segment, "*") {
+			name := segment[1:]
+			segments[i] = "{" + name + "}"
+			params = append(params, OpenAPIParameter{
+				Name:     name,
+				In:       "path",
+				Required: true,
+				Schema:   H{"type": "string"},
+			})
+		}
+	}
+
+	return strings.Join(segments, "/"), params
+}
+
+func operationID(method, path string) string {
+	var b strings.Builder
+	b.WriteString(strings.ToLower(method))
+
+	for _, segment := range strings.Split(path, "/") {
+		segment = strings.TrimLeft(segment, ":*")
+		for _, part := range strings.FieldsFunc(segment, func(r rune) bool {
+			return !(r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r >= '0' && r <= '9')
+		}) {
+			b.WriteString(strings.ToUpper(part[:1]) + part[1:])
+		}
+	}
+	return b.String()
+}
@@ -0,0 +1,243 @@
+package goify
+
+import (
+	"fmt"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+type CircuitState string
+
+const (
+	CircuitClosed CircuitState = "closed"
+	CircuitOpen   CircuitState = "open"
+)
+
+type PanicBudgetConfig struct {
+	MaxPanics     int
+	Window        time.Duration
+	Cooldown      time.Duration
+	OnStateChange func(c *Context, event PanicCircuitEvent)
+}
+
+type PanicCircuitEvent struct {
+	Route     string       `json:"route"`
+	State     CircuitState `json:"state"`
+	Panics    int          `json:"panics"`
+	OpenUntil time.Time    `json:"open_until"`
+	Time      time.Time    `json:"time"`
+}
+
+func DefaultPanicBudgetConfig() PanicBudgetConfig {
+	return PanicBudgetConfig{
+		MaxPanics: 5,
+		Window:    time.Minute,
+		Cooldown:  30 * time.Second,
+	}
+}
+
+type PanicBudget struct {
+	mu     sync.Mutex
+	config PanicBudgetConfig
+	routes map[string]*routeCircuit
+	stats  map[string]int64
+}
+
+type routeCircuit struct {
+	panics    []time.Time
+	openUntil time.Time
+}
+
+func NewPanicBudget(config PanicBudgetConfig) *PanicBudget {
+	defaults := DefaultPanicBudgetConfig()
+	if config.MaxPanics <= 0 {
+		config.MaxPanics = defaults.MaxPanics
+	}
+	if config.Window <= 0 {
+		config.Window = defaults.Window
+	}
+	if config.Cooldown <= 0 {
+		config.Cooldown = defaults.Cooldown
+	}
+
+	return &PanicBudget{
+		config: config,
+		routes: make(map[string]*routeCircuit),
+		stats:  make(map[string]int64),
+	}
+}
+
+func (pb *PanicBudget) Middleware() MiddlewareFunc {
+	return func(c *Context, next func()) {
+		if c.fullPath == "" {
+			next()
+			return
+		}
+
+		route := c.Request.Method + " " + c.fullPath
+		now := time.Now()
+
+		openUntil, closed := pb.check(route, now)
+		if closed {
+			pb.notify(c, PanicCircuitEvent{Route: route, State: CircuitClosed, Time: now})
+		}
+		if !openUntil.IsZero() {
+			c.SetHeader("Retry-After", strconv.Itoa(ceilSeconds(openUntil.Sub(now))))
+			c.SendError(http.StatusServiceUnavailable, "Service temporarily unavailable")
+			return
+		}
+
+		defer func() {
+			if err := recover(); err != nil {
+				if err != http.ErrAbortHandler {
+					pb.record(c, route)
+				}
+				panic(err)
+			}
+		}()
+
+		next()
+
+		if c.panicked {
+			pb.record(c, route)
+		}
+	}
+}
+
+func (pb *PanicBudget) record(c *Context, route string) {
+	if event, opened := pb.recordPanic(route, time.Now()); opened {
+		pb.notify(c, event)
+	}
+}
+
+func (pb *PanicBudget) check(route string, now time.Time) (time.Time, bool) {
+	pb.mu.Lock()
+	defer pb.mu.Unlock()
+
+	circuit, exists := pb.routes[route]
+	if !exists || circuit.openUntil.IsZero() {
+		return time.Time{}, false
+	}
+
+	if now.Before(circuit.openUntil) {
+		pb.stats["rejected"]++
+		return circuit.openUntil, false
+	}
+
+	circuit.openUntil = time.Time{}
+	circuit.panics = nil
+	pb.stats["closed"]++
+	return time.Time{}, true
+}
+
+func (pb *PanicBudget) recordPanic(route string, now time.Time) (PanicCircuitEvent, bool) {
+	pb.mu.Lock()
+	defer pb.mu.Unlock()
+
+	pb.stats["panics"]++
+
+	circuit, exists := pb.routes[route]
+	if !exists {
+		circuit = &routeCircuit{}
+		pb.routes[route] = circuit
+	}
+	if !circuit.openUntil.IsZero() {
+		return PanicCircuitEvent{}, false
+	}
+
+	var recent []time.Time
+	for _, panicTime := range circuit.panics {
+		if now.Sub(panicTime) < pb.config.Window {
+			recent = append(recent, panicTime)
+		}
+	}
+	circuit.panics = append(recent, now)
+
+	if len(circuit.panics) <= pb.config.MaxPanics {
+		return PanicCircuitEvent{}, false
+	}
+
+	circuit.openUntil = now.Add(pb.config.Cooldown)
+	pb.stats["opened"]++
+	return PanicCircuitEvent{
+		Route:     route,
+		State:     CircuitOpen,
+		Panics:    len(circuit.panics),
+		OpenUntil: circuit.openUntil,
+		Time:      now,
+	}, true
+}
+
+func (pb *PanicBudget) notify(c *Context, event PanicCircuitEvent) {
+	if pb.config.OnStateChange != nil {
+		pb.config.OnStateChange(c, event)
+		return
+	}
+
+	if event.State == CircuitOpen {
+		c.Logger().Error("route disabled after repeated panics",
+			"route", event.Route,
+			"panics", event.Panics,
+			"window", pb.config.Window,
+			"open_until", event.OpenUntil,
+		)
+		return
+	}
+	c.Logger().Info("route re-enabled after panic cool-down", "route", event.Route)
+}
+
+func (pb *PanicBudget) OpenRoutes() []string {
+	pb.mu.Lock()
+	defer pb.mu.Unlock()
+
+	now := time.Now()
+	var routes []string
+	for route, circuit := range pb.routes {
+		if now.Before(circuit.openUntil) {
+			routes = append(routes, route)
+		}
+	}
+	sort.Strings(routes)
+	return routes
+}
+
+func (pb *PanicBudget) Reset(route string) {
+	pb.mu.Lock()
+	defer pb.mu.Unlock()
+	delete(pb.routes, route)
+}
+
+func (pb *PanicBudget) HealthCheck() HealthChecker {
+	return func() HealthCheck {
+		routes := pb.OpenRoutes()
+		if len(routes) == 0 {
+			return HealthCheck{
+				Name:    "panic_budget",
+				Status:  StatusHealthy,
+				Message: "No routes are disabled",
+			}
+		}
+
+		return HealthCheck{
+			Name:    "panic_budget",
+			Status:  StatusDegraded,
+			Message: fmt.Sprintf("Routes disabled after repeated panics: %s", strings.Join(routes, ", ")),
+			Data:    map[string]interface{}{"routes": routes},
+		}
+	}
+}
+
+func (pb *PanicBudget) Stats() map[string]int64 {
+	pb.mu.Lock()
+	defer pb.mu.Unlock()
+
+	stats := make(map[string]int64, len(pb.stats))
+	for name, count := range pb.stats {
+		stats[name] = count
+	}
+	return stats
+}
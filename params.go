@@ -20,7 +20,7 @@ func NewRouteNode() *RouteNode {
 	}
 }
 
-func (node *RouteNode) addRoute (path, method string, handler HandlerFunc) {
+func (node *RouteNode) addRoute (rt *Router, path, method string, handler HandlerFunc) {
 	segments := splitPath(path)
 	current := node
 
@@ -35,6 +35,8 @@ func (node *RouteNode) addRoute (path, method string, handler HandlerFunc) {
 				current.children["*param*"] = NewRouteNode()
 				current.children["*param*"].isParam = true
 				current.children["*param*"].paramKey = paramKey
+			} else if existing := current.children["*param*"].paramKey; existing != paramKey {
+				rt.warnOnce("param:"+path, "route %s uses parameter :%s where another route already uses :%s; the value will be available as :%s", path, paramKey, existing, existing)
 			}
 			current = current.children["*param*"]
 		} else if strings.HasPrefix(segment, "*") {
@@ -54,50 +56,60 @@ func (node *RouteNode) addRoute (path, method string, handler HandlerFunc) {
 		}
 	}
 	
+	if _, exists := current.handlers[method]; exists {
+		rt.warnOnce("route:"+method+" "+path, "route %s %s conflicts with an existing route; the previous handler is overwritten", method, path)
+	}
 	current.handlers[method] = handler
 	current.path = path
 }
 
-func (node *RouteNode) findRoute(path string, method string) (HandlerFunc, map[string]string) {
+func (node *RouteNode) findRoute(path string, method string) (HandlerFunc, map[string]string, []string, string) {
 	segments := splitPath(path)
 	params := make(map[string]string)
+	var keys []string
 	
-	handler := node.searchRoute(segments, 0, method, params)
-	return handler, params
+	matched := node.searchRoute(segments, 0, method, params, &keys)
+	if matched == nil {
+		return nil, params, keys, ""
+	}
+	return matched.handlers[method], params, keys, matched.path
 }
 
-func (node *RouteNode) searchRoute(segments []string, index int, method string, params map[string]string) HandlerFunc {
+func (node *RouteNode) searchRoute(segments []string, index int, method string, params map[string]string, keys *[]string) *RouteNode {
 	if index >= len(segments) {
-		if handler, exists := node.handlers[method]; exists {
-			return handler
+		if _, exists := node.handlers[method]; exists {
+			return node
 		}
 		return nil
 	}
 	
 	segment := segments[index]
 	if segment == "" {
-		return node.searchRoute(segments, index+1, method, params)
+		return node.searchRoute(segments, index+1, method, params, keys)
 	}
 
 	if child, exists := node.children[segment]; exists {
-		if handler := child.searchRoute(segments, index+1, method, params); handler != nil {
-			return handler
+		if matched := child.searchRoute(segments, index+1, method, params, keys); matched != nil {
+			return matched
 		}
 	}
 
 	if paramNode, exists := node.children["*param*"]; exists {
 		params[paramNode.paramKey] = segment
-		if handler := paramNode.searchRoute(segments, index+1, method, params); handler != nil {
-			return handler
+		*keys = append(*keys, paramNode.paramKey)
+		if matched := paramNode.searchRoute(segments, index+1, method, params, keys); matched != nil {
+			return matched
 		}
+		*keys = (*keys)[:len(*keys)-1]
 		delete(params, paramNode.paramKey)
 	}
 
 	if wildNode, exists := node.children["*wild*"]; exists {
 		remaining := strings.Join(segments[index:], "/")
-		params[wildNode.paramKey] = remaining
-		if handler, exists := wildNode.handlers[method]; exists {
-			return handler
+		if _, exists := wildNode.handlers[method]; exists {
+			params[wildNode.paramKey] = remaining
+			*keys = append(*keys, wildNode.paramKey)
+			return wildNode
 		}
 	}
 	
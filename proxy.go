@@ -0,0 +1,93 @@
+package goify
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/http/httputil"
+	"net/url"
+	"strings"
+)
+
+type ProxyOptions struct {
+	StripPrefix    string
+	Rewrite        func(path string) string
+	PreserveHost   bool
+	Headers        map[string]string
+	Transport      http.RoundTripper
+	ModifyResponse func(*http.Response) error
+}
+
+type proxyContextKey struct{}
+
+func Proxy(target string, opts ...ProxyOptions) HandlerFunc {
+	var options ProxyOptions
+	if len(opts) > 0 {
+		options = opts[0]
+	}
+
+	targetURL, err := url.Parse(target)
+	if err != nil || targetURL.Scheme == "" || targetURL.Host == "" {
+		panic(fmt.Sprintf("goify: invalid proxy target %q", target))
+	}
+
+	proxy := &httputil.ReverseProxy{
+		Rewrite: func(pr *httputil.ProxyRequest) {
+			c := pr.In.Context().Value(proxyContextKey{}).(*Context)
+
+			path := pr.In.URL.Path
+			if options.StripPrefix != "" {
+				path = strings.TrimPrefix(path, strings.TrimSuffix(options.StripPrefix, "/"))
+				if !strings.HasPrefix(path, "/") {
+					path = "/" + path
+				}
+			}
+			if options.Rewrite != nil {
+				path = options.Rewrite(path)
+			}
+			pr.Out.URL.Path = path
+			pr.Out.URL.RawPath = ""
+
+			pr.SetURL(targetURL)
+			pr.SetXForwarded()
+			chainForwardedFor(c, pr)
+
+			if options.PreserveHost {
+				pr.Out.Host = pr.In.Host
+			}
+			for name, value := range options.Headers {
+				pr.Out.Header.Set(name, value)
+			}
+		},
+		Transport:      options.Transport,
+		ModifyResponse: options.ModifyResponse,
+		ErrorHandler: func(w http.ResponseWriter, req *http.Request, err error) {
+			c := req.Context().Value(proxyContextKey{}).(*Context)
+			if errors.Is(err, context.Canceled) {
+				return
+			}
+
+			code := http.StatusBadGateway
+			var netErr net.Error
+			if errors.Is(err, context.DeadlineExceeded) || (errors.As(err, &netErr) && netErr.Timeout()) {
+				code = http.StatusGatewayTimeout
+			}
+			c.Error(NewHTTPError(code, http.StatusText(code)).Wrap(err))
+		},
+	}
+
+	return func(c *Context) {
+		req := c.Request.WithContext(context.WithValue(c.Request.Context(), proxyContextKey{}, c))
+		proxy.ServeHTTP(c.Response, req)
+	}
+}
+
+func chainForwardedFor(c *Context, pr *httputil.ProxyRequest) {
+	prior := pr.In.Header.Values("X-Forwarded-For")
+	if len(prior) == 0 || !c.router.isTrustedProxy(net.ParseIP(c.RemoteIP())) {
+		return
+	}
+	pr.Out.Header.Set("X-Forwarded-For", strings.Join(prior, ", ")+", "+c.RemoteIP())
+}
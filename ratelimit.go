@@ -0,0 +1,353 @@
+package goify
+
+import (
+	"fmt"
+	"math"
+	"net"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+)
+
+type RateLimitStrategy string
+
+const (
+	RateLimitSlidingWindow RateLimitStrategy = "sliding-window"
+	RateLimitTokenBucket   RateLimitStrategy = "token-bucket"
+)
+
+type RateLimitConfig struct {
+	Limit         int
+	Window        time.Duration
+	Burst         int
+	Strategy      RateLimitStrategy
+	EnableHeaders bool
+	KeyFunc       func(c *Context) string
+	Exempt        func(c *Context) bool
+	OnLimited     func(c *Context, event RateLimitEvent)
+}
+
+type RateLimitEvent struct {
+	Key        string        `json:"key"`
+	ClientIP   string        `json:"client_ip"`
+	Method     string        `json:"method"`
+	Path       string        `json:"path"`
+	Limit      int           `json:"limit"`
+	RetryAfter time.Duration `json:"retry_after"`
+	Time       time.Time     `json:"time"`
+}
+
+func DefaultRateLimitConfig() RateLimitConfig {
+	return RateLimitConfig{
+		Limit:         100,
+		Window:        time.Minute,
+		Strategy:      RateLimitSlidingWindow,
+		EnableHeaders: true,
+		KeyFunc:       KeyByIP,
+	}
+}
+
+func KeyByIP(c *Context) string {
+	return c.ClientIP()
+}
+
+func KeyByHeader(name string) func(c *Context) string {
+	return func(c *Context) string {
+		if value := c.GetHeader(name); value != "" {
+			return name + ":" + value
+		}
+		return ""
+	}
+}
+
+func KeyByContext(key string) func(c *Context) string {
+	return func(c *Context) string {
+		if value, exists := c.Get(key); exists && value != nil {
+			return key + ":" + fmt.Sprint(value)
+		}
+		return ""
+	}
+}
+
+func KeyByRoute(c *Context) string {
+	return c.Request.Method + " " + c.routeTemplate() + " " + c.ClientIP()
+}
+
+func ExemptIPs(networks ...string) func(c *Context) bool {
+	parsed := make([]*net.IPNet, 0, len(networks))
+	for _, value := range networks {
+		network, err := parseNetwork(value)
+		if err != nil {
+			panic(fmt.Sprintf("goify: invalid exempt network %q", value))
+		}
+		parsed = append(parsed, network)
+	}
+
+	return func(c *Context) bool {
+		ip := net.ParseIP(c.ClientIP())
+		for _, network := range parsed {
+			if ip != nil && network.Contains(ip) {
+				return true
+			}
+		}
+		return false
+	}
+}
+
+func ExemptHeader(name string, values ...string) func(c *Context) bool {
+	allowed := make(map[string]bool, len(values))
+	for _, value := range values {
+		allowed[value] = true
+	}
+
+	return func(c *Context) bool {
+		value := c.GetHeader(name)
+		return value != "" && allowed[value]
+	}
+}
+
+func ExemptPaths(paths ...string) func(c *Context) bool {
+	return func(c *Context) bool {
+		return matchPaths(c, paths)
+	}
+}
+
+func ExemptAny(exemptions ...func(c *Context) bool) func(c *Context) bool {
+	return func(c *Context) bool {
+		for _, exempt := range exemptions {
+			if exempt(c) {
+				return true
+			}
+		}
+		return false
+	}
+}
+
+type RateLimiter struct {
+	mu          sync.Mutex
+	config      RateLimitConfig
+	requests    map[string][]time.Time
+	buckets     map[string]*tokenBucket
+	lastCleanup time.Time
+	stats       map[string]int64
+}
+
+type tokenBucket struct {
+	tokens  float64
+	updated time.Time
+}
+
+type rateLimitResult struct {
+	allowed    bool
+	remaining  int
+	reset      time.Duration
+	retryAfter time.Duration
+}
+
+func NewRateLimiter(limit int, window time.Duration) *RateLimiter {
+	config := DefaultRateLimitConfig()
+	config.Limit = limit
+	config.Window = window
+	return NewRateLimiterWithConfig(config)
+}
+
+func NewRateLimiterWithConfig(config RateLimitConfig) *RateLimiter {
+	defaults := DefaultRateLimitConfig()
+	if config.Limit <= 0 {
+		config.Limit = defaults.Limit
+	}
+	if config.Window <= 0 {
+		config.Window = defaults.Window
+	}
+	if config.Strategy == "" {
+		config.Strategy = defaults.Strategy
+	}
+	if config.Burst <= 0 {
+		config.Burst = config.Limit
+	}
+	if config.KeyFunc == nil {
+		config.KeyFunc = defaults.KeyFunc
+	}
+
+	return &RateLimiter{
+		config:      config,
+		requests:    make(map[string][]time.Time),
+		buckets:     make(map[string]*tokenBucket),
+		lastCleanup: time.Now(),
+		stats:       make(map[string]int64),
+	}
+}
+
+func (rl *RateLimiter) Stats() map[string]int64 {
+	rl.mu.Lock()
+	defer rl.mu.Unlock()
+
+	stats := make(map[string]int64, len(rl.stats))
+	for name, count := range rl.stats {
+		stats[name] = count
+	}
+	return stats
+}
+
+func (rl *RateLimiter) count(name string) {
+	rl.mu.Lock()
+	rl.stats[name]++
+	rl.mu.Unlock()
+}
+
+func (rl *RateLimiter) Middleware() MiddlewareFunc {
+	return func(c *Context, next func()) {
+		if rl.config.Exempt != nil && rl.config.Exempt(c) {
+			rl.count("exempted")
+			next()
+			return
+		}
+
+		key := rl.config.KeyFunc(c)
+		if key == "" {
+			key = c.ClientIP()
+		}
+		result := rl.take(key, time.Now())
+
+		if rl.config.EnableHeaders {
+			limit := rl.config.Limit
+			if rl.config.Strategy == RateLimitTokenBucket {
+				limit = rl.config.Burst
+			}
+			c.SetHeader("X-RateLimit-Limit", strconv.Itoa(limit))
+			c.SetHeader("X-RateLimit-Remaining", strconv.Itoa(result.remaining))
+			c.SetHeader("X-RateLimit-Reset", strconv.Itoa(ceilSeconds(result.reset)))
+		}
+
+		if !result.allowed {
+			rl.count("limited")
+			rl.limited(c, key, result)
+			c.SetHeader("Retry-After", strconv.Itoa(ceilSeconds(result.retryAfter)))
+			c.SendError(http.StatusTooManyRequests, "Too many requests", "Rate limit exceeded")
+			return
+		}
+
+		rl.count("allowed")
+		next()
+	}
+}
+
+func (rl *RateLimiter) limited(c *Context, key string, result rateLimitResult) {
+	event := RateLimitEvent{
+		Key:        key,
+		ClientIP:   c.ClientIP(),
+		Method:     c.Request.Method,
+		Path:       c.Request.URL.Path,
+		Limit:      rl.config.Limit,
+		RetryAfter: result.retryAfter,
+		Time:       time.Now(),
+	}
+
+	if rl.config.OnLimited != nil {
+		rl.config.OnLimited(c, event)
+		return
+	}
+
+	c.Logger().Info("rate limit exceeded",
+		"key", event.Key,
+		"client_ip", event.ClientIP,
+		"method", event.Method,
+		"path", event.Path,
+		"limit", event.Limit,
+		"retry_after", event.RetryAfter,
+	)
+}
+
+func (rl *RateLimiter) take(key string, now time.Time) rateLimitResult {
+	rl.mu.Lock()
+	defer rl.mu.Unlock()
+
+	rl.cleanup(now)
+
+	if rl.config.Strategy == RateLimitTokenBucket {
+		return rl.takeToken(key, now)
+	}
+	return rl.takeWindow(key, now)
+}
+
+func (rl *RateLimiter) takeWindow(key string, now time.Time) rateLimitResult {
+	var validRequests []time.Time
+	for _, reqTime := range rl.requests[key] {
+		if now.Sub(reqTime) < rl.config.Window {
+			validRequests = append(validRequests, reqTime)
+		}
+	}
+
+	if len(validRequests) >= rl.config.Limit {
+		rl.requests[key] = validRequests
+		wait := validRequests[0].Add(rl.config.Window).Sub(now)
+		return rateLimitResult{
+			remaining:  0,
+			reset:      wait,
+			retryAfter: wait,
+		}
+	}
+
+	validRequests = append(validRequests, now)
+	rl.requests[key] = validRequests
+	return rateLimitResult{
+		allowed:   true,
+		remaining: rl.config.Limit - len(validRequests),
+		reset:     validRequests[0].Add(rl.config.Window).Sub(now),
+	}
+}
+
+func (rl *RateLimiter) takeToken(key string, now time.Time) rateLimitResult {
+	capacity := float64(rl.config.Burst)
+	perToken := rl.config.Window / time.Duration(rl.config.Limit)
+
+	bucket, exists := rl.buckets[key]
+	if !exists {
+		bucket = &tokenBucket{tokens: capacity, updated: now}
+		rl.buckets[key] = bucket
+	}
+
+	elapsed := now.Sub(bucket.updated)
+	bucket.tokens = math.Min(capacity, bucket.tokens+float64(elapsed)/float64(perToken))
+	bucket.updated = now
+
+	result := rateLimitResult{}
+	if bucket.tokens >= 1 {
+		bucket.tokens--
+		result.allowed = true
+	} else {
+		result.retryAfter = time.Duration((1 - bucket.tokens) * float64(perToken))
+	}
+
+	result.remaining = int(bucket.tokens)
+	result.reset = time.Duration((capacity - bucket.tokens) * float64(perToken))
+	return result
+}
+
+func (rl *RateLimiter) cleanup(now time.Time) {
+	if now.Sub(rl.lastCleanup) < rl.config.Window {
+		return
+	}
+	rl.lastCleanup = now
+
+	for key, requests := range rl.requests {
+		if len(requests) == 0 || now.Sub(requests[len(requests)-1]) >= rl.config.Window {
+			delete(rl.requests, key)
+		}
+	}
+
+	perToken := rl.config.Window / time.Duration(rl.config.Limit)
+	for key, bucket := range rl.buckets {
+		if bucket.tokens+float64(now.Sub(bucket.updated))/float64(perToken) >= float64(rl.config.Burst) {
+			delete(rl.buckets, key)
+		}
+	}
+}
+
+func ceilSeconds(d time.Duration) int {
+	if d <= 0 {
+		return 0
+	}
+	return int(math.Ceil(d.Seconds()))
+}
@@ -0,0 +1,131 @@
+package goify
+
+import "strings"
+
+type Policy interface {
+	Allow(c *Context) bool
+}
+
+type PolicyFunc func(c *Context) bool
+
+func (f PolicyFunc) Allow(c *Context) bool {
+	return f(c)
+}
+
+func (c *Context) SetRoles(roles ...string) {
+	c.Set("roles", roles)
+}
+
+func (c *Context) Roles() []string {
+	if value, exists := c.Get("roles"); exists {
+		if roles, ok := value.([]string); ok {
+			return roles
+		}
+	}
+	return nil
+}
+
+func (c *Context) SetPermissions(permissions ...string) {
+	c.Set("permissions", permissions)
+}
+
+func (c *Context) Permissions() []string {
+	if value, exists := c.Get("permissions"); exists {
+		if permissions, ok := value.([]string); ok {
+			return permissions
+		}
+	}
+	return nil
+}
+
+func (c *Context) HasRole(roles ...string) bool {
+	for _, granted := range c.Roles() {
+		for _, role := range roles {
+			if granted == role {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func (c *Context) HasPermission(permissions ...string) bool {
+	granted := c.Permissions()
+	for _, permission := range permissions {
+		if !permissionGranted(granted, permission) {
+			return false
+		}
+	}
+	return true
+}
+
+func permissionGranted(granted []string, permission string) bool {
+	for _, grant := range granted {
+		if grant == permission || grant == "*" {
+			return true
+		}
+		if prefix, ok := strings.CutSuffix(grant, ":*"); ok && strings.HasPrefix(permission, prefix+":") {
+			return true
+		}
+	}
+	return false
+}
+
+func RolePolicy(roles ...string) Policy {
+	return PolicyFunc(func(c *Context) bool {
+		return c.HasRole(roles...)
+	})
+}
+
+func PermissionPolicy(permissions ...string) Policy {
+	return PolicyFunc(func(c *Context) bool {
+		return c.HasPermission(permissions...)
+	})
+}
+
+func AnyPolicy(policies ...Policy) Policy {
+	return PolicyFunc(func(c *Context) bool {
+		for _, policy := range policies {
+			if policy.Allow(c) {
+				return true
+			}
+		}
+		return false
+	})
+}
+
+func AllPolicies(policies ...Policy) Policy {
+	return PolicyFunc(func(c *Context) bool {
+		for _, policy := range policies {
+			if !policy.Allow(c) {
+				return false
+			}
+		}
+		return true
+	})
+}
+
+func RequireRoles(roles ...string) MiddlewareFunc {
+	if len(roles) == 0 {
+		panic("goify: RequireRoles requires at least one role")
+	}
+	return RequirePolicy(RolePolicy(roles...))
+}
+
+func RequirePermissions(permissions ...string) MiddlewareFunc {
+	if len(permissions) == 0 {
+		panic("goify: RequirePermissions requires at least one permission")
+	}
+	return RequirePolicy(PermissionPolicy(permissions...))
+}
+
+func RequirePolicy(policy Policy) MiddlewareFunc {
+	return func(c *Context, next func()) {
+		if !policy.Allow(c) {
+			c.SendForbidden("Insufficient permissions")
+			return
+		}
+
+		next()
+	}
+}
@@ -0,0 +1,355 @@
+package goify
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strings"
+	"sync/atomic"
+	"time"
+	"unicode/utf8"
+)
+
+const redactedValue = "[REDACTED]"
+
+const DefaultRecordMaxBodySize = 1 << 20
+
+type RecordedBody struct {
+	Encoding  string `json:"encoding,omitempty"`
+	Data      string `json:"data"`
+	Truncated bool   `json:"truncated,omitempty"`
+}
+
+type RecordedRequest struct {
+	Method string       `json:"method"`
+	URL    string       `json:"url"`
+	Header http.Header  `json:"header,omitempty"`
+	Body   RecordedBody `json:"body"`
+}
+
+type RecordedResponse struct {
+	Status int          `json:"status"`
+	Header http.Header  `json:"header,omitempty"`
+	Body   RecordedBody `json:"body"`
+}
+
+type RecordedExchange struct {
+	RecordedAt time.Time        `json:"recorded_at"`
+	Request    RecordedRequest  `json:"request"`
+	Response   RecordedResponse `json:"response"`
+}
+
+type RecordConfig struct {
+	Dir           string
+	RedactHeaders []string
+	MaxBodySize   int64
+	Skip          func(*Context) bool
+}
+
+var defaultRedactHeaders = []string{"Authorization", "Cookie", "Set-Cookie", "X-Api-Key"}
+
+var recordSequence uint64
+
+type recordingWriter struct {
+	http.ResponseWriter
+	status    int
+	body      bytes.Buffer
+	limit     int64
+	truncated bool
+}
+
+func (w *recordingWriter) WriteHeader(code int) {
+	if w.status == 0 {
+		w.status = code
+	}
+	w.ResponseWriter.WriteHeader(code)
+}
+
+func (w *recordingWriter) Write(b []byte) (int, error) {
+	if w.status == 0 {
+		w.status = http.StatusOK
+	}
+	if room := w.limit - int64(w.body.Len()); room >= int64(len(b)) {
+		w.body.Write(b)
+	} else {
+		w.body.Write(b[:max(room, 0)])
+		w.truncated = true
+	}
+	return w.ResponseWriter.Write(b)
+}
+
+func (w *recordingWriter) Flush() {
+	if f, ok := w.ResponseWriter.(http.Flusher); ok {
+		f.Flush()
+	}
+}
+
+func Record(dir string) MiddlewareFunc {
+	return RecordWithConfig(RecordConfig{Dir: dir})
+}
+
+func RecordWithConfig(config RecordConfig) MiddlewareFunc {
+	if config.Dir == "" {
+		config.Dir = "testdata/recordings"
+	}
+	if config.RedactHeaders == nil {
+		config.RedactHeaders = defaultRedactHeaders
+	}
+	if config.MaxBodySize <= 0 {
+		config.MaxBodySize = DefaultRecordMaxBodySize
+	}
+
+	return func(c *Context, next func()) {
+		if config.Skip != nil && config.Skip(c) {
+			next()
+			return
+		}
+
+		var reqBody []byte
+		reqTruncated := false
+		if c.Request.Body != nil {
+			body := c.Request.Body
+			reqBody, _ = io.ReadAll(io.LimitReader(body, config.MaxBodySize+1))
+			if int64(len(reqBody)) > config.MaxBodySize {
+				reqTruncated = true
+				c.Request.Body = struct {
+					io.Reader
+					io.Closer
+				}{io.MultiReader(bytes.NewReader(reqBody), body), body}
+				reqBody = reqBody[:config.MaxBodySize]
+			} else {
+				body.Close()
+				c.Request.Body = io.NopCloser(bytes.NewReader(reqBody))
+			}
+		}
+
+		original := c.Response
+		writer := &recordingWriter{ResponseWriter: original, limit: config.MaxBodySize}
+		c.Response = writer
+
+		next()
+
+		c.Response = original
+
+		status := writer.status
+		if status == 0 {
+			status = http.StatusOK
+		}
+
+		requestBody := encodeRecordedBody(reqBody)
+		requestBody.Truncated = reqTruncated
+		responseBody := encodeRecordedBody(writer.body.Bytes())
+		responseBody.Truncated = writer.truncated
+
+		exchange := RecordedExchange{
+			RecordedAt: time.Now(),
+			Request: RecordedRequest{
+				Method: c.Request.Method,
+				URL:    c.Request.URL.RequestURI(),
+				Header: redactHeader(c.Request.Header, config.RedactHeaders),
+				Body:   requestBody,
+			},
+			Response: RecordedResponse{
+				Status: status,
+				Header: redactHeader(writer.Header(), config.RedactHeaders),
+				Body:   responseBody,
+			},
+		}
+
+		if err := saveRecording(config.Dir, exchange); err != nil {
+			log.Printf("Failed to save recording: %v", err)
+		}
+	}
+}
+
+func redactHeader(header http.Header, redact []string) http.Header {
+	result := header.Clone()
+	for _, name := range redact {
+		if _, exists := result[http.CanonicalHeaderKey(name)]; exists {
+			result.Set(name, redactedValue)
+		}
+	}
+	return result
+}
+
+func encodeRecordedBody(data []byte) RecordedBody {
+	if utf8.Valid(data) {
+		return RecordedBody{Data: string(data)}
+	}
+	return RecordedBody{Encoding: "base64", Data: base64.StdEncoding.EncodeToString(data)}
+}
+
+func (b RecordedBody) Bytes() ([]byte, error) {
+	if b.Encoding == "base64" {
+		return base64.StdEncoding.DecodeString(b.Data)
+	}
+	return []byte(b.Data), nil
+}
+
+func saveRecording(dir string, exchange RecordedExchange) error {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return err
+	}
+
+	name := strings.Trim(exchange.Request.URL, "/")
+	if i := strings.IndexByte(name, '?'); i >= 0 {
+		name = name[:i]
+	}
+	name = strings.NewReplacer("/", "_", ":", "_", "*", "_").Replace(name)
+	if name == "" {
+		name = "root"
+	}
+
+	seq := atomic.AddUint64(&recordSequence, 1)
+	filename := fmt.Sprintf("%d_%04d_%s_%s.json", exchange.RecordedAt.UnixNano(), seq, exchange.Request.Method, name)
+
+	data, err := json.MarshalIndent(exchange, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(filepath.Join(dir, filename), data, 0644)
+}
+
+func LoadRecordings(dir string) (map[string]RecordedExchange, error) {
+	files, err := filepath.Glob(filepath.Join(dir, "*.json"))
+	if err != nil {
+		return nil, err
+	}
+
+	recordings := make(map[string]RecordedExchange, len(files))
+	for _, file := range files {
+		data, err := os.ReadFile(file)
+		if err != nil {
+			return nil, err
+		}
+
+		var exchange RecordedExchange
+		if err := json.Unmarshal(data, &exchange); err != nil {
+			return nil, fmt.Errorf("invalid recording %s: %v", file, err)
+		}
+		recordings[file] = exchange
+	}
+
+	return recordings, nil
+}
+
+func (rt *Router) Test(req *http.Request) *http.Response {
+	recorder := httptest.NewRecorder()
+	rt.ServeHTTP(recorder, req)
+	return recorder.Result()
+}
+
+type ReplayResult struct {
+	File     string
+	Expected RecordedResponse
+	Status   int
+	Body     []byte
+	Match    bool
+	Skipped  bool
+	Diff     string
+}
+
+type ReplayOptions struct {
+	Header http.Header
+}
+
+func (rt *Router) Replay(dir string, opts ...ReplayOptions) ([]ReplayResult, error) {
+	var options ReplayOptions
+	if len(opts) > 0 {
+		options = opts[0]
+	}
+
+	recordings, err := LoadRecordings(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	files := make([]string, 0, len(recordings))
+	for file := range recordings {
+		files = append(files, file)
+	}
+	sort.Strings(files)
+
+	results := make([]ReplayResult, 0, len(files))
+	for _, file := range files {
+		exchange := recordings[file]
+		if exchange.Request.Body.Truncated {
+			results = append(results, ReplayResult{
+				File:     file,
+				Expected: exchange.Response,
+				Match:    true,
+				Skipped:  true,
+				Diff:     "request body was truncated during recording",
+			})
+			continue
+		}
+
+		reqBody, err := exchange.Request.Body.Bytes()
+		if err != nil {
+			return nil, fmt.Errorf("invalid request body in %s: %v", file, err)
+		}
+
+		req := httptest.NewRequest(exchange.Request.Method, exchange.Request.URL, bytes.NewReader(reqBody))
+		for key, values := range exchange.Request.Header {
+			for _, value := range values {
+				if value != redactedValue {
+					req.Header.Add(key, value)
+				}
+			}
+		}
+		for key, values := range options.Header {
+			req.Header[http.CanonicalHeaderKey(key)] = append([]string(nil), values...)
+		}
+
+		resp := rt.Test(req)
+		body, _ := io.ReadAll(resp.Body)
+		resp.Body.Close()
+
+		result := ReplayResult{
+			File:     file,
+			Expected: exchange.Response,
+			Status:   resp.StatusCode,
+			Body:     body,
+			Match:    true,
+		}
+
+		expectedBody, _ := exchange.Response.Body.Bytes()
+		if resp.StatusCode != exchange.Response.Status {
+			result.Match = false
+			result.Diff = fmt.Sprintf("status: expected %d, got %d", exchange.Response.Status, resp.StatusCode)
+		} else if exchange.Response.Body.Truncated {
+			if !bytes.HasPrefix(body, expectedBody) {
+				result.Match = false
+				result.Diff = fmt.Sprintf("body: expected prefix %q, got %q", expectedBody, body)
+			}
+		} else if !bodiesEqual(expectedBody, body) {
+			result.Match = false
+			result.Diff = fmt.Sprintf("body: expected %q, got %q", expectedBody, body)
+		}
+
+		results = append(results, result)
+	}
+
+	return results, nil
+}
+
+func bodiesEqual(expected, actual []byte) bool {
+	if bytes.Equal(expected, actual) {
+		return true
+	}
+
+	var expectedJSON, actualJSON interface{}
+	if json.Unmarshal(expected, &expectedJSON) != nil || json.Unmarshal(actual, &actualJSON) != nil {
+		return false
+	}
+	return reflect.DeepEqual(expectedJSON, actualJSON)
+}
@@ -0,0 +1,127 @@
+package goify
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+type RequestErrors struct {
+	Path   ValidationErrors `json:"path,omitempty"`
+	Query  ValidationErrors `json:"query,omitempty"`
+	Header ValidationErrors `json:"header,omitempty"`
+	Body   ValidationErrors `json:"body,omitempty"`
+}
+
+func (e *RequestErrors) Error() string {
+	var parts []string
+	for _, source := range []struct {
+		name   string
+		errors ValidationErrors
+	}{{"path", e.Path}, {"query", e.Query}, {"header", e.Header}, {"body", e.Body}} {
+		if len(source.errors) > 0 {
+			parts = append(parts, fmt.Sprintf("%s: %s", source.name, source.errors.Error()))
+		}
+	}
+	return strings.Join(parts, "; ")
+}
+
+func (e *RequestErrors) All() ValidationErrors {
+	var all ValidationErrors
+	all = append(all, e.Path...)
+	all = append(all, e.Query...)
+	all = append(all, e.Header...)
+	return append(all, e.Body...)
+}
+
+func (e *RequestErrors) empty() bool {
+	return len(e.Path) == 0 && len(e.Query) == 0 && len(e.Header) == 0 && len(e.Body) == 0
+}
+
+func (c *Context) BindRequest(obj interface{}) error {
+	rv := reflect.ValueOf(obj)
+	if rv.Kind() != reflect.Ptr || rv.Elem().Kind() != reflect.Struct {
+		return fmt.Errorf("obj must be a pointer to struct")
+	}
+
+	errs := &RequestErrors{}
+	if c.Request.ContentLength != 0 {
+		if err := c.bindByContentType(obj); err != nil {
+			if !errors.As(err, &errs.Body) {
+				return err
+			}
+		}
+	}
+
+	var missing ValidationErrors
+	if err := c.bindQueryValues(rv.Elem(), c.Request.URL.Query(), &missing, &errs.Query); err != nil {
+		return err
+	}
+	if err := c.bindHeaderFields(rv.Elem(), &errs.Header); err != nil {
+		return err
+	}
+	if err := c.bindParamFields(rv.Elem(), &errs.Path); err != nil {
+		return err
+	}
+	if !errs.empty() {
+		return errs
+	}
+
+	validationErrors := mergeMissing(c.ValidateStruct(obj), missing)
+	if len(validationErrors) == 0 {
+		return nil
+	}
+
+	sources := make(map[string]string)
+	requestSources(rv.Elem().Type(), sources)
+	for _, validationErr := range validationErrors {
+		name := validationErr.Field
+		if i := strings.IndexAny(name, ".["); i >= 0 {
+			name = name[:i]
+		}
+
+		switch sources[name] {
+		case "param":
+			errs.Path = append(errs.Path, validationErr)
+		case "query":
+			errs.Query = append(errs.Query, validationErr)
+		case "header":
+			errs.Header = append(errs.Header, validationErr)
+		default:
+			errs.Body = append(errs.Body, validationErr)
+		}
+	}
+	return errs
+}
+
+func requestSources(typ reflect.Type, sources map[string]string) {
+	for i := 0; i < typ.NumField(); i++ {
+		fieldType := typ.Field(i)
+
+		if fieldType.Anonymous && fieldType.Tag.Get("json") == "" {
+			embedded := fieldType.Type
+			if embedded.Kind() == reflect.Ptr {
+				embedded = embedded.Elem()
+			}
+			if embedded.Kind() == reflect.Struct && embedded != timeType {
+				requestSources(embedded, sources)
+				continue
+			}
+		}
+
+		name := fieldType.Name
+		if tag := fieldType.Tag.Get("json"); tag != "" {
+			if tagName := strings.Split(tag, ",")[0]; tagName != "" && tagName != "-" {
+				name = tagName
+			}
+		}
+
+		for _, source := range []string{"param", "query", "header"} {
+			if tag := fieldType.Tag.Get(source); tag != "" && tag != "-" {
+				sources[name] = source
+				break
+			}
+		}
+	}
+}
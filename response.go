@@ -1,6 +1,7 @@
 package goify
 
 import (
+	"bytes"
 	"encoding/json"
 	"fmt"
 	"net/http"
@@ -35,7 +36,7 @@ func (c *Context) SendError(code int, message string, details ...interface{}) er
 	return c.JSON(code, errorResp)
 }
 
-func (c *Context) SendSuccess(data interface{}, message ...string) error {
+func (c *Context) Send(code int, data interface{}, message ...string) error {
 	successResp := SuccessResponse{
 		Success: true,
 		Data:    data,
@@ -45,20 +46,19 @@ func (c *Context) SendSuccess(data interface{}, message ...string) error {
 		successResp.Message = message[0]
 	}
 	
-	return c.JSON(http.StatusOK, successResp)
+	return c.JSON(code, successResp)
+}
+
+func (c *Context) SendSuccess(data interface{}, message ...string) error {
+	return c.Send(http.StatusOK, data, message...)
 }
 
 func (c *Context) SendCreated(data interface{}, message ...string) error {
-	successResp := SuccessResponse{
-		Success: true,
-		Data:    data,
-	}
-	
-	if len(message) > 0 {
-		successResp.Message = message[0]
-	}
-	
-	return c.JSON(http.StatusCreated, successResp)
+	return c.Send(http.StatusCreated, data, message...)
+}
+
+func (c *Context) SendAccepted(data interface{}, message ...string) error {
+	return c.Send(http.StatusAccepted, data, message...)
 }
 
 func (c *Context) SendNoContent() error {
@@ -86,6 +86,9 @@ func (c *Context) SendValidationError(validationErrors interface{}) error {
 	case ValidationErrors:
 		message = "Validation failed"
 		details = ve
+	case *RequestErrors:
+		message = "Validation failed"
+		details = ve
 	case error:
 		message = ve.Error()
 		if validationErrs, ok := validationErrors.(ValidationErrors); ok {
@@ -119,14 +122,23 @@ func (c *Context) SendFieldError(field, message string) error {
 func (c *Context) SendFileUploadError(uploadErrors interface{}) error {
 	var message string
 	var details interface{}
+	code := 422
 	
 	switch ue := uploadErrors.(type) {
 	case FileUploadErrors:
 		message = "File upload failed"
 		details = ue
+		for _, uploadErr := range ue {
+			if uploadErr.Code == "upload_too_large" {
+				code = http.StatusRequestEntityTooLarge
+			}
+		}
 	case FileUploadError:
 		message = ue.Message
 		details = []FileUploadError{ue}
+		if ue.Code == "upload_too_large" {
+			code = http.StatusRequestEntityTooLarge
+		}
 	case error:
 		message = ue.Error()
 	default:
@@ -137,11 +149,11 @@ func (c *Context) SendFileUploadError(uploadErrors interface{}) error {
 	errorResp := ErrorResponse{
 		Error:   "File Upload Error",
 		Message: message,
-		Code:    422,
+		Code:    code,
 		Details: details,
 	}
 	
-	return c.JSON(422, errorResp)
+	return c.JSON(code, errorResp)
 }
 
 func (c *Context) SendFileTooBigError(maxSize int64) error {
@@ -175,17 +187,17 @@ func (c *Context) SendInternalError(message ...string) error {
 	return c.SendError(http.StatusInternalServerError, msg)
 }
 
-func (c *Context) SendFile(filepath string) error {
-	http.ServeFile(c.Response, c.Request, filepath)
-	return nil
+func (c *Context) SendFile(path string, opts ...DownloadOptions) error {
+	return c.serveFile(path, "", "", opts)
 }
 
-func (c *Context) Download(filepath, filename string) error {
-	if filename != "" {
-		c.SetHeader("Content-Disposition", fmt.Sprintf("attachment; filename=\"%s\"", filename))
+func (c *Context) SendFileFrom(root, name string, opts ...DownloadOptions) error {
+	path, err := SecureJoin(root, name)
+	if err != nil {
+		c.SendNotFound("File not found")
+		return err
 	}
-	http.ServeFile(c.Response, c.Request, filepath)
-	return nil
+	return c.SendFile(path, opts...)
 }
 
 func (c *Context) Stream(contentType string, fn func(http.ResponseWriter)) error {
@@ -196,10 +208,19 @@ func (c *Context) Stream(contentType string, fn func(http.ResponseWriter)) error
 }
 
 func (c *Context) JSONPretty(code int, obj interface{}, indent string) error {
+	data, err := marshalJSON(obj)
+	if err != nil {
+		return c.encodeFailed(obj, err)
+	}
+
+	var buf bytes.Buffer
+	if err := json.Indent(&buf, data, "", indent); err != nil {
+		return err
+	}
+	buf.WriteByte('\n')
+
 	c.SetHeader("Content-Type", "application/json")
 	c.Response.WriteHeader(code)
-	
-	encoder := json.NewEncoder(c.Response)
-	encoder.SetIndent("", indent)
-	return encoder.Encode(obj)
+	_, err = c.Response.Write(buf.Bytes())
+	return err
 }
\ No newline at end of file
@@ -1,26 +1,47 @@
 package goify
 
 import (
-	"context"
 	"fmt"
+	"log/slog"
+	"net"
 	"net/http"
 	"strings"
+	"sync"
 )
 
 type Router struct {
-	routes     map[string]map[string]HandlerFunc
-	tree       *RouteNode                
-	middleware []MiddlewareFunc
-	server     *http.Server
+	routes         map[string]map[string]HandlerFunc
+	tree           *RouteNode
+	middleware     []MiddlewareFunc
+	lifecycle      lifecycleState
+	jsonpParam     string
+	cookieKeys     []cookieKey
+	trustedProxies []*net.IPNet
+	cursors        *CursorSigner
+	warnings       warningState
+	logger         *slog.Logger
+	locales        []string
+	translations   *Translations
+	registered     []*Route
+	errorHandler   ErrorHandler
+	hosts          []*hostTable
+	dependencies   []*dependency
+	corsPolicies   []*corsPolicy
+	deferred       sync.WaitGroup
+	multipart      MultipartConfig
+	health         *HealthRegistry
 }
 
 type HandlerFunc func(*Context)
 
+var anyMethods = []string{"GET", "POST", "PUT", "DELETE", "PATCH", "HEAD", "OPTIONS"}
+
 func New() *Router {
 	return &Router{
 		routes:     make(map[string]map[string]HandlerFunc),
 		tree:       NewRouteNode(),
 		middleware: make([]MiddlewareFunc, 0),
+		health:     NewHealthRegistry(),
 	}
 }
 
@@ -28,85 +49,129 @@ func (rt *Router) ServeHTTP(w http.ResponseWriter, req *http.Request) {
 	method := req.Method
 	path := cleanPath(req.URL.Path)
 
-	handler, params := rt.tree.findRoute(path, method)
-	if handler != nil {
-		ctx := &Context{
-			Request:  req,
-			Response: w,
-			params:   params,
-			store:    make(map[string]interface{}),
-		}
+	handler, params, paramKeys, fullPath := rt.matchHost(req.Host, method, path)
+	if handler == nil {
+		handler, params, paramKeys, fullPath = lookupRoute(rt.routes, rt.tree, method, path)
+	}
 
-		rt.executeMiddleware(ctx, handler)
-		return
+	if handler == nil && method == http.MethodOptions && len(rt.corsPolicies) > 0 {
+		handler = rt.preflightHandler
 	}
 
-	if methodRoutes, exists := rt.routes[method]; exists {
-		if handler, found := methodRoutes[path]; found {
-			ctx := &Context{
-				Request:  req,
-				Response: w,
-				params:   make(map[string]string),
-				store:    make(map[string]interface{}),
-			}
-
-			rt.executeMiddleware(ctx, handler)
-			return
-		}
+	if handler == nil {
+		handler = notFoundHandler
 	}
 
-	http.NotFound(w, req)
+	ctx := &Context{
+		Request:   req,
+		params:    params,
+		paramKeys: paramKeys,
+		fullPath:  fullPath,
+		store:     make(map[string]interface{}),
+		router:    rt,
+		multipart: rt.multipart,
+	}
+	ctx.writer = &responseWriter{ResponseWriter: w, ctx: ctx}
+	ctx.Response = ctx.writer
+
+	defer ctx.Cleanup()
+
+	rt.executeMiddleware(ctx, handler)
+	ctx.finish()
+	ctx.runDeferred()
 }
 
-func (rt *Router) addRoute(method, path string, handler HandlerFunc) {
+func notFoundHandler(c *Context) {
+	http.NotFound(c.Response, c.Request)
+}
+
+func lookupRoute(routes map[string]map[string]HandlerFunc, tree *RouteNode, method, path string) (HandlerFunc, map[string]string, []string, string) {
+	if handler := routes[method][path]; handler != nil {
+		return handler, make(map[string]string), nil, path
+	}
+	return tree.findRoute(path, method)
+}
+
+func (rt *Router) addRoute(method, path string, handler HandlerFunc) *Route {
+	return rt.insertRoute(rt.routes, rt.tree, "", method, path, handler)
+}
+
+func (rt *Router) insertRoute(routes map[string]map[string]HandlerFunc, tree *RouteNode, host, method, path string, handler HandlerFunc) *Route {
 	path = cleanPath(path)
 
 	if strings.Contains(path, ":") || strings.Contains(path, "*") {
-		rt.tree.addRoute(path, method, handler)
+		tree.addRoute(rt, path, method, handler)
 	} else {
-		if rt.routes[method] == nil {
-			rt.routes[method] = make(map[string]HandlerFunc)
+		if routes[method] == nil {
+			routes[method] = make(map[string]HandlerFunc)
+		}
+		if _, exists := routes[method][path]; exists {
+			rt.warnOnce("route:"+method+" "+host+path, "route %s %s is registered more than once; the previous handler is overwritten", method, host+path)
 		}
-		rt.routes[method][path] = handler
+		routes[method][path] = handler
 	}
+
+	return rt.registerRoute(host, method, path)
+}
+
+func (rt *Router) GET(path string, handler HandlerFunc) *Route {
+	return rt.addRoute("GET", path, handler)
+}
+
+func (rt *Router) POST(path string, handler HandlerFunc) *Route {
+	return rt.addRoute("POST", path, handler)
 }
 
-func (rt *Router) GET(path string, handler HandlerFunc) {
-	rt.addRoute("GET", path, handler)
+func (rt *Router) PUT(path string, handler HandlerFunc) *Route {
+	return rt.addRoute("PUT", path, handler)
 }
 
-func (rt *Router) POST(path string, handler HandlerFunc) {
-	rt.addRoute("POST", path, handler)
+func (rt *Router) DELETE(path string, handler HandlerFunc) *Route {
+	return rt.addRoute("DELETE", path, handler)
 }
 
-func (rt *Router) PUT(path string, handler HandlerFunc) {
-	rt.addRoute("PUT", path, handler)
+func (rt *Router) PATCH(path string, handler HandlerFunc) *Route {
+	return rt.addRoute("PATCH", path, handler)
 }
 
-func (rt *Router) DELETE(path string, handler HandlerFunc) {
-	rt.addRoute("DELETE", path, handler)
+func (rt *Router) HEAD(path string, handler HandlerFunc) *Route {
+	return rt.addRoute("HEAD", path, handler)
 }
 
-func (rt *Router) PATCH(path string, handler HandlerFunc) {
-	rt.addRoute("PATCH", path, handler)
+func (rt *Router) Any(path string, handler HandlerFunc) {
+	for _, method := range anyMethods {
+		rt.addRoute(method, path, handler)
+	}
+}
+
+func (rt *Router) ServeDir(prefix, root string) {
+	prefix = strings.TrimSuffix(cleanPath(prefix), "/")
+	fileServer := http.StripPrefix(prefix, http.FileServer(http.Dir(root)))
+
+	handler := func(c *Context) {
+		fileServer.ServeHTTP(c.Response, c.Request)
+	}
+
+	rt.GET(prefix+"/*filepath", handler)
+	rt.HEAD(prefix+"/*filepath", handler)
 }
 
 func (rt *Router) Listen(addr string) error {
-	rt.server = &http.Server{
+	server := &http.Server{
 		Addr:    addr,
 		Handler: rt,
 	}
 	
-	fmt.Printf("🚀 Server started on http://localhost%s\n", addr)
-	return rt.server.ListenAndServe()
+	return rt.serve(server, func() (net.Listener, error) {
+		return rt.listen(addr)
+	})
 }
 
-func (rt *Router) Shutdown(ctx ...context.Context) error {
-	if rt.server != nil {
-		if len(ctx) > 0 {
-			return rt.server.Shutdown(ctx[0])
-		}
-		return rt.server.Close()
+func (rt *Router) listen(addr string) (net.Listener, error) {
+	listener, err := net.Listen("tcp", addr)
+	if err != nil {
+		return nil, err
 	}
-	return nil
-}
\ No newline at end of file
+	fmt.Printf("🚀 Server started on http://localhost%s\n", addr)
+	return listener, nil
+}
@@ -0,0 +1,96 @@
+package goify
+
+import (
+	"sort"
+)
+
+type Route struct {
+	host        string
+	method      string
+	path        string
+	summary     string
+	description string
+	tags        []string
+}
+
+type RouteInfo struct {
+	Host        string   `json:"host,omitempty"`
+	Method      string   `json:"method"`
+	Path        string   `json:"path"`
+	Summary     string   `json:"summary,omitempty"`
+	Description string   `json:"description,omitempty"`
+	Tags        []string `json:"tags,omitempty"`
+}
+
+func (r *Route) Summary(summary string) *Route {
+	r.summary = summary
+	return r
+}
+
+func (r *Route) Description(description string) *Route {
+	r.description = description
+	return r
+}
+
+func (r *Route) Tags(tags ...string) *Route {
+	r.tags = append(r.tags, tags...)
+	return r
+}
+
+func (r *Route) Info() RouteInfo {
+	return RouteInfo{
+		Host:        r.host,
+		Method:      r.method,
+		Path:        r.path,
+		Summary:     r.summary,
+		Description: r.description,
+		Tags:        append([]string(nil), r.tags...),
+	}
+}
+
+func (rt *Router) registerRoute(host, method, path string) *Route {
+	route := &Route{host: host, method: method, path: path}
+
+	for i, existing := range rt.registered {
+		if existing.host == host && existing.method == method && existing.path == path {
+			rt.registered[i] = route
+			return route
+		}
+	}
+
+	rt.registered = append(rt.registered, route)
+	return route
+}
+
+func (rt *Router) Routes() []RouteInfo {
+	routes := make([]RouteInfo, 0, len(rt.registered))
+	for _, route := range rt.registered {
+		routes = append(routes, route.Info())
+	}
+
+	sort.SliceStable(routes, func(i, j int) bool {
+		if routes[i].Host != routes[j].Host {
+			return routes[i].Host < routes[j].Host
+		}
+		if routes[i].Path != routes[j].Path {
+			return routes[i].Path < routes[j].Path
+		}
+		return methodOrder(routes[i].Method) < methodOrder(routes[j].Method)
+	})
+	return routes
+}
+
+func (rt *Router) RoutesHandler() HandlerFunc {
+	return func(c *Context) {
+		c.JSON(200, rt.Routes())
+	}
+}
+
+func methodOrder(method string) int {
+	for i, m := range []string{"GET", "HEAD", "POST", "PUT", "PATCH", "DELETE"} {
+		if m == method {
+			return i
+		}
+	}
+	return 100
+}
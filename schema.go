@@ -0,0 +1,39 @@
+package goify
+
+import (
+	"github.com/VsRnA/goify/validate"
+)
+
+type ValidationSchema = validate.Schema
+
+type FieldSchema = validate.FieldSchema
+
+func SchemaOf(v interface{}) ValidationSchema {
+	return validate.SchemaOf(v)
+}
+
+func (rt *Router) ServeSchemas(path string, models ...interface{}) *Route {
+	path = cleanPath(path)
+
+	schemas := make(map[string]ValidationSchema, len(models))
+	for _, model := range models {
+		schema := SchemaOf(model)
+		if schema.Name == "" {
+			panic("goify: ServeSchemas requires named struct types")
+		}
+		schemas[schema.Name] = schema
+	}
+
+	rt.GET(path+"/:name", func(c *Context) {
+		schema, exists := schemas[c.Param("name")]
+		if !exists {
+			c.SendNotFound("Schema not found")
+			return
+		}
+		c.JSON(200, schema)
+	})
+
+	return rt.GET(path, func(c *Context) {
+		c.JSON(200, schemas)
+	})
+}
@@ -0,0 +1,183 @@
+package goify
+
+import (
+	"net"
+	"net/http"
+	"sync"
+	"time"
+)
+
+type SecureServerConfig struct {
+	ReadHeaderTimeout   time.Duration
+	ReadTimeout         time.Duration
+	WriteTimeout        time.Duration
+	IdleTimeout         time.Duration
+	MaxHeaderBytes      int
+	MaxConnections      int
+	MaxConnectionsPerIP int
+}
+
+func DefaultSecureServerConfig() SecureServerConfig {
+	return SecureServerConfig{
+		ReadHeaderTimeout:   5 * time.Second,
+		ReadTimeout:         30 * time.Second,
+		IdleTimeout:         120 * time.Second,
+		MaxHeaderBytes:      64 << 10,
+		MaxConnections:      10000,
+		MaxConnectionsPerIP: 100,
+	}
+}
+
+func (rt *Router) ListenSecure(addr string, config ...SecureServerConfig) error {
+	cfg := DefaultSecureServerConfig()
+	if len(config) > 0 {
+		cfg = config[0].withDefaults(cfg)
+	}
+
+	server := &http.Server{
+		Addr:              addr,
+		Handler:           rt,
+		ReadHeaderTimeout: cfg.ReadHeaderTimeout,
+		ReadTimeout:       cfg.ReadTimeout,
+		WriteTimeout:      cfg.WriteTimeout,
+		IdleTimeout:       cfg.IdleTimeout,
+		MaxHeaderBytes:    cfg.MaxHeaderBytes,
+	}
+
+	return rt.serve(server, func() (net.Listener, error) {
+		listener, err := rt.listen(addr)
+		if err != nil {
+			return nil, err
+		}
+		return newLimitListener(listener, cfg.MaxConnections, cfg.MaxConnectionsPerIP), nil
+	})
+}
+
+func (cfg SecureServerConfig) withDefaults(defaults SecureServerConfig) SecureServerConfig {
+	if cfg.ReadHeaderTimeout == 0 {
+		cfg.ReadHeaderTimeout = defaults.ReadHeaderTimeout
+	}
+	if cfg.ReadTimeout == 0 {
+		cfg.ReadTimeout = defaults.ReadTimeout
+	}
+	if cfg.WriteTimeout == 0 {
+		cfg.WriteTimeout = defaults.WriteTimeout
+	}
+	if cfg.IdleTimeout == 0 {
+		cfg.IdleTimeout = defaults.IdleTimeout
+	}
+	if cfg.MaxHeaderBytes == 0 {
+		cfg.MaxHeaderBytes = defaults.MaxHeaderBytes
+	}
+	if cfg.MaxConnections == 0 {
+		cfg.MaxConnections = defaults.MaxConnections
+	}
+	if cfg.MaxConnectionsPerIP == 0 {
+		cfg.MaxConnectionsPerIP = defaults.MaxConnectionsPerIP
+	}
+	return cfg
+}
+
+type limitListener struct {
+	net.Listener
+	slots  chan struct{}
+	perIP  int
+	mu     sync.Mutex
+	active map[string]int
+}
+
+func newLimitListener(l net.Listener, maxConns, perIP int) net.Listener {
+	if maxConns <= 0 && perIP <= 0 {
+		return l
+	}
+
+	ll := &limitListener{
+		Listener: l,
+		perIP:    perIP,
+		active:   make(map[string]int),
+	}
+	if maxConns > 0 {
+		ll.slots = make(chan struct{}, maxConns)
+	}
+	return ll
+}
+
+func (l *limitListener) Accept() (net.Conn, error) {
+	for {
+		if l.slots != nil {
+			l.slots <- struct{}{}
+		}
+
+		conn, err := l.Listener.Accept()
+		if err != nil {
+			l.releaseSlot()
+			return nil, err
+		}
+
+		ip := remoteIP(conn.RemoteAddr())
+		if !l.acquireIP(ip) {
+			conn.Close()
+			l.releaseSlot()
+			continue
+		}
+
+		return &limitConn{Conn: conn, release: func() {
+			l.releaseIP(ip)
+			l.releaseSlot()
+		}}, nil
+	}
+}
+
+func (l *limitListener) acquireIP(ip string) bool {
+	if l.perIP <= 0 {
+		return true
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if l.active[ip] >= l.perIP {
+		return false
+	}
+	l.active[ip]++
+	return true
+}
+
+func (l *limitListener) releaseIP(ip string) {
+	if l.perIP <= 0 {
+		return
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if l.active[ip]--; l.active[ip] <= 0 {
+		delete(l.active, ip)
+	}
+}
+
+func (l *limitListener) releaseSlot() {
+	if l.slots != nil {
+		<-l.slots
+	}
+}
+
+type limitConn struct {
+	net.Conn
+	once    sync.Once
+	release func()
+}
+
+func (c *limitConn) Close() error {
+	err := c.Conn.Close()
+	c.once.Do(c.release)
+	return err
+}
+
+func remoteIP(addr net.Addr) string {
+	host, _, err := net.SplitHostPort(addr.String())
+	if err != nil {
+		return addr.String()
+	}
+	return host
+}
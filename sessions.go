@@ -0,0 +1,246 @@
+package goify
+
+import (
+	"errors"
+	"net/http"
+	"time"
+)
+
+var (
+	ErrSessionNotFound = errors.New("goify: session not found")
+	ErrSessionTooLarge = errors.New("goify: session is too large for a cookie")
+)
+
+type SessionRecord struct {
+	ID      string                 `json:"id"`
+	Values  map[string]interface{} `json:"values"`
+	Expires time.Time              `json:"expires"`
+}
+
+type SessionConfig struct {
+	Store      SessionStore
+	CookieName string
+	TTL        time.Duration
+	Sliding    bool
+	Cookie     CookieOptions
+}
+
+type Session struct {
+	record     SessionRecord
+	token      string
+	staleToken string
+	isNew      bool
+	modified   bool
+	renew      bool
+	destroyed  bool
+}
+
+func DefaultSessionConfig() SessionConfig {
+	return SessionConfig{
+		CookieName: "goify_session",
+		TTL:        24 * time.Hour,
+		Sliding:    true,
+	}
+}
+
+func Sessions(store SessionStore) MiddlewareFunc {
+	config := DefaultSessionConfig()
+	config.Store = store
+	return SessionsWithConfig(config)
+}
+
+func SessionsWithConfig(config SessionConfig) MiddlewareFunc {
+	defaults := DefaultSessionConfig()
+	if config.Store == nil {
+		config.Store = NewMemorySessionStore()
+	}
+	if config.CookieName == "" {
+		config.CookieName = defaults.CookieName
+	}
+	if config.TTL <= 0 {
+		config.TTL = defaults.TTL
+	}
+
+	return func(c *Context, next func()) {
+		session, err := loadSession(c, config)
+		if err != nil {
+			c.Error(NewHTTPError(http.StatusServiceUnavailable, "Session store unavailable").Wrap(err))
+			return
+		}
+		c.Set("session", session)
+
+		committed := false
+		commit := func() {
+			if committed {
+				return
+			}
+			committed = true
+			if err := commitSession(c, config, session); err != nil {
+				c.Logger().Error("failed to save session", "error", err)
+			}
+		}
+		c.onBeforeWrite(commit)
+
+		next()
+		commit()
+	}
+}
+
+func loadSession(c *Context, config SessionConfig) (*Session, error) {
+	cookie, err := c.Cookie(config.CookieName)
+	if err == nil && cookie.Value != "" {
+		record, err := config.Store.Load(c.Request.Context(), cookie.Value)
+		switch {
+		case err == nil && time.Now().Before(record.Expires):
+			if record.Values == nil {
+				record.Values = make(map[string]interface{})
+			}
+			return &Session{record: *record, token: cookie.Value}, nil
+		case err != nil && !errors.Is(err, ErrSessionNotFound):
+			return nil, err
+		}
+	}
+
+	return &Session{
+		record: SessionRecord{
+			ID:      randomToken(),
+			Values:  make(map[string]interface{}),
+			Expires: time.Now().Add(config.TTL),
+		},
+		isNew: true,
+	}, nil
+}
+
+func commitSession(c *Context, config SessionConfig, session *Session) error {
+	ctx := c.Request.Context()
+	options := config.Cookie
+
+	if session.destroyed {
+		if session.staleToken == "" {
+			return nil
+		}
+		c.DeleteCookie(config.CookieName, options)
+		err := config.Store.Delete(ctx, session.staleToken)
+		session.staleToken = ""
+		return err
+	}
+
+	if !session.modified && (session.isNew || !config.Sliding) {
+		return nil
+	}
+
+	if config.Sliding || session.isNew || session.renew {
+		session.record.Expires = time.Now().Add(config.TTL)
+	}
+	if session.staleToken != "" {
+		if err := config.Store.Delete(ctx, session.staleToken); err != nil {
+			return err
+		}
+		session.staleToken = ""
+	}
+
+	token, err := config.Store.Save(ctx, &session.record)
+	if err != nil {
+		return err
+	}
+	session.token = token
+
+	options.Signed = false
+	options.Encrypted = false
+	options.MaxAge = ceilSeconds(time.Until(session.record.Expires))
+	return c.SetCookieValue(config.CookieName, token, options)
+}
+
+func (c *Context) Session() *Session {
+	if value, exists := c.Get("session"); exists {
+		if session, ok := value.(*Session); ok {
+			return session
+		}
+	}
+	return nil
+}
+
+func (c *Context) RegenerateSession() *Session {
+	session := c.Session()
+	if session != nil {
+		session.Regenerate()
+	}
+	return session
+}
+
+func (s *Session) ID() string {
+	return s.record.ID
+}
+
+func (s *Session) IsNew() bool {
+	return s.isNew
+}
+
+func (s *Session) ExpiresAt() time.Time {
+	return s.record.Expires
+}
+
+func (s *Session) Get(key string) (interface{}, bool) {
+	value, exists := s.record.Values[key]
+	return value, exists
+}
+
+func (s *Session) GetString(key string) string {
+	if value, exists := s.record.Values[key]; exists {
+		if str, ok := value.(string); ok {
+			return str
+		}
+	}
+	return ""
+}
+
+func (s *Session) Set(key string, value interface{}) {
+	s.record.Values[key] = value
+	s.modified = true
+	s.destroyed = false
+}
+
+func (s *Session) Delete(key string) {
+	if _, exists := s.record.Values[key]; exists {
+		delete(s.record.Values, key)
+		s.modified = true
+	}
+}
+
+func (s *Session) Values() map[string]interface{} {
+	values := make(map[string]interface{}, len(s.record.Values))
+	for key, value := range s.record.Values {
+		values[key] = value
+	}
+	return values
+}
+
+func (s *Session) Clear() {
+	if len(s.record.Values) > 0 {
+		s.record.Values = make(map[string]interface{})
+		s.modified = true
+	}
+}
+
+func (s *Session) Regenerate() {
+	s.rotate()
+	s.renew = true
+	s.modified = true
+	s.destroyed = false
+}
+
+func (s *Session) Destroy() {
+	s.rotate()
+	s.record.Values = make(map[string]interface{})
+	s.renew = true
+	s.modified = false
+	s.destroyed = true
+}
+
+func (s *Session) rotate() {
+	if s.token != "" && s.staleToken == "" {
+		s.staleToken = s.token
+	}
+	s.record.ID = randomToken()
+	s.token = ""
+}
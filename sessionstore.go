@@ -0,0 +1,321 @@
+package goify
+
+import (
+	"context"
+	"crypto/hmac"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+)
+
+type SessionStore interface {
+	Load(ctx context.Context, token string) (*SessionRecord, error)
+	Save(ctx context.Context, record *SessionRecord) (string, error)
+	Delete(ctx context.Context, token string) error
+}
+
+func validSessionID(id string) bool {
+	if id == "" || len(id) > 128 {
+		return false
+	}
+	for i := 0; i < len(id); i++ {
+		ch := id[i]
+		if !(ch >= 'a' && ch <= 'z' || ch >= 'A' && ch <= 'Z' || ch >= '0' && ch <= '9' || ch == '-' || ch == '_') {
+			return false
+		}
+	}
+	return true
+}
+
+func decodeSessionRecord(data []byte) (*SessionRecord, error) {
+	var record SessionRecord
+	if err := json.Unmarshal(data, &record); err != nil || !time.Now().Before(record.Expires) {
+		return nil, ErrSessionNotFound
+	}
+	return &record, nil
+}
+
+type MemorySessionStore struct {
+	mu        sync.Mutex
+	sessions  map[string]memorySession
+	lastSweep time.Time
+}
+
+type memorySession struct {
+	data    []byte
+	expires time.Time
+}
+
+func NewMemorySessionStore() *MemorySessionStore {
+	return &MemorySessionStore{sessions: make(map[string]memorySession), lastSweep: time.Now()}
+}
+
+func (s *MemorySessionStore) Load(ctx context.Context, token string) (*SessionRecord, error) {
+	s.mu.Lock()
+	entry, ok := s.sessions[token]
+	s.mu.Unlock()
+
+	if !ok || !time.Now().Before(entry.expires) {
+		return nil, ErrSessionNotFound
+	}
+	return decodeSessionRecord(entry.data)
+}
+
+func (s *MemorySessionStore) Save(ctx context.Context, record *SessionRecord) (string, error) {
+	data, err := json.Marshal(record)
+	if err != nil {
+		return "", err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := time.Now()
+	if now.Sub(s.lastSweep) > time.Minute {
+		for id, entry := range s.sessions {
+			if !now.Before(entry.expires) {
+				delete(s.sessions, id)
+			}
+		}
+		s.lastSweep = now
+	}
+
+	s.sessions[record.ID] = memorySession{data: data, expires: record.Expires}
+	return record.ID, nil
+}
+
+func (s *MemorySessionStore) Delete(ctx context.Context, token string) error {
+	s.mu.Lock()
+	delete(s.sessions, token)
+	s.mu.Unlock()
+	return nil
+}
+
+func (s *MemorySessionStore) Len() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return len(s.sessions)
+}
+
+type FileSessionStore struct {
+	dir string
+}
+
+func NewFileSessionStore(dir string) (*FileSessionStore, error) {
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return nil, fmt.Errorf("failed to create session store directory: %v", err)
+	}
+	return &FileSessionStore{dir: dir}, nil
+}
+
+func (s *FileSessionStore) path(id string) string {
+	return filepath.Join(s.dir, id+".json")
+}
+
+func (s *FileSessionStore) Load(ctx context.Context, token string) (*SessionRecord, error) {
+	if !validSessionID(token) {
+		return nil, ErrSessionNotFound
+	}
+
+	data, err := os.ReadFile(s.path(token))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, ErrSessionNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	record, err := decodeSessionRecord(data)
+	if errors.Is(err, ErrSessionNotFound) {
+		os.Remove(s.path(token))
+	}
+	return record, err
+}
+
+func (s *FileSessionStore) Save(ctx context.Context, record *SessionRecord) (string, error) {
+	if !validSessionID(record.ID) {
+		return "", fmt.Errorf("goify: invalid session id %q", record.ID)
+	}
+
+	data, err := json.Marshal(record)
+	if err != nil {
+		return "", err
+	}
+
+	tmp, err := os.CreateTemp(s.dir, ".session-*")
+	if err != nil {
+		return "", err
+	}
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		os.Remove(tmp.Name())
+		return "", err
+	}
+	if err := tmp.Close(); err != nil {
+		os.Remove(tmp.Name())
+		return "", err
+	}
+	if err := os.Rename(tmp.Name(), s.path(record.ID)); err != nil {
+		os.Remove(tmp.Name())
+		return "", err
+	}
+	return record.ID, nil
+}
+
+func (s *FileSessionStore) Delete(ctx context.Context, token string) error {
+	if !validSessionID(token) {
+		return nil
+	}
+	if err := os.Remove(s.path(token)); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return err
+	}
+	return nil
+}
+
+func (s *FileSessionStore) Cleanup() (int, error) {
+	entries, err := os.ReadDir(s.dir)
+	if err != nil {
+		return 0, err
+	}
+
+	removed := 0
+	for _, entry := range entries {
+		id, ok := strings.CutSuffix(entry.Name(), ".json")
+		if entry.IsDir() || !ok || !validSessionID(id) {
+			continue
+		}
+		data, err := os.ReadFile(s.path(id))
+		if err != nil {
+			continue
+		}
+		if _, err := decodeSessionRecord(data); err != nil {
+			if os.Remove(s.path(id)) == nil {
+				removed++
+			}
+		}
+	}
+	return removed, nil
+}
+
+type CookieSessionStore struct {
+	MaxSize int
+	keys    [][]byte
+}
+
+func NewCookieSessionStore(keys ...[]byte) (*CookieSessionStore, error) {
+	if len(keys) == 0 {
+		return nil, errors.New("goify: at least one session key is required")
+	}
+
+	store := &CookieSessionStore{MaxSize: 4000}
+	for _, key := range keys {
+		if len(key) < 16 {
+			return nil, errors.New("goify: session key must be at least 16 bytes")
+		}
+		store.keys = append(store.keys, deriveKey(key, "session"))
+	}
+	return store, nil
+}
+
+func (s *CookieSessionStore) Load(ctx context.Context, token string) (*SessionRecord, error) {
+	payload, signature, ok := strings.Cut(token, ".")
+	if !ok {
+		return nil, ErrSessionNotFound
+	}
+
+	for _, key := range s.keys {
+		if hmac.Equal([]byte(signature), []byte(signCookie(key, "session", payload))) {
+			data, err := base64.RawURLEncoding.DecodeString(payload)
+			if err != nil {
+				return nil, ErrSessionNotFound
+			}
+			record, err := decodeSessionRecord(data)
+			if err != nil {
+				return nil, ErrSessionNotFound
+			}
+			return record, nil
+		}
+	}
+	return nil, ErrSessionNotFound
+}
+
+func (s *CookieSessionStore) Save(ctx context.Context, record *SessionRecord) (string, error) {
+	data, err := json.Marshal(record)
+	if err != nil {
+		return "", err
+	}
+
+	payload := base64.RawURLEncoding.EncodeToString(data)
+	token := payload + "." + signCookie(s.keys[0], "session", payload)
+	if s.MaxSize > 0 && len(token) > s.MaxSize {
+		return "", ErrSessionTooLarge
+	}
+	return token, nil
+}
+
+func (s *CookieSessionStore) Delete(ctx context.Context, token string) error {
+	return nil
+}
+
+type RedisSessionClient interface {
+	Get(ctx context.Context, key string) ([]byte, error)
+	Set(ctx context.Context, key string, value []byte, ttl time.Duration) error
+	Del(ctx context.Context, key string) error
+}
+
+type RedisSessionStore struct {
+	Prefix string
+	client RedisSessionClient
+}
+
+func NewRedisSessionStore(client RedisSessionClient, prefix ...string) *RedisSessionStore {
+	store := &RedisSessionStore{Prefix: "session:", client: client}
+	if len(prefix) > 0 {
+		store.Prefix = prefix[0]
+	}
+	return store
+}
+
+func (s *RedisSessionStore) Load(ctx context.Context, token string) (*SessionRecord, error) {
+	if !validSessionID(token) {
+		return nil, ErrSessionNotFound
+	}
+
+	data, err := s.client.Get(ctx, s.Prefix+token)
+	if err != nil {
+		return nil, err
+	}
+	if data == nil {
+		return nil, ErrSessionNotFound
+	}
+	return decodeSessionRecord(data)
+}
+
+func (s *RedisSessionStore) Save(ctx context.Context, record *SessionRecord) (string, error) {
+	ttl := time.Until(record.Expires)
+	if ttl < time.Second {
+		ttl = time.Second
+	}
+
+	data, err := json.Marshal(record)
+	if err != nil {
+		return "", err
+	}
+	if err := s.client.Set(ctx, s.Prefix+record.ID, data, ttl); err != nil {
+		return "", err
+	}
+	return record.ID, nil
+}
+
+func (s *RedisSessionStore) Delete(ctx context.Context, token string) error {
+	if !validSessionID(token) {
+		return nil
+	}
+	return s.client.Del(ctx, s.Prefix+token)
+}
@@ -11,9 +11,10 @@ import (
 )
 
 type ShutdownConfig struct {
-	Timeout         time.Duration
-	ShutdownSignals []os.Signal
-	OnShutdown      []func()
+	Timeout           time.Duration
+	ShutdownSignals   []os.Signal
+	OnShutdown        []func()
+	DependencyTimeout time.Duration
 }
 
 var globalShutdownCallbacks []func()
@@ -32,6 +33,15 @@ func (rt *Router) ListenAndServeWithGracefulShutdown(addr string, config ...Shut
 		cfg = config[0]
 	}
 
+	if cfg.DependencyTimeout > 0 {
+		ctx, cancel := context.WithTimeout(context.Background(), cfg.DependencyTimeout)
+		err := rt.WaitForDependencies(ctx)
+		cancel()
+		if err != nil {
+			return err
+		}
+	}
+
 	go func() {
 		if err := rt.Listen(addr); err != nil && err != http.ErrServerClosed {
 			log.Printf("Server error: %v", err)
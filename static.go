@@ -0,0 +1,201 @@
+package goify
+
+import (
+	"errors"
+	"io"
+	"io/fs"
+	"net/http"
+	"os"
+	"path"
+	"strings"
+	"time"
+)
+
+type StaticConfig struct {
+	Prefix      string
+	Root        string
+	FS          fs.FS
+	Index       string
+	Browse      bool
+	NotFound    HandlerFunc
+	Fallthrough bool
+	ModTime     time.Time
+}
+
+func Static(prefix, root string) MiddlewareFunc {
+	return StaticWithConfig(StaticConfig{
+		Prefix:      prefix,
+		Root:        root,
+		Fallthrough: true,
+	})
+}
+
+func StaticFS(prefix string, fsys fs.FS) MiddlewareFunc {
+	return StaticWithConfig(StaticConfig{
+		Prefix:      prefix,
+		FS:          fsys,
+		Fallthrough: true,
+	})
+}
+
+func StaticWithConfig(config StaticConfig) MiddlewareFunc {
+	if config.Index == "" {
+		config.Index = "index.html"
+	}
+	config.Prefix = strings.TrimSuffix(config.Prefix, "/")
+
+	var fileSystem http.FileSystem
+	if config.FS != nil {
+		modTime := config.ModTime
+		if modTime.IsZero() {
+			modTime = executableModTime()
+		}
+		fileSystem = http.FS(modTimeFS{fsys: config.FS, modTime: modTime})
+	} else {
+		fileSystem = http.Dir(config.Root)
+	}
+	fileServer := http.FileServer(fileSystem)
+
+	return func(c *Context, next func()) {
+		if c.Request.Method != http.MethodGet && c.Request.Method != http.MethodHead {
+			next()
+			return
+		}
+
+		urlPath := c.Request.URL.Path
+		if config.Prefix != "" {
+			if urlPath != config.Prefix && !strings.HasPrefix(urlPath, config.Prefix+"/") {
+				next()
+				return
+			}
+			urlPath = strings.TrimPrefix(urlPath, config.Prefix)
+		}
+
+		if containsDotDot(urlPath) {
+			c.SendBadRequest("Invalid path")
+			return
+		}
+
+		filePath := path.Clean("/" + urlPath)
+		served, ok := staticFile(fileSystem, filePath, config.Index, config.Browse)
+		if !ok {
+			if config.NotFound != nil {
+				config.NotFound(c)
+				return
+			}
+			if config.Fallthrough {
+				next()
+				return
+			}
+			c.SendNotFound("File not found")
+			return
+		}
+
+		if contentType := LookupMimeType(served); served != "" && contentType != "" {
+			c.SetHeader("Content-Type", contentType)
+		}
+
+		req := c.Request.Clone(c.Request.Context())
+		req.URL.Path = urlPath
+		if req.URL.Path == "" {
+			req.URL.Path = "/"
+		}
+		req.URL.RawPath = ""
+		fileServer.ServeHTTP(c.Response, req)
+	}
+}
+
+func staticFile(fileSystem http.FileSystem, name, index string, browse bool) (string, bool) {
+	file, err := fileSystem.Open(name)
+	if err != nil {
+		return "", false
+	}
+	defer file.Close()
+
+	info, err := file.Stat()
+	if err != nil {
+		return "", false
+	}
+	if !info.IsDir() {
+		return name, true
+	}
+
+	indexFile, err := fileSystem.Open(path.Join(name, index))
+	if err == nil {
+		indexFile.Close()
+		return path.Join(name, index), true
+	}
+	return "", browse && !errors.Is(err, fs.ErrPermission)
+}
+
+func containsDotDot(p string) bool {
+	if !strings.Contains(p, "..") {
+		return false
+	}
+	for _, segment := range strings.FieldsFunc(p, func(r rune) bool { return r == '/' || r == '\\' }) {
+		if segment == ".." {
+			return true
+		}
+	}
+	return false
+}
+
+func executableModTime() time.Time {
+	if executable, err := os.Executable(); err == nil {
+		if info, err := os.Stat(executable); err == nil {
+			return info.ModTime()
+		}
+	}
+	return defaultHealth.StartTime()
+}
+
+type modTimeFS struct {
+	fsys    fs.FS
+	modTime time.Time
+}
+
+func (m modTimeFS) Open(name string) (fs.File, error) {
+	file, err := m.fsys.Open(name)
+	if err != nil {
+		return nil, err
+	}
+	return &modTimeFile{File: file, modTime: m.modTime}, nil
+}
+
+type modTimeFile struct {
+	fs.File
+	modTime time.Time
+}
+
+func (f *modTimeFile) Stat() (fs.FileInfo, error) {
+	info, err := f.File.Stat()
+	if err != nil || !info.ModTime().IsZero() {
+		return info, err
+	}
+	return modTimeInfo{FileInfo: info, modTime: f.modTime}, nil
+}
+
+func (f *modTimeFile) Seek(offset int64, whence int) (int64, error) {
+	seeker, ok := f.File.(io.Seeker)
+	if !ok {
+		return 0, errors.New("static: file does not implement io.Seeker")
+	}
+	return seeker.Seek(offset, whence)
+}
+
+func (f *modTimeFile) ReadDir(n int) ([]fs.DirEntry, error) {
+	dir, ok := f.File.(fs.ReadDirFile)
+	if !ok {
+		return nil, errors.New("static: not a directory")
+	}
+	return dir.ReadDir(n)
+}
+
+type modTimeInfo struct {
+	fs.FileInfo
+	modTime time.Time
+}
+
+func (i modTimeInfo) ModTime() time.Time {
+	return i.modTime
+}
@@ -0,0 +1,110 @@
+package goify
+
+import (
+	"fmt"
+	"log"
+	"net"
+	"os"
+	"strconv"
+	"sync"
+	"time"
+)
+
+type SystemdConfig struct {
+	Watchdog      bool
+	WatchdogCheck func() bool
+}
+
+func SystemdNotify(state string) (bool, error) {
+	socketPath := os.Getenv("NOTIFY_SOCKET")
+	if socketPath == "" {
+		return false, nil
+	}
+	if socketPath[0] == '@' {
+		socketPath = "\x00" + socketPath[1:]
+	}
+
+	conn, err := net.DialUnix("unixgram", nil, &net.UnixAddr{Name: socketPath, Net: "unixgram"})
+	if err != nil {
+		return false, fmt.Errorf("systemd notify: %v", err)
+	}
+	defer conn.Close()
+
+	if _, err := conn.Write([]byte(state)); err != nil {
+		return false, fmt.Errorf("systemd notify: %v", err)
+	}
+	return true, nil
+}
+
+func SystemdWatchdogInterval() (time.Duration, bool) {
+	usec, err := strconv.ParseInt(os.Getenv("WATCHDOG_USEC"), 10, 64)
+	if err != nil || usec <= 0 {
+		return 0, false
+	}
+
+	if pid := os.Getenv("WATCHDOG_PID"); pid != "" {
+		if p, err := strconv.Atoi(pid); err != nil || p != os.Getpid() {
+			return 0, false
+		}
+	}
+
+	return time.Duration(usec) * time.Microsecond, true
+}
+
+func (rt *Router) UseSystemd(config ...SystemdConfig) {
+	cfg := SystemdConfig{Watchdog: true}
+	if len(config) > 0 {
+		cfg = config[0]
+	}
+
+	var mu sync.Mutex
+	var stopWatchdog chan struct{}
+
+	rt.OnReady(func() {
+		if _, err := SystemdNotify("READY=1"); err != nil {
+			log.Printf("%v", err)
+		}
+
+		interval, ok := SystemdWatchdogInterval()
+		if !cfg.Watchdog || !ok {
+			return
+		}
+
+		mu.Lock()
+		stopWatchdog = make(chan struct{})
+		go systemdWatchdog(interval/2, cfg.WatchdogCheck, stopWatchdog)
+		mu.Unlock()
+	})
+
+	rt.onStop(func() {
+		mu.Lock()
+		if stopWatchdog != nil {
+			close(stopWatchdog)
+			stopWatchdog = nil
+		}
+		mu.Unlock()
+
+		if _, err := SystemdNotify("STOPPING=1"); err != nil {
+			log.Printf("%v", err)
+		}
+	})
+}
+
+func systemdWatchdog(interval time.Duration, check func() bool, stop <-chan struct{}) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-stop:
+			return
+		case <-ticker.C:
+			if check != nil && !check() {
+				continue
+			}
+			if _, err := SystemdNotify("WATCHDOG=1"); err != nil {
+				log.Printf("%v", err)
+			}
+		}
+	}
+}
@@ -0,0 +1,178 @@
+package goify
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"net"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+)
+
+type TimeoutConfig struct {
+	Timeout    time.Duration
+	StatusCode int
+	Message    string
+}
+
+func Timeout(timeout time.Duration) MiddlewareFunc {
+	return TimeoutWithConfig(TimeoutConfig{Timeout: timeout})
+}
+
+func TimeoutWithConfig(config TimeoutConfig) MiddlewareFunc {
+	if config.StatusCode == 0 {
+		config.StatusCode = http.StatusServiceUnavailable
+	}
+	if config.Message == "" {
+		config.Message = "Request timed out"
+	}
+
+	return func(c *Context, next func()) {
+		if config.Timeout <= 0 || c.writer == nil {
+			next()
+			return
+		}
+
+		ctx, cancel := context.WithTimeout(c.Request.Context(), config.Timeout)
+		defer cancel()
+		c.Request = c.Request.WithContext(ctx)
+
+		guard := &timeoutWriter{
+			ResponseWriter: c.writer.ResponseWriter,
+			header:         c.writer.ResponseWriter.Header().Clone(),
+			ctx:            ctx,
+			config:         config,
+		}
+		c.writer.ResponseWriter = guard
+
+		finished := make(chan struct{})
+		watchdog := make(chan struct{})
+		go func() {
+			defer close(watchdog)
+			select {
+			case <-finished:
+			case <-ctx.Done():
+				guard.mu.Lock()
+				guard.expire()
+				guard.mu.Unlock()
+			}
+		}()
+
+		defer func() {
+			close(finished)
+			<-watchdog
+			c.writer.ResponseWriter = guard.ResponseWriter
+
+			guard.mu.Lock()
+			defer guard.mu.Unlock()
+			if guard.timedOut {
+				c.writer.status = config.StatusCode
+				c.writer.written = true
+				return
+			}
+			if !guard.wroteHeader {
+				copyHeader(guard.ResponseWriter.Header(), guard.header)
+			}
+		}()
+
+		next()
+	}
+}
+
+type timeoutWriter struct {
+	http.ResponseWriter
+	mu          sync.Mutex
+	header      http.Header
+	ctx         context.Context
+	config      TimeoutConfig
+	wroteHeader bool
+	timedOut    bool
+}
+
+func (w *timeoutWriter) Header() http.Header {
+	return w.header
+}
+
+func (w *timeoutWriter) WriteHeader(code int) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if w.expire() || w.wroteHeader {
+		return
+	}
+	w.wroteHeader = true
+	copyHeader(w.ResponseWriter.Header(), w.header)
+	w.ResponseWriter.WriteHeader(code)
+}
+
+func (w *timeoutWriter) Write(b []byte) (int, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if w.expire() {
+		return 0, http.ErrHandlerTimeout
+	}
+	if !w.wroteHeader {
+		w.wroteHeader = true
+		copyHeader(w.ResponseWriter.Header(), w.header)
+		w.ResponseWriter.WriteHeader(http.StatusOK)
+	}
+	return w.ResponseWriter.Write(b)
+}
+
+func (w *timeoutWriter) Flush() {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if w.expire() {
+		return
+	}
+	if flusher, ok := w.ResponseWriter.(http.Flusher); ok {
+		flusher.Flush()
+	}
+}
+
+func (w *timeoutWriter) Hijack() (net.Conn, *bufio.ReadWriter, error) {
+	hijacker, ok := w.ResponseWriter.(http.Hijacker)
+	if !ok {
+		return nil, nil, fmt.Errorf("response writer does not support hijacking")
+	}
+	return hijacker.Hijack()
+}
+
+func (w *timeoutWriter) expire() bool {
+	if w.timedOut {
+		return true
+	}
+	if w.wroteHeader || w.ctx.Err() != context.DeadlineExceeded {
+		return false
+	}
+	w.timedOut = true
+
+	body, _ := marshalJSON(ErrorResponse{
+		Error:   http.StatusText(w.config.StatusCode),
+		Message: w.config.Message,
+		Code:    w.config.StatusCode,
+	})
+
+	header := w.ResponseWriter.Header()
+	header.Set("Content-Type", "application/json")
+	header.Set("Content-Length", strconv.Itoa(len(body)))
+	header.Set("Connection", "close")
+	w.ResponseWriter.WriteHeader(w.config.StatusCode)
+	w.ResponseWriter.Write(body)
+	if flusher, ok := w.ResponseWriter.(http.Flusher); ok {
+		flusher.Flush()
+	}
+	return true
+}
+
+func copyHeader(dst, src http.Header) {
+	for key := range dst {
+		if _, exists := src[key]; !exists {
+			delete(dst, key)
+		}
+	}
+	for key, values := range src {
+		dst[key] = values
+	}
+}
@@ -0,0 +1,274 @@
+package goify
+
+import (
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/VsRnA/goify/upload"
+)
+
+const TusVersion = "1.0.0"
+
+type (
+	TusInfo  = upload.TusInfo
+	TusStore = upload.TusStore
+)
+
+type TusConfig struct {
+	Store      TusStore
+	MaxSize    int64
+	Expiration time.Duration
+	OnCreate   func(c *Context, info *TusInfo) error
+	OnComplete func(c *Context, info *TusInfo) error
+}
+
+func DefaultTusConfig() TusConfig {
+	return TusConfig{
+		Expiration: 24 * time.Hour,
+	}
+}
+
+type tusHandler struct {
+	config TusConfig
+	base   string
+}
+
+func (rt *Router) Tus(path string, config TusConfig) {
+	if config.Store == nil {
+		panic("goify: tus requires a store")
+	}
+	if config.MaxSize < 0 {
+		panic("goify: tus max size must not be negative")
+	}
+
+	h := &tusHandler{config: config, base: strings.TrimSuffix(cleanPath(path), "/")}
+	rt.addRoute(http.MethodOptions, h.base, h.options)
+	rt.addRoute(http.MethodOptions, h.base+"/:id", h.options)
+	rt.POST(h.base, h.create)
+	rt.HEAD(h.base+"/:id", h.head)
+	rt.PATCH(h.base+"/:id", h.patch)
+	rt.DELETE(h.base+"/:id", h.delete)
+}
+
+func (h *tusHandler) options(c *Context) {
+	c.SetHeader("Tus-Resumable", TusVersion)
+	c.SetHeader("Tus-Version", TusVersion)
+	c.SetHeader("Tus-Extension", "creation,creation-with-upload,expiration,termination")
+	if h.config.MaxSize > 0 {
+		c.SetHeader("Tus-Max-Size", strconv.FormatInt(h.config.MaxSize, 10))
+	}
+	c.Status(http.StatusNoContent)
+}
+
+func (h *tusHandler) create(c *Context) {
+	if !h.checkVersion(c) {
+		return
+	}
+
+	size, err := strconv.ParseInt(c.GetHeader("Upload-Length"), 10, 64)
+	if err != nil || size < 0 {
+		c.Error(NewHTTPError(http.StatusBadRequest, "Invalid or missing Upload-Length header"))
+		return
+	}
+	if h.config.MaxSize > 0 && size > h.config.MaxSize {
+		c.Error(NewHTTPError(http.StatusRequestEntityTooLarge, "Upload exceeds maximum size", H{"limit": FormatFileSize(h.config.MaxSize)}))
+		return
+	}
+
+	metadata, err := parseTusMetadata(c.GetHeader("Upload-Metadata"))
+	if err != nil {
+		c.Error(NewHTTPError(http.StatusBadRequest, "Invalid Upload-Metadata header").Wrap(err))
+		return
+	}
+
+	info := TusInfo{Size: size, Metadata: metadata, CreatedAt: time.Now()}
+	if h.config.Expiration > 0 {
+		info.ExpiresAt = info.CreatedAt.Add(h.config.Expiration)
+	}
+	if h.config.OnCreate != nil {
+		if err := h.config.OnCreate(c, &info); err != nil {
+			c.Error(err)
+			return
+		}
+	}
+
+	created, err := h.config.Store.Create(info)
+	if err != nil {
+		c.Error(NewHTTPError(http.StatusInternalServerError, "Failed to create upload").Wrap(err))
+		return
+	}
+
+	if c.ContentType() == "application/offset+octet-stream" && c.Request.ContentLength != 0 {
+		c.markBodyRead("Tus")
+		written, err := h.config.Store.Write(created.ID, 0, c.Request.Body)
+		if err != nil && (written == nil || errors.Is(err, upload.ErrSizeExceeded)) {
+			h.writeFailed(c, err)
+			return
+		}
+		created = written
+	}
+
+	c.SetHeader("Location", h.base+"/"+created.ID)
+	h.setUploadHeaders(c, created)
+	c.Status(http.StatusCreated)
+
+	if created.Complete() {
+		h.complete(c, created)
+	}
+}
+
+func (h *tusHandler) head(c *Context) {
+	if !h.checkVersion(c) {
+		return
+	}
+
+	info, err := h.config.Store.Info(c.Param("id"))
+	if err != nil {
+		h.storeError(c, err)
+		return
+	}
+
+	c.SetHeader("Cache-Control", "no-store")
+	c.SetHeader("Upload-Length", strconv.FormatInt(info.Size, 10))
+	if len(info.Metadata) > 0 {
+		c.SetHeader("Upload-Metadata", formatTusMetadata(info.Metadata))
+	}
+	h.setUploadHeaders(c, info)
+	c.Status(http.StatusOK)
+}
+
+func (h *tusHandler) patch(c *Context) {
+	if !h.checkVersion(c) {
+		return
+	}
+	if c.ContentType() != "application/offset+octet-stream" {
+		c.Error(NewHTTPError(http.StatusUnsupportedMediaType, "Content-Type must be application/offset+octet-stream"))
+		return
+	}
+
+	offset, err := strconv.ParseInt(c.GetHeader("Upload-Offset"), 10, 64)
+	if err != nil || offset < 0 {
+		c.Error(NewHTTPError(http.StatusBadRequest, "Invalid or missing Upload-Offset header"))
+		return
+	}
+
+	c.markBodyRead("Tus")
+	info, err := h.config.Store.Write(c.Param("id"), offset, c.Request.Body)
+	if err != nil {
+		h.writeFailed(c, err)
+		return
+	}
+
+	h.setUploadHeaders(c, info)
+	c.Status(http.StatusNoContent)
+
+	// Only the write that reaches Size completes the upload; an empty retry
+	// of the final PATCH must not run OnComplete again.
+	if info.Complete() && info.Offset > offset {
+		h.complete(c, info)
+	}
+}
+
+func (h *tusHandler) delete(c *Context) {
+	if !h.checkVersion(c) {
+		return
+	}
+
+	if err := h.config.Store.Delete(c.Param("id")); err != nil {
+		h.storeError(c, err)
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
+
+func (h *tusHandler) checkVersion(c *Context) bool {
+	c.SetHeader("Tus-Resumable", TusVersion)
+	if c.GetHeader("Tus-Resumable") != TusVersion {
+		c.SetHeader("Tus-Version", TusVersion)
+		c.Error(NewHTTPError(http.StatusPreconditionFailed, "Unsupported tus protocol version"))
+		return false
+	}
+	return true
+}
+
+func (h *tusHandler) writeFailed(c *Context, err error) {
+	switch {
+	case errors.Is(err, upload.ErrSizeExceeded):
+		c.Error(NewHTTPError(http.StatusRequestEntityTooLarge, "Upload exceeds declared Upload-Length"))
+	case errors.Is(err, upload.ErrOffsetMismatch):
+		c.Error(NewHTTPError(http.StatusConflict, "Upload-Offset does not match the current offset"))
+	default:
+		h.storeError(c, err)
+	}
+}
+
+func (h *tusHandler) storeError(c *Context, err error) {
+	switch {
+	case errors.Is(err, upload.ErrUploadNotFound):
+		c.Error(NewHTTPError(http.StatusNotFound, "Upload not found"))
+	case errors.Is(err, upload.ErrUploadLocked):
+		c.Error(NewHTTPError(http.StatusLocked, "Upload is locked by another request"))
+	default:
+		c.Error(NewHTTPError(http.StatusInternalServerError, "Upload storage error").Wrap(err))
+	}
+}
+
+func (h *tusHandler) setUploadHeaders(c *Context, info *TusInfo) {
+	c.SetHeader("Upload-Offset", strconv.FormatInt(info.Offset, 10))
+	if !info.ExpiresAt.IsZero() && !info.Complete() {
+		c.SetHeader("Upload-Expires", info.ExpiresAt.UTC().Format(http.TimeFormat))
+	}
+}
+
+func (h *tusHandler) complete(c *Context, info *TusInfo) {
+	if h.config.OnComplete == nil {
+		return
+	}
+	if err := h.config.OnComplete(c, info); err != nil {
+		c.Logger().Error("tus completion callback failed", "error", err, "upload", info.ID)
+	}
+}
+
+func parseTusMetadata(header string) (map[string]string, error) {
+	if strings.TrimSpace(header) == "" {
+		return nil, nil
+	}
+
+	metadata := make(map[string]string)
+	for _, pair := range strings.Split(header, ",") {
+		key, encoded, _ := strings.Cut(strings.TrimSpace(pair), " ")
+		if key == "" {
+			return nil, fmt.Errorf("empty metadata key")
+		}
+		value, err := base64.StdEncoding.DecodeString(strings.TrimSpace(encoded))
+		if err != nil {
+			return nil, fmt.Errorf("metadata %q is not valid base64", key)
+		}
+		metadata[key] = string(value)
+	}
+	return metadata, nil
+}
+
+func formatTusMetadata(metadata map[string]string) string {
+	keys := make([]string, 0, len(metadata))
+	for key := range metadata {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	pairs := make([]string, 0, len(keys))
+	for _, key := range keys {
+		if metadata[key] == "" {
+			pairs = append(pairs, key)
+			continue
+		}
+		pairs = append(pairs, key+" "+base64.StdEncoding.EncodeToString([]byte(metadata[key])))
+	}
+	return strings.Join(pairs, ",")
+}
@@ -0,0 +1,151 @@
+package goify
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/VsRnA/goify/upload"
+)
+
+func newTusRouter(t *testing.T, completed *int) (*Router, *upload.TusDiskStore) {
+	t.Helper()
+
+	store, err := upload.NewTusDiskStore(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	config := DefaultTusConfig()
+	config.Store = store
+	config.MaxSize = 1 << 20
+	config.OnComplete = func(c *Context, info *TusInfo) error {
+		*completed++
+		return nil
+	}
+
+	rt := New()
+	rt.Tus("/files", config)
+	return rt, store
+}
+
+func tusRequest(rt *Router, method, path string, body string, headers map[string]string) *httptest.ResponseRecorder {
+	var reader io.Reader
+	if body != "" {
+		reader = strings.NewReader(body)
+	}
+	req := httptest.NewRequest(method, path, reader)
+	req.Header.Set("Tus-Resumable", TusVersion)
+	for name, value := range headers {
+		req.Header.Set(name, value)
+	}
+	w := httptest.NewRecorder()
+	rt.ServeHTTP(w, req)
+	return w
+}
+
+func patchTus(rt *Router, location string, offset int, body string) *httptest.ResponseRecorder {
+	return tusRequest(rt, http.MethodPatch, location, body, map[string]string{
+		"Content-Type":  "application/offset+octet-stream",
+		"Upload-Offset": strconv.Itoa(offset),
+	})
+}
+
+func TestTusUploadInChunks(t *testing.T) {
+	var completed int
+	rt, store := newTusRouter(t, &completed)
+
+	w := tusRequest(rt, http.MethodPost, "/files", "", map[string]string{
+		"Upload-Length":   "11",
+		"Upload-Metadata": "filename aGVsbG8udHh0",
+	})
+	if w.Code != http.StatusCreated {
+		t.Fatalf("create: expected 201, got %d", w.Code)
+	}
+	location := w.Header().Get("Location")
+	id := strings.TrimPrefix(location, "/files/")
+
+	if w := patchTus(rt, location, 0, "hello "); w.Code != http.StatusNoContent || w.Header().Get("Upload-Offset") != "6" {
+		t.Fatalf("first chunk: %d offset=%s", w.Code, w.Header().Get("Upload-Offset"))
+	}
+	if w := patchTus(rt, location, 0, "again"); w.Code != http.StatusConflict {
+		t.Fatalf("stale offset: expected 409, got %d", w.Code)
+	}
+	if w := tusRequest(rt, http.MethodHead, location, "", nil); w.Header().Get("Upload-Offset") != "6" || w.Header().Get("Upload-Length") != "11" {
+		t.Fatalf("head: offset=%s length=%s", w.Header().Get("Upload-Offset"), w.Header().Get("Upload-Length"))
+	}
+	if completed != 0 {
+		t.Fatalf("OnComplete ran before the upload finished")
+	}
+
+	if w := patchTus(rt, location, 6, "world"); w.Code != http.StatusNoContent {
+		t.Fatalf("last chunk: expected 204, got %d", w.Code)
+	}
+	if completed != 1 {
+		t.Fatalf("expected OnComplete once, got %d", completed)
+	}
+
+	data, err := os.ReadFile(store.Path(id))
+	if err != nil || string(data) != "hello world" {
+		t.Fatalf("unexpected upload content %q (%v)", data, err)
+	}
+}
+
+func TestTusRetriedFinalPatchDoesNotCompleteTwice(t *testing.T) {
+	var completed int
+	rt, _ := newTusRouter(t, &completed)
+
+	location := tusRequest(rt, http.MethodPost, "/files", "", map[string]string{"Upload-Length": "5"}).Header().Get("Location")
+	if w := patchTus(rt, location, 0, "hello"); w.Code != http.StatusNoContent {
+		t.Fatalf("patch: expected 204, got %d", w.Code)
+	}
+
+	// The client lost the response and retries with the final offset.
+	if w := patchTus(rt, location, 5, ""); w.Code != http.StatusNoContent || w.Header().Get("Upload-Offset") != "5" {
+		t.Fatalf("retry: %d offset=%s", w.Code, w.Header().Get("Upload-Offset"))
+	}
+	if completed != 1 {
+		t.Fatalf("expected OnComplete once, got %d", completed)
+	}
+}
+
+func TestTusCreationWithUpload(t *testing.T) {
+	var completed int
+	rt, _ := newTusRouter(t, &completed)
+
+	w := tusRequest(rt, http.MethodPost, "/files", "hello", map[string]string{
+		"Upload-Length": "5",
+		"Content-Type":  "application/offset+octet-stream",
+	})
+	if w.Code != http.StatusCreated || w.Header().Get("Upload-Offset") != "5" {
+		t.Fatalf("create: %d offset=%s", w.Code, w.Header().Get("Upload-Offset"))
+	}
+	if completed != 1 {
+		t.Fatalf("expected OnComplete once, got %d", completed)
+	}
+}
+
+func TestTusRejectsOversizedAndUnversionedRequests(t *testing.T) {
+	var completed int
+	rt, _ := newTusRouter(t, &completed)
+
+	if w := tusRequest(rt, http.MethodPost, "/files", "", map[string]string{"Upload-Length": strconv.Itoa(2 << 20)}); w.Code != http.StatusRequestEntityTooLarge {
+		t.Fatalf("oversized: expected 413, got %d", w.Code)
+	}
+
+	location := tusRequest(rt, http.MethodPost, "/files", "", map[string]string{"Upload-Length": "3"}).Header().Get("Location")
+	if w := patchTus(rt, location, 0, "toolong"); w.Code != http.StatusRequestEntityTooLarge {
+		t.Fatalf("data past Upload-Length: expected 413, got %d", w.Code)
+	}
+
+	req := httptest.NewRequest(http.MethodHead, location, nil)
+	w := httptest.NewRecorder()
+	rt.ServeHTTP(w, req)
+	if w.Code != http.StatusPreconditionFailed {
+		t.Fatalf("missing Tus-Resumable: expected 412, got %d", w.Code)
+	}
+}
@@ -1,230 +1,167 @@
 package goify
 
 import (
-	"fmt"
-	"io"
-	"mime/multipart"
-	"os"
-	"path/filepath"
-	"strings"
-	"time"
+	"image"
+
+	"github.com/VsRnA/goify/upload"
 )
 
-type FileHeader struct {
-	*multipart.FileHeader
-	File multipart.File
-}
+type FileHeader = upload.FileHeader
 
-type FileValidation struct {
-	MaxSize	int64
-	MinSize	int64
-	AllowedTypes []string
-	AllowedExts	[]string
-	Required bool
-}
+type FileValidation = upload.Validation
 
-type FileUploadError	struct {
-	Field string `json:"field"`
-	Message string `json:"message"`
-	Code	string `json:"code"`
-}
+type FileUploadError = upload.Error
 
-func (fue FileUploadError) Error() string {
-	return fue.Message
-}
+type FileUploadErrors = upload.Errors
+
+type Size = upload.Size
 
-type FileUploadErrors []FileUploadError 
+type FileNamer = upload.Namer
 
-func (fues FileUploadErrors) Error() string {
-	var messages []string
+type (
+	UUIDNamer      = upload.UUIDNamer
+	HashNamer      = upload.HashNamer
+	SequenceNamer  = upload.SequenceNamer
+	TimestampNamer = upload.TimestampNamer
+)
 
-	for _, err := range fues {
-		messages = append(messages, err.Message)
-	}
+var ErrFileNameCollision = upload.ErrCollision
 
-	return strings.Join(messages, "; ")
-}
+var ErrUnsafePath = upload.ErrUnsafePath
 
-func ValidateFile(fileHeader *FileHeader, validation FileValidation) error {
-	if fileHeader == nil {
-		if validation.Required {
-			return FileUploadError{
-				Message: "File is required",
-				Code:    "required",
-			}
-		}
-		return nil
-	}
-
-	if validation.MaxSize > 0 && fileHeader.Size > validation.MaxSize {
-		return FileUploadError{
-			Message: fmt.Sprintf("File size exceeds maximum allowed size of %d bytes", validation.MaxSize),
-			Code:    "max_size",
-		}
-	}
-
-	if validation.MinSize > 0 && fileHeader.Size < validation.MinSize {
-		return FileUploadError{
-			Message: fmt.Sprintf("File size is below minimum required size of %d bytes", validation.MinSize),
-			Code:    "min_size",
-		}
-	}
-
-	if len(validation.AllowedTypes) > 0 {
-		allowed := false
-		for _, allowedType := range validation.AllowedTypes {
-			if fileHeader.Header.Get("Content-Type") == allowedType {
-				allowed = true
-				break
-			}
-		}
-		if !allowed {
-			return FileUploadError{
-				Message: fmt.Sprintf("File type '%s' is not allowed. Allowed types: %v", 
-					fileHeader.Header.Get("Content-Type"), validation.AllowedTypes),
-				Code: "invalid_type",
-			}
-		}
-	}
-
-	if len(validation.AllowedExts) > 0 {
-		ext := strings.ToLower(filepath.Ext(fileHeader.Filename))
-		allowed := false
-		for _, allowedExt := range validation.AllowedExts {
-			if ext == strings.ToLower(allowedExt) {
-				allowed = true
-				break
-			}
-		}
-		if !allowed {
-			return FileUploadError{
-				Message: fmt.Sprintf("File extension '%s' is not allowed. Allowed extensions: %v", 
-					ext, validation.AllowedExts),
-				Code: "invalid_extension",
-			}
-		}
-	}
-
-	return nil
+type (
+	ExtractOptions = upload.ExtractOptions
+	ExtractedFile  = upload.ExtractedFile
+)
+
+type SaveOptions struct {
+	Namer         FileNamer
+	MaxAttempts   int
+	Hash          string
+	Deduplicate   bool
+	Variants      []ImageVariant
+	BaseURL       string
+	Scanner       Scanner
+	ScanAction    string
+	QuarantineDir string
 }
 
-func SaveFile(fileHeader *FileHeader, dst string) error {
-	if fileHeader == nil {
-		return fmt.Errorf("file header is nil")
-	}
+type UploadResult = upload.Result
 
-	if err := os.MkdirAll(filepath.Dir(dst), 0755); err != nil {
-		return fmt.Errorf("failed to create destination directory: %v", err)
-	}
+type (
+	ImageVariant   = upload.ImageVariant
+	ImageProcessor = upload.ImageProcessor
+	VariantResult  = upload.VariantResult
+)
+
+const (
+	FitContain = upload.FitContain
+	FitCover   = upload.FitCover
+)
+
+type (
+	Scanner      = upload.Scanner
+	ScannerFunc  = upload.ScannerFunc
+	ScanResult   = upload.ScanResult
+	ClamdScanner = upload.ClamdScanner
+)
+
+const (
+	ScanReject     = upload.ScanReject
+	ScanQuarantine = upload.ScanQuarantine
+)
+
+const (
+	HashSHA256 = upload.HashSHA256
+	HashMD5    = upload.HashMD5
+)
 
-	out, err := os.Create(dst)
-	if err != nil {
-		return fmt.Errorf("failed to create destination file: %v", err)
-	}
-	defer out.Close()
+const NoLimit = upload.NoLimit
 
-	_, err = io.Copy(out, fileHeader.File)
-	if err != nil {
-		return fmt.Errorf("failed to copy file content: %v", err)
-	}
+func ValidateFile(fileHeader *FileHeader, validation FileValidation) error {
+	return upload.Validate(fileHeader, validation)
+}
 
-	return nil
+func SaveFile(fileHeader *FileHeader, dst string) error {
+	return upload.SaveFile(fileHeader, dst)
 }
 
 func SaveFileWithName(fileHeader *FileHeader, dir, filename string) error {
-	dst := filepath.Join(dir, filename)
-	return SaveFile(fileHeader, dst)
+	return upload.SaveFileWithName(fileHeader, dir, filename)
 }
 
 func GenerateUniqueFilename(originalName string) string {
-	ext := filepath.Ext(originalName)
-	base := strings.TrimSuffix(originalName, ext)
+	return upload.GenerateUniqueFilename(originalName)
+}
 
-	base = strings.ReplaceAll(base, " ", "_")
-	base = strings.ReplaceAll(base, "..", "")
+func SanitizeFilename(name string) string {
+	return upload.SanitizeFilename(name)
+}
 
-	timestamp := fmt.Sprintf("%d", GetCurrentTimestamp())
-	
-	return fmt.Sprintf("%s_%s%s", base, timestamp, ext)
+func SecureJoin(root, name string) (string, error) {
+	return upload.SecureJoin(root, name)
 }
 
 func GetCurrentTimestamp() int64 {
-	return time.Now().UnixNano()
+	return upload.GetCurrentTimestamp()
 }
 
 func GetFileSize(filename string) (int64, error) {
-	info, err := os.Stat(filename)
-	if err != nil {
-		return 0, err
-	}
-	return info.Size(), nil
+	return upload.GetFileSize(filename)
 }
 
 func FileExists(filename string) bool {
-	_, err := os.Stat(filename)
-	return !os.IsNotExist(err)
+	return upload.FileExists(filename)
 }
 
 func DeleteFile(filename string) error {
-	return os.Remove(filename)
+	return upload.DeleteFile(filename)
 }
 
 func GetMimeType(filename string) string {
-	ext := strings.ToLower(filepath.Ext(filename))
-	
-	mimeTypes := map[string]string{
-		".jpg":  "image/jpeg",
-		".jpeg": "image/jpeg",
-		".png":  "image/png",
-		".gif":  "image/gif",
-		".pdf":  "application/pdf",
-		".txt":  "text/plain",
-		".csv":  "text/csv",
-		".json": "application/json",
-		".xml":  "application/xml",
-		".zip":  "application/zip",
-		".mp4":  "video/mp4",
-		".mp3":  "audio/mpeg",
-	}
-	
-	if mimeType, exists := mimeTypes[ext]; exists {
-		return mimeType
-	}
-	
-	return "application/octet-stream"
+	return upload.GetMimeType(filename)
+}
+
+func LookupMimeType(filename string) string {
+	return upload.LookupMimeType(filename)
+}
+
+func RegisterMimeType(ext, mimeType string) {
+	upload.RegisterMimeType(ext, mimeType)
 }
 
 func IsImageFile(mimeType string) bool {
-	imageTypes := []string{
-		"image/jpeg",
-		"image/jpg", 
-		"image/png",
-		"image/gif",
-		"image/webp",
-		"image/svg+xml",
-	}
-	
-	for _, imageType := range imageTypes {
-		if mimeType == imageType {
-			return true
-		}
-	}
-	
-	return false
-}
-
-func FormatFileSize(size int64) string {
-	const unit = 1024
-	if size < unit {
-		return fmt.Sprintf("%d B", size)
-	}
-	
-	div, exp := int64(unit), 0
-	for n := size / unit; n >= unit; n /= unit {
-		div *= unit
-		exp++
-	}
-	
-	units := []string{"KB", "MB", "GB", "TB", "PB"}
-	return fmt.Sprintf("%.1f %s", float64(size)/float64(div), units[exp])
-}
\ No newline at end of file
+	return upload.IsImageFile(mimeType)
+}
+
+func ParseSize(s string) (int64, error) {
+	return upload.ParseSize(s)
+}
+
+func MustParseSize(s string) int64 {
+	return upload.MustParseSize(s)
+}
+
+func DefaultExtractOptions() ExtractOptions {
+	return upload.DefaultExtractOptions()
+}
+
+func ExtractArchive(fileHeader *FileHeader, dest string, opts ...ExtractOptions) ([]ExtractedFile, error) {
+	return upload.ExtractArchive(fileHeader, dest, opts...)
+}
+
+func DetectContentType(data []byte) string {
+	return upload.DetectContentType(data)
+}
+
+func SniffContentType(fileHeader *FileHeader) (string, error) {
+	return upload.SniffContentType(fileHeader)
+}
+
+func RegisterMagic(mimeType string, offset int, signature []byte) {
+	upload.RegisterMagic(mimeType, offset, signature)
+}
+
+func ImageConfig(fileHeader *FileHeader) (image.Config, string, error) {
+	return upload.ImageConfig(fileHeader)
+}
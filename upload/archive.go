@@ -0,0 +1,306 @@
+package upload
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+)
+
+const NoLimit = -1
+
+type ExtractOptions struct {
+	MaxEntries  int
+	MaxSize     Size
+	MaxFileSize Size
+	AllowedExts []string
+}
+
+func DefaultExtractOptions() ExtractOptions {
+	return ExtractOptions{
+		MaxEntries: 1000,
+		MaxSize:    100 << 20,
+	}
+}
+
+type ExtractedFile struct {
+	Name string `json:"name"`
+	Path string `json:"path"`
+	Size int64  `json:"size"`
+}
+
+type extractor struct {
+	dest    string
+	options ExtractOptions
+	entries int
+	total   int64
+	files   []ExtractedFile
+	dirs    []string
+}
+
+func ExtractArchive(fileHeader *FileHeader, dest string, opts ...ExtractOptions) ([]ExtractedFile, error) {
+	if err := rewind(fileHeader.File); err != nil {
+		return nil, err
+	}
+
+	magic := make([]byte, 4)
+	n, _ := io.ReadFull(fileHeader.File, magic)
+	if err := rewind(fileHeader.File); err != nil {
+		return nil, err
+	}
+
+	if bytes.HasPrefix(magic[:n], []byte("PK\x03\x04")) || bytes.HasPrefix(magic[:n], []byte("PK\x05\x06")) {
+		return ExtractZip(fileHeader.File, fileHeader.Size, dest, opts...)
+	}
+	return ExtractTar(fileHeader.File, dest, opts...)
+}
+
+func ExtractZip(r io.ReaderAt, size int64, dest string, opts ...ExtractOptions) ([]ExtractedFile, error) {
+	reader, err := zip.NewReader(r, size)
+	if err != nil {
+		return nil, archiveError("archive_format", "Invalid zip archive")
+	}
+
+	ex, err := newExtractor(dest, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, file := range reader.File {
+		mode := file.Mode()
+		if mode.IsDir() {
+			if err := ex.dir(file.Name); err != nil {
+				return nil, ex.fail(err)
+			}
+			continue
+		}
+		if !mode.IsRegular() {
+			return nil, ex.fail(archiveError("archive_link", fmt.Sprintf("Archive entry %q is not a regular file", file.Name)))
+		}
+
+		rc, err := file.Open()
+		if err != nil {
+			return nil, ex.fail(err)
+		}
+		err = ex.file(file.Name, rc)
+		rc.Close()
+		if err != nil {
+			return nil, ex.fail(err)
+		}
+	}
+
+	return ex.files, nil
+}
+
+func ExtractTar(r io.Reader, dest string, opts ...ExtractOptions) ([]ExtractedFile, error) {
+	buffered := bufio.NewReader(r)
+	if magic, _ := buffered.Peek(2); bytes.Equal(magic, []byte{0x1f, 0x8b}) {
+		gz, err := gzip.NewReader(buffered)
+		if err != nil {
+			return nil, archiveError("archive_format", "Invalid gzip stream")
+		}
+		defer gz.Close()
+		r = gz
+	} else {
+		r = buffered
+	}
+
+	ex, err := newExtractor(dest, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	reader := tar.NewReader(r)
+	for {
+		header, err := reader.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, ex.fail(archiveError("archive_format", "Invalid tar archive"))
+		}
+
+		switch header.Typeflag {
+		case tar.TypeDir:
+			if err := ex.dir(header.Name); err != nil {
+				return nil, ex.fail(err)
+			}
+		case tar.TypeReg:
+			if err := ex.file(header.Name, reader); err != nil {
+				return nil, ex.fail(err)
+			}
+		case tar.TypeXGlobalHeader, tar.TypeXHeader:
+		default:
+			return nil, ex.fail(archiveError("archive_link", fmt.Sprintf("Archive entry %q is not a regular file", header.Name)))
+		}
+	}
+
+	if len(ex.files) == 0 && ex.entries == 0 {
+		return nil, archiveError("archive_format", "Archive is empty or not a supported format")
+	}
+	return ex.files, nil
+}
+
+func newExtractor(dest string, opts []ExtractOptions) (*extractor, error) {
+	options := DefaultExtractOptions()
+	if len(opts) > 0 {
+		defaults := options
+		options = opts[0]
+		if options.MaxEntries == 0 {
+			options.MaxEntries = defaults.MaxEntries
+		}
+		if options.MaxSize == 0 {
+			options.MaxSize = defaults.MaxSize
+		}
+	}
+
+	abs, err := filepath.Abs(dest)
+	if err != nil {
+		return nil, err
+	}
+	if err := os.MkdirAll(abs, 0755); err != nil {
+		return nil, err
+	}
+
+	return &extractor{dest: abs, options: options}, nil
+}
+
+func (ex *extractor) count(name string) error {
+	ex.entries++
+	if ex.options.MaxEntries > 0 && ex.entries > ex.options.MaxEntries {
+		return archiveError("archive_entries", fmt.Sprintf("Archive contains more than %d entries", ex.options.MaxEntries))
+	}
+	return nil
+}
+
+func (ex *extractor) dir(name string) error {
+	if err := ex.count(name); err != nil {
+		return err
+	}
+
+	target, err := secureJoin(ex.dest, name)
+	if err != nil {
+		return err
+	}
+	if target == ex.dest {
+		return nil
+	}
+	return ex.mkdirAll(target)
+}
+
+func (ex *extractor) mkdirAll(dir string) error {
+	if _, err := os.Stat(dir); err == nil {
+		return nil
+	}
+	if parent := filepath.Dir(dir); parent != dir && parent != ex.dest {
+		if err := ex.mkdirAll(parent); err != nil {
+			return err
+		}
+	}
+	if err := os.Mkdir(dir, 0755); err != nil && !os.IsExist(err) {
+		return err
+	}
+	ex.dirs = append(ex.dirs, dir)
+	return nil
+}
+
+func (ex *extractor) file(name string, r io.Reader) error {
+	if err := ex.count(name); err != nil {
+		return err
+	}
+
+	target, err := secureJoin(ex.dest, name)
+	if err != nil {
+		return err
+	}
+	if !extAllowed(name, ex.options.AllowedExts) {
+		return archiveError("archive_extension", fmt.Sprintf("File type of %q is not allowed in archive", name))
+	}
+	if err := ex.mkdirAll(filepath.Dir(target)); err != nil {
+		return err
+	}
+
+	out, err := os.OpenFile(target, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0644)
+	if err != nil {
+		if os.IsExist(err) {
+			return archiveError("archive_duplicate", fmt.Sprintf("Archive entry %q already exists", name))
+		}
+		return err
+	}
+	ex.files = append(ex.files, ExtractedFile{Name: path.Clean(filepath.ToSlash(name)), Path: target})
+
+	limit := int64(-1)
+	if ex.options.MaxFileSize > 0 {
+		limit = int64(ex.options.MaxFileSize)
+	}
+	if ex.options.MaxSize > 0 && (limit < 0 || int64(ex.options.MaxSize)-ex.total < limit) {
+		limit = int64(ex.options.MaxSize) - ex.total
+	}
+
+	var written int64
+	if limit >= 0 {
+		written, err = io.Copy(out, io.LimitReader(r, limit+1))
+	} else {
+		written, err = io.Copy(out, r)
+	}
+	closeErr := out.Close()
+	if err != nil {
+		return err
+	}
+	if closeErr != nil {
+		return closeErr
+	}
+
+	ex.total += written
+	ex.files[len(ex.files)-1].Size = written
+	if limit >= 0 && written > limit {
+		if ex.options.MaxFileSize > 0 && written > int64(ex.options.MaxFileSize) {
+			return archiveError("archive_file_size", fmt.Sprintf("Archive entry %q exceeds maximum size of %d bytes", name, ex.options.MaxFileSize))
+		}
+		return archiveError("archive_size", fmt.Sprintf("Archive exceeds maximum uncompressed size of %d bytes", ex.options.MaxSize))
+	}
+	return nil
+}
+
+func (ex *extractor) fail(err error) error {
+	for _, file := range ex.files {
+		os.Remove(file.Path)
+	}
+	for i := len(ex.dirs) - 1; i >= 0; i-- {
+		os.Remove(ex.dirs[i])
+	}
+	ex.files = nil
+	return err
+}
+
+func secureJoin(root, name string) (string, error) {
+	target, err := SecureJoin(root, name)
+	if err != nil {
+		return "", archiveError("archive_path", fmt.Sprintf("Unsafe path in archive: %q", name))
+	}
+	return target, nil
+}
+
+func extAllowed(name string, allowed []string) bool {
+	if len(allowed) == 0 {
+		return true
+	}
+	ext := strings.ToLower(filepath.Ext(name))
+	for _, allowedExt := range allowed {
+		if strings.ToLower(allowedExt) == ext {
+			return true
+		}
+	}
+	return false
+}
+
+func archiveError(code, message string) error {
+	return Error{Message: message, Code: code}
+}
@@ -0,0 +1,95 @@
+package upload
+
+import (
+	"errors"
+	"fmt"
+	"path"
+	"path/filepath"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+const maxFilenameLength = 255
+
+var ErrUnsafePath = errors.New("upload: unsafe path")
+
+var reservedFilenames = map[string]bool{
+	"CON": true, "PRN": true, "AUX": true, "NUL": true,
+	"COM1": true, "COM2": true, "COM3": true, "COM4": true, "COM5": true, "COM6": true, "COM7": true, "COM8": true, "COM9": true,
+	"LPT1": true, "LPT2": true, "LPT3": true, "LPT4": true, "LPT5": true, "LPT6": true, "LPT7": true, "LPT8": true, "LPT9": true,
+}
+
+func SanitizeFilename(name string) string {
+	return sanitizeFilename(name, maxFilenameLength)
+}
+
+func sanitizeFilename(name string, limit int) string {
+	name = path.Base(strings.ReplaceAll(name, "\\", "/"))
+
+	var b strings.Builder
+	for i, r := range name {
+		switch {
+		case r == utf8.RuneError && !strings.HasPrefix(name[i:], string(utf8.RuneError)):
+			b.WriteByte('_')
+		case unicode.IsControl(r) || unicode.Is(unicode.Cf, r):
+			continue
+		case unicode.IsSpace(r) || strings.ContainsRune(`<>:"/|?*`, r):
+			b.WriteByte('_')
+		default:
+			b.WriteRune(r)
+		}
+	}
+
+	name = b.String()
+	for strings.Contains(name, "..") {
+		name = strings.ReplaceAll(name, "..", ".")
+	}
+	name = strings.Trim(name, ".")
+	if name == "" {
+		return "file"
+	}
+
+	if stem, _, _ := strings.Cut(name, "."); reservedFilenames[strings.ToUpper(stem)] {
+		name = "_" + name
+	}
+	return truncateFilename(name, limit)
+}
+
+func truncateFilename(name string, limit int) string {
+	if len(name) <= limit {
+		return name
+	}
+
+	ext := filepath.Ext(name)
+	base := strings.TrimSuffix(name, ext)
+	if len(ext) > limit/4 {
+		base, ext = name, ""
+	}
+
+	cut := limit - len(ext)
+	for cut > 0 && !utf8.RuneStart(base[cut]) {
+		cut--
+	}
+	return base[:cut] + ext
+}
+
+func SecureJoin(root, name string) (string, error) {
+	name = strings.ReplaceAll(name, "\\", "/")
+	if name == "" || strings.HasPrefix(name, "/") || strings.Contains(name, "\x00") || filepath.VolumeName(name) != "" {
+		return "", fmt.Errorf("%w: %q", ErrUnsafePath, name)
+	}
+
+	for _, segment := range strings.Split(name, "/") {
+		if segment == ".." {
+			return "", fmt.Errorf("%w: %q", ErrUnsafePath, name)
+		}
+	}
+
+	target := filepath.Join(root, filepath.FromSlash(path.Clean(name)))
+	rel, err := filepath.Rel(root, target)
+	if err != nil || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+		return "", fmt.Errorf("%w: %q", ErrUnsafePath, name)
+	}
+	return target, nil
+}
@@ -0,0 +1,132 @@
+package upload
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+type SizeUnits int
+
+const (
+	UnitsJEDEC SizeUnits = iota
+	UnitsIEC
+	UnitsSI
+)
+
+type Locale struct {
+	DecimalSeparator string
+	Byte             string
+	JEDECUnits       []string
+	IECUnits         []string
+	SIUnits          []string
+	Day              string
+	Hour             string
+	Minute           string
+	Second           string
+}
+
+type FormatOptions struct {
+	Locale    string
+	Units     SizeUnits
+	Precision int
+}
+
+var (
+	localesMu sync.RWMutex
+	locales   = map[string]Locale{
+		"en": {
+			DecimalSeparator: ".",
+			Byte:             "B",
+			JEDECUnits:       []string{"KB", "MB", "GB", "TB", "PB"},
+			IECUnits:         []string{"KiB", "MiB", "GiB", "TiB", "PiB"},
+			SIUnits:          []string{"kB", "MB", "GB", "TB", "PB"},
+			Day:              "d",
+			Hour:             "h",
+			Minute:           "m",
+			Second:           "s",
+		},
+		"ru": {
+			DecimalSeparator: ",",
+			Byte:             "Б",
+			JEDECUnits:       []string{"КБ", "МБ", "ГБ", "ТБ", "ПБ"},
+			IECUnits:         []string{"КиБ", "МиБ", "ГиБ", "ТиБ", "ПиБ"},
+			SIUnits:          []string{"кБ", "МБ", "ГБ", "ТБ", "ПБ"},
+			Day:              " д",
+			Hour:             " ч",
+			Minute:           " мин",
+			Second:           " с",
+		},
+	}
+	formatDefaults = FormatOptions{Locale: "en", Units: UnitsJEDEC, Precision: 1}
+)
+
+func RegisterLocale(name string, locale Locale) {
+	localesMu.Lock()
+	defer localesMu.Unlock()
+	locales[name] = locale
+}
+
+func SetFormatDefaults(opts FormatOptions) {
+	localesMu.Lock()
+	defer localesMu.Unlock()
+	formatDefaults = opts
+}
+
+// ResolveFormat applies the package defaults to opts and returns the
+// options together with the locale they select, falling back to "en".
+func ResolveFormat(opts ...FormatOptions) (FormatOptions, Locale) {
+	localesMu.RLock()
+	defer localesMu.RUnlock()
+
+	options := formatDefaults
+	if len(opts) > 0 {
+		options = opts[0]
+		if options.Locale == "" {
+			options.Locale = formatDefaults.Locale
+		}
+	}
+	if options.Precision <= 0 {
+		options.Precision = 1
+	}
+
+	locale, exists := locales[options.Locale]
+	if !exists {
+		if i := strings.IndexAny(options.Locale, "-_"); i > 0 {
+			locale, exists = locales[options.Locale[:i]]
+		}
+		if !exists {
+			locale = locales["en"]
+		}
+	}
+	return options, locale
+}
+
+func FormatFileSize(size int64, opts ...FormatOptions) string {
+	options, locale := ResolveFormat(opts...)
+
+	base, units := int64(1024), locale.JEDECUnits
+	switch options.Units {
+	case UnitsIEC:
+		units = locale.IECUnits
+	case UnitsSI:
+		base, units = 1000, locale.SIUnits
+	}
+
+	if size < base && size > -base {
+		return fmt.Sprintf("%d %s", size, locale.Byte)
+	}
+
+	value, exp := float64(size)/float64(base), 0
+	for (value >= float64(base) || value <= -float64(base)) && exp < len(units)-1 {
+		value /= float64(base)
+		exp++
+	}
+
+	number := strconv.FormatFloat(value, 'f', options.Precision, 64)
+	if locale.DecimalSeparator != "" && locale.DecimalSeparator != "." {
+		number = strings.Replace(number, ".", locale.DecimalSeparator, 1)
+	}
+	return number + " " + units[exp]
+}
@@ -0,0 +1,147 @@
+package upload
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"image"
+	_ "image/gif"
+	_ "image/jpeg"
+	_ "image/png"
+	"io"
+)
+
+const maxImageHeader = 1 << 20
+
+func (v Validation) checksImage() bool {
+	return v.MinWidth > 0 || v.MaxWidth > 0 || v.MinHeight > 0 || v.MaxHeight > 0 ||
+		v.MinAspectRatio > 0 || v.MaxAspectRatio > 0
+}
+
+func (v Validation) withoutImageChecks() Validation {
+	v.MinWidth, v.MaxWidth, v.MinHeight, v.MaxHeight = 0, 0, 0, 0
+	v.MinAspectRatio, v.MaxAspectRatio = 0, 0
+	return v
+}
+
+func ImageConfig(fileHeader *FileHeader) (image.Config, string, error) {
+	var config image.Config
+	var format string
+	err := readContent(fileHeader, func(r io.Reader) error {
+		var err error
+		config, format, err = image.DecodeConfig(io.LimitReader(r, maxImageHeader))
+		return err
+	})
+	return config, format, err
+}
+
+func checkImage(config image.Config, err error, validation Validation) error {
+	if err != nil {
+		return Error{
+			Message: fmt.Sprintf("File is not a supported image: %v", err),
+			Code:    "invalid_image",
+		}
+	}
+
+	width, height := config.Width, config.Height
+	for _, limit := range []struct {
+		name     string
+		value    int
+		min, max int
+	}{{"width", width, validation.MinWidth, validation.MaxWidth}, {"height", height, validation.MinHeight, validation.MaxHeight}} {
+		if limit.min > 0 && limit.value < limit.min {
+			return Error{
+				Message: fmt.Sprintf("Image %s of %dpx is below the minimum of %dpx", limit.name, limit.value, limit.min),
+				Code:    "image_too_small",
+			}
+		}
+		if limit.max > 0 && limit.value > limit.max {
+			return Error{
+				Message: fmt.Sprintf("Image %s of %dpx exceeds the maximum of %dpx", limit.name, limit.value, limit.max),
+				Code:    "image_too_large",
+			}
+		}
+	}
+
+	if validation.MinAspectRatio > 0 || validation.MaxAspectRatio > 0 {
+		ratio := 0.0
+		if height > 0 {
+			ratio = float64(width) / float64(height)
+		}
+		if (validation.MinAspectRatio > 0 && ratio < validation.MinAspectRatio) || (validation.MaxAspectRatio > 0 && ratio > validation.MaxAspectRatio) {
+			return Error{
+				Message: fmt.Sprintf("Image aspect ratio %.2f is outside the allowed range", ratio),
+				Code:    "invalid_aspect_ratio",
+			}
+		}
+	}
+	return nil
+}
+
+func jpegOrientation(data []byte) int {
+	if len(data) < 4 || data[0] != 0xFF || data[1] != 0xD8 {
+		return 1
+	}
+
+	for i := 2; i+4 <= len(data); {
+		if data[i] != 0xFF {
+			return 1
+		}
+		marker := data[i+1]
+		switch {
+		case marker == 0xFF:
+			i++
+			continue
+		case marker == 0xDA || marker == 0xD9:
+			return 1
+		case marker >= 0xD0 && marker <= 0xD7:
+			i += 2
+			continue
+		}
+
+		size := int(binary.BigEndian.Uint16(data[i+2:]))
+		if size < 2 || i+2+size > len(data) {
+			return 1
+		}
+		if segment := data[i+4 : i+2+size]; marker == 0xE1 && bytes.HasPrefix(segment, []byte("Exif\x00\x00")) {
+			return exifOrientation(segment[6:])
+		}
+		i += 2 + size
+	}
+	return 1
+}
+
+func exifOrientation(tiff []byte) int {
+	if len(tiff) < 8 {
+		return 1
+	}
+
+	var order binary.ByteOrder
+	switch string(tiff[:2]) {
+	case "II":
+		order = binary.LittleEndian
+	case "MM":
+		order = binary.BigEndian
+	default:
+		return 1
+	}
+
+	offset := int(order.Uint32(tiff[4:]))
+	if offset < 8 || offset+2 > len(tiff) {
+		return 1
+	}
+	count := int(order.Uint16(tiff[offset:]))
+	for i := 0; i < count; i++ {
+		entry := offset + 2 + i*12
+		if entry+12 > len(tiff) {
+			return 1
+		}
+		if order.Uint16(tiff[entry:]) == 0x0112 {
+			if value := int(order.Uint16(tiff[entry+8:])); value >= 1 && value <= 8 {
+				return value
+			}
+			return 1
+		}
+	}
+	return 1
+}
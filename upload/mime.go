@@ -0,0 +1,111 @@
+package upload
+
+import (
+	"mime"
+	"path/filepath"
+	"strings"
+	"sync"
+)
+
+var (
+	mimeMu        sync.RWMutex
+	mimeOverrides = map[string]string{}
+	mimeFallback  = map[string]string{
+		".7z":    "application/x-7z-compressed",
+		".aac":   "audio/aac",
+		".avi":   "video/x-msvideo",
+		".bmp":   "image/bmp",
+		".csv":   "text/csv; charset=utf-8",
+		".doc":   "application/msword",
+		".docx":  "application/vnd.openxmlformats-officedocument.wordprocessingml.document",
+		".eot":   "application/vnd.ms-fontobject",
+		".epub":  "application/epub+zip",
+		".flac":  "audio/flac",
+		".gz":    "application/gzip",
+		".heic":  "image/heic",
+		".ico":   "image/x-icon",
+		".ics":   "text/calendar; charset=utf-8",
+		".jsonl": "application/jsonl",
+		".m4a":   "audio/mp4",
+		".md":    "text/markdown; charset=utf-8",
+		".mov":   "video/quicktime",
+		".mp3":   "audio/mpeg",
+		".mp4":   "video/mp4",
+		".odp":   "application/vnd.oasis.opendocument.presentation",
+		".ods":   "application/vnd.oasis.opendocument.spreadsheet",
+		".odt":   "application/vnd.oasis.opendocument.text",
+		".oga":   "audio/ogg",
+		".ogg":   "audio/ogg",
+		".ogv":   "video/ogg",
+		".otf":   "font/otf",
+		".ppt":   "application/vnd.ms-powerpoint",
+		".pptx":  "application/vnd.openxmlformats-officedocument.presentationml.presentation",
+		".rar":   "application/vnd.rar",
+		".rtf":   "application/rtf",
+		".tar":   "application/x-tar",
+		".tif":   "image/tiff",
+		".tiff":  "image/tiff",
+		".ttf":   "font/ttf",
+		".txt":   "text/plain; charset=utf-8",
+		".wav":   "audio/wav",
+		".weba":  "audio/webm",
+		".webm":  "video/webm",
+		".woff":  "font/woff",
+		".woff2": "font/woff2",
+		".xls":   "application/vnd.ms-excel",
+		".xlsx":  "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet",
+		".yaml":  "application/yaml",
+		".yml":   "application/yaml",
+		".zip":   "application/zip",
+		".zst":   "application/zstd",
+	}
+)
+
+func RegisterMimeType(ext, mimeType string) {
+	ext = normalizeExt(ext)
+	mimeMu.Lock()
+	defer mimeMu.Unlock()
+	mimeOverrides[ext] = mimeType
+}
+
+func LookupMimeType(filename string) string {
+	ext := normalizeExt(filepath.Ext(filename))
+	if ext == "." {
+		return ""
+	}
+
+	mimeMu.RLock()
+	mimeType, ok := mimeOverrides[ext]
+	mimeMu.RUnlock()
+	if ok {
+		return mimeType
+	}
+
+	if mimeType := mime.TypeByExtension(ext); mimeType != "" {
+		return mimeType
+	}
+	return mimeFallback[ext]
+}
+
+func GetMimeType(filename string) string {
+	mimeType := LookupMimeType(filename)
+	if mimeType == "" {
+		return "application/octet-stream"
+	}
+	if mediaType, _, err := mime.ParseMediaType(mimeType); err == nil {
+		return mediaType
+	}
+	return mimeType
+}
+
+func normalizeExt(ext string) string {
+	return "." + strings.TrimPrefix(strings.ToLower(ext), ".")
+}
+
+func fileContentType(fileHeader *FileHeader) string {
+	contentType := fileHeader.Header.Get("Content-Type")
+	if contentType == "" || contentType == "application/octet-stream" {
+		return GetMimeType(fileHeader.Filename)
+	}
+	return contentType
+}
@@ -0,0 +1,131 @@
+package upload
+
+import (
+	"crypto/md5"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"hash"
+	"io"
+	"path/filepath"
+	"strings"
+)
+
+var ErrCollision = errors.New("upload: file name already exists")
+
+const DefaultMaxAttempts = 5
+
+type AttemptNamer interface {
+	Namer
+	NameAttempt(fileHeader *FileHeader, attempt int) (string, error)
+}
+
+type UUIDNamer struct{}
+
+func (UUIDNamer) Name(fileHeader *FileHeader) (string, error) {
+	id, err := NewUUID()
+	if err != nil {
+		return "", err
+	}
+	return id + safeExt(fileHeader.Filename), nil
+}
+
+type HashNamer struct{}
+
+func (HashNamer) Name(fileHeader *FileHeader) (string, error) {
+	sum, err := HashFile(fileHeader)
+	if err != nil {
+		return "", err
+	}
+	return sum + safeExt(fileHeader.Filename), nil
+}
+
+type SequenceNamer struct{}
+
+func (n SequenceNamer) Name(fileHeader *FileHeader) (string, error) {
+	return n.NameAttempt(fileHeader, 0)
+}
+
+func (SequenceNamer) NameAttempt(fileHeader *FileHeader, attempt int) (string, error) {
+	ext := safeExt(fileHeader.Filename)
+	name := sanitizeFilename(fileHeader.Filename, maxFilenameLength-len(ext)-4)
+	base := strings.TrimSuffix(name, filepath.Ext(name))
+	if base == "" {
+		base = "file"
+	}
+	if attempt == 0 {
+		return base + ext, nil
+	}
+	return fmt.Sprintf("%s-%d%s", base, attempt, ext), nil
+}
+
+func NewUUID() (string, error) {
+	var b [16]byte
+	if _, err := rand.Read(b[:]); err != nil {
+		return "", fmt.Errorf("failed to generate uuid: %v", err)
+	}
+	b[6] = (b[6] & 0x0f) | 0x40
+	b[8] = (b[8] & 0x3f) | 0x80
+	return fmt.Sprintf("%x-%x-%x-%x-%x", b[0:4], b[4:6], b[6:8], b[8:10], b[10:16]), nil
+}
+
+const (
+	HashSHA256 = "sha256"
+	HashMD5    = "md5"
+)
+
+func HashFile(fileHeader *FileHeader) (string, error) {
+	return hashContent(fileHeader, HashSHA256)
+}
+
+func hashContent(fileHeader *FileHeader, algorithm string) (string, error) {
+	if fileHeader == nil || fileHeader.File == nil {
+		return "", fmt.Errorf("file header is nil")
+	}
+
+	h, err := newHash(algorithm)
+	if err != nil {
+		return "", err
+	}
+	if _, err := io.Copy(h, fileHeader.File); err != nil {
+		return "", fmt.Errorf("failed to hash file content: %v", err)
+	}
+	if err := rewind(fileHeader.File); err != nil {
+		return "", err
+	}
+
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+func newHash(algorithm string) (hash.Hash, error) {
+	switch strings.ToLower(algorithm) {
+	case HashSHA256:
+		return sha256.New(), nil
+	case HashMD5:
+		return md5.New(), nil
+	}
+	return nil, fmt.Errorf("unsupported hash algorithm: %s", algorithm)
+}
+
+func rewind(r io.Reader) error {
+	seeker, ok := r.(io.Seeker)
+	if !ok {
+		return fmt.Errorf("file content is not seekable")
+	}
+	if _, err := seeker.Seek(0, io.SeekStart); err != nil {
+		return fmt.Errorf("failed to rewind file: %v", err)
+	}
+	return nil
+}
+
+func safeExt(filename string) string {
+	ext := strings.ToLower(filepath.Ext(filename))
+	for _, r := range strings.TrimPrefix(ext, ".") {
+		if !(r >= 'a' && r <= 'z' || r >= '0' && r <= '9') {
+			return ""
+		}
+	}
+	return ext
+}
@@ -0,0 +1,298 @@
+package upload
+
+import (
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"hash"
+	"io"
+	"os"
+	"path/filepath"
+)
+
+type Validator interface {
+	Validate(fileHeader *FileHeader) error
+}
+
+type Namer interface {
+	Name(fileHeader *FileHeader) (string, error)
+}
+
+type Storage interface {
+	Save(name string, r io.Reader) (string, error)
+	Exists(name string) (bool, error)
+	Delete(name string) error
+}
+
+type Pipeline interface {
+	Process(fileHeader *FileHeader) (*Result, error)
+}
+
+type PostProcessor interface {
+	PostProcess(fileHeader *FileHeader, result *Result, storage Storage) error
+}
+
+type Result struct {
+	OriginalName string          `json:"original_name"`
+	Name         string          `json:"name"`
+	Location     string          `json:"location"`
+	Size         int64           `json:"size"`
+	ContentType  string          `json:"content_type"`
+	Checksum     string          `json:"checksum,omitempty"`
+	Duplicate    bool            `json:"duplicate,omitempty"`
+	URL          string          `json:"url,omitempty"`
+	Variants     []VariantResult `json:"variants,omitempty"`
+}
+
+func (v Validation) Validate(fileHeader *FileHeader) error {
+	return Validate(fileHeader, v)
+}
+
+type NamerFunc func(fileHeader *FileHeader) (string, error)
+
+func (f NamerFunc) Name(fileHeader *FileHeader) (string, error) {
+	return f(fileHeader)
+}
+
+type PostProcessFunc func(fileHeader *FileHeader, result *Result, storage Storage) error
+
+func (f PostProcessFunc) PostProcess(fileHeader *FileHeader, result *Result, storage Storage) error {
+	return f(fileHeader, result, storage)
+}
+
+type TimestampNamer struct{}
+
+func (TimestampNamer) Name(fileHeader *FileHeader) (string, error) {
+	return GenerateUniqueFilename(fileHeader.Filename), nil
+}
+
+type DiskStorage struct {
+	Dir string
+}
+
+func (s DiskStorage) Save(name string, r io.Reader) (string, error) {
+	dst, err := SecureJoin(s.Dir, name)
+	if err != nil {
+		return "", err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(dst), 0755); err != nil {
+		return "", fmt.Errorf("failed to create destination directory: %v", err)
+	}
+
+	out, err := os.OpenFile(dst, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0644)
+	if err != nil {
+		if os.IsExist(err) {
+			return "", fmt.Errorf("%w: %s", ErrCollision, name)
+		}
+		return "", fmt.Errorf("failed to create destination file: %v", err)
+	}
+	defer out.Close()
+
+	if _, err := io.Copy(out, r); err != nil {
+		os.Remove(dst)
+		return "", fmt.Errorf("failed to copy file content: %v", err)
+	}
+
+	return dst, nil
+}
+
+func (s DiskStorage) Location(name string) string {
+	return filepath.Join(s.Dir, name)
+}
+
+func (s DiskStorage) Exists(name string) (bool, error) {
+	target, err := SecureJoin(s.Dir, name)
+	if err != nil {
+		return false, err
+	}
+	_, err = os.Stat(target)
+	if os.IsNotExist(err) {
+		return false, nil
+	}
+	return err == nil, err
+}
+
+func (s DiskStorage) Delete(name string) error {
+	target, err := SecureJoin(s.Dir, name)
+	if err != nil {
+		return err
+	}
+	return os.Remove(target)
+}
+
+type Locator interface {
+	Location(name string) string
+}
+
+type DefaultPipeline struct {
+	Validator   Validator
+	Namer       Namer
+	Storage     Storage
+	MaxAttempts int
+	Hash        string
+	Deduplicate bool
+	PostProcess []PostProcessor
+}
+
+func NewPipeline(dir string, validation ...Validation) *DefaultPipeline {
+	pipeline := &DefaultPipeline{
+		Namer:   TimestampNamer{},
+		Storage: DiskStorage{Dir: dir},
+	}
+	if len(validation) > 0 {
+		pipeline.Validator = validation[0]
+	}
+	return pipeline
+}
+
+func (p *DefaultPipeline) Process(fileHeader *FileHeader) (*Result, error) {
+	result, err := p.store(fileHeader)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, processor := range p.PostProcess {
+		if err := processor.PostProcess(fileHeader, result, p.Storage); err != nil {
+			if !result.Duplicate {
+				p.Storage.Delete(result.Name)
+			}
+			return nil, err
+		}
+	}
+	return result, nil
+}
+
+func (p *DefaultPipeline) store(fileHeader *FileHeader) (*Result, error) {
+	if fileHeader == nil {
+		return nil, fmt.Errorf("file header is nil")
+	}
+
+	if p.Validator != nil {
+		if err := p.Validator.Validate(fileHeader); err != nil {
+			return nil, err
+		}
+	}
+
+	namer := p.Namer
+	if namer == nil {
+		namer = TimestampNamer{}
+	}
+
+	if p.Storage == nil {
+		return nil, fmt.Errorf("upload pipeline has no storage")
+	}
+
+	if p.Deduplicate {
+		return p.deduplicate(fileHeader)
+	}
+
+	var content io.Reader = fileHeader.File
+	var digest hash.Hash
+	if p.Hash != "" {
+		h, err := newHash(p.Hash)
+		if err != nil {
+			return nil, err
+		}
+		digest = h
+		content = io.TeeReader(fileHeader.File, digest)
+	}
+
+	name, location, err := p.save(namer, fileHeader, content)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &Result{
+		OriginalName: fileHeader.Filename,
+		Name:         name,
+		Location:     location,
+		Size:         fileHeader.Size,
+		ContentType:  fileContentType(fileHeader),
+	}
+	if digest != nil {
+		result.Checksum = hex.EncodeToString(digest.Sum(nil))
+	}
+	return result, nil
+}
+
+func (p *DefaultPipeline) deduplicate(fileHeader *FileHeader) (*Result, error) {
+	algorithm := p.Hash
+	if algorithm == "" {
+		algorithm = HashSHA256
+	}
+	sum, err := hashContent(fileHeader, algorithm)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &Result{
+		OriginalName: fileHeader.Filename,
+		Name:         sum + safeExt(fileHeader.Filename),
+		Size:         fileHeader.Size,
+		ContentType:  fileContentType(fileHeader),
+		Checksum:     sum,
+	}
+
+	exists, err := p.Storage.Exists(result.Name)
+	if err != nil {
+		return nil, err
+	}
+	if !exists {
+		result.Location, err = p.Storage.Save(result.Name, fileHeader.File)
+		if err == nil {
+			return result, nil
+		}
+		if !errors.Is(err, ErrCollision) {
+			return nil, err
+		}
+	}
+
+	result.Duplicate = true
+	result.Location = locate(p.Storage, result.Name)
+	return result, nil
+}
+
+func (p *DefaultPipeline) save(namer Namer, fileHeader *FileHeader, content io.Reader) (string, string, error) {
+	attempts := p.MaxAttempts
+	if attempts <= 0 {
+		attempts = DefaultMaxAttempts
+	}
+
+	previous := ""
+	for attempt := 0; attempt < attempts; attempt++ {
+		var name string
+		var err error
+		if attemptNamer, ok := namer.(AttemptNamer); ok {
+			name, err = attemptNamer.NameAttempt(fileHeader, attempt)
+		} else {
+			name, err = namer.Name(fileHeader)
+		}
+		if err != nil {
+			return "", "", err
+		}
+		if name == previous {
+			break
+		}
+		previous = name
+
+		exists, err := p.Storage.Exists(name)
+		if err != nil {
+			return "", "", err
+		}
+		if exists {
+			continue
+		}
+
+		location, err := p.Storage.Save(name, content)
+		if errors.Is(err, ErrCollision) {
+			continue
+		}
+		if err != nil {
+			return "", "", err
+		}
+		return name, location, nil
+	}
+
+	return "", "", fmt.Errorf("%w: %s", ErrCollision, previous)
+}
@@ -0,0 +1,276 @@
+package upload
+
+import (
+	"bufio"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+const (
+	ScanReject     = "reject"
+	ScanQuarantine = "quarantine"
+)
+
+const (
+	defaultClamdAddress = "127.0.0.1:3310"
+	defaultClamdTimeout = 2 * time.Minute
+	clamdChunkSize      = 64 << 10
+)
+
+type ScanResult struct {
+	Infected bool
+	Threat   string
+}
+
+type Scanner interface {
+	Scan(r io.Reader) (ScanResult, error)
+}
+
+type ScannerFunc func(r io.Reader) (ScanResult, error)
+
+func (f ScannerFunc) Scan(r io.Reader) (ScanResult, error) {
+	return f(r)
+}
+
+type ClamdScanner struct {
+	Network string
+	Address string
+	Timeout time.Duration
+}
+
+func (s ClamdScanner) Scan(r io.Reader) (ScanResult, error) {
+	network, address, timeout := s.Network, s.Address, s.Timeout
+	if network == "" {
+		network = "tcp"
+	}
+	if address == "" {
+		address = defaultClamdAddress
+	}
+	if timeout <= 0 {
+		timeout = defaultClamdTimeout
+	}
+
+	conn, err := net.DialTimeout(network, address, timeout)
+	if err != nil {
+		return ScanResult{}, fmt.Errorf("failed to connect to clamd: %v", err)
+	}
+	defer conn.Close()
+	conn.SetDeadline(time.Now().Add(timeout))
+
+	writeErr := writeClamdStream(conn, r)
+	var readErr *sourceError
+	if errors.As(writeErr, &readErr) {
+		return ScanResult{}, readErr.err
+	}
+
+	reply, err := bufio.NewReader(conn).ReadString(0)
+	if err != nil && reply == "" {
+		if writeErr != nil {
+			return ScanResult{}, writeErr
+		}
+		return ScanResult{}, fmt.Errorf("failed to read clamd reply: %v", err)
+	}
+	return parseClamdReply(strings.TrimRight(reply, "\x00\n"))
+}
+
+func writeClamdStream(w io.Writer, r io.Reader) error {
+	if _, err := io.WriteString(w, "zINSTREAM\x00"); err != nil {
+		return fmt.Errorf("failed to send clamd command: %v", err)
+	}
+
+	buf := make([]byte, 4+clamdChunkSize)
+	for {
+		n, err := io.ReadFull(r, buf[4:])
+		if n > 0 {
+			binary.BigEndian.PutUint32(buf[:4], uint32(n))
+			if _, err := w.Write(buf[:4+n]); err != nil {
+				return fmt.Errorf("failed to stream content to clamd: %v", err)
+			}
+		}
+		if err == io.EOF || err == io.ErrUnexpectedEOF {
+			break
+		}
+		if err != nil {
+			return &sourceError{err: fmt.Errorf("failed to read file content: %v", err)}
+		}
+	}
+
+	if _, err := w.Write([]byte{0, 0, 0, 0}); err != nil {
+		return fmt.Errorf("failed to stream content to clamd: %v", err)
+	}
+	return nil
+}
+
+type sourceError struct {
+	err error
+}
+
+func (e *sourceError) Error() string {
+	return e.err.Error()
+}
+
+func parseClamdReply(reply string) (ScanResult, error) {
+	_, status, _ := strings.Cut(reply, ": ")
+	switch {
+	case status == "OK":
+		return ScanResult{}, nil
+	case strings.HasSuffix(status, " FOUND"):
+		return ScanResult{Infected: true, Threat: strings.TrimSuffix(status, " FOUND")}, nil
+	default:
+		return ScanResult{}, fmt.Errorf("clamd error: %s", reply)
+	}
+}
+
+func (v Validation) checkScanConfig() error {
+	switch v.ScanAction {
+	case "", ScanReject:
+		return nil
+	case ScanQuarantine:
+		if v.QuarantineDir == "" {
+			return fmt.Errorf("quarantine scan action requires a quarantine directory")
+		}
+		return nil
+	default:
+		return fmt.Errorf("unknown scan action %q", v.ScanAction)
+	}
+}
+
+func scanFile(fileHeader *FileHeader, validation Validation) error {
+	if err := validation.checkScanConfig(); err != nil {
+		return err
+	}
+
+	var result ScanResult
+	err := readContent(fileHeader, func(r io.Reader) error {
+		var err error
+		result, err = validation.Scanner.Scan(r)
+		return err
+	})
+	if err != nil {
+		return fmt.Errorf("content scan failed: %w", err)
+	}
+	if !result.Infected {
+		return nil
+	}
+
+	if validation.ScanAction == ScanQuarantine {
+		err := readContent(fileHeader, func(r io.Reader) error {
+			return quarantine(validation.QuarantineDir, fileHeader.Filename, r)
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return infectedError(result)
+}
+
+type streamScan struct {
+	validation Validation
+	filename   string
+	pipe       *io.PipeWriter
+	done       chan struct{}
+	result     ScanResult
+	err        error
+	held       *os.File
+}
+
+func startScan(validation Validation, filename string, content io.Reader) (*streamScan, io.Reader, error) {
+	if err := validation.checkScanConfig(); err != nil {
+		return nil, nil, err
+	}
+
+	reader, writer := io.Pipe()
+	scan := &streamScan{validation: validation, filename: filename, pipe: writer, done: make(chan struct{})}
+	var sink io.Writer = writer
+	if validation.ScanAction == ScanQuarantine {
+		if err := os.MkdirAll(validation.QuarantineDir, 0700); err != nil {
+			return nil, nil, fmt.Errorf("failed to create quarantine directory: %v", err)
+		}
+		held, err := os.CreateTemp(validation.QuarantineDir, ".scan-*")
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to create quarantine file: %v", err)
+		}
+		scan.held = held
+		sink = io.MultiWriter(writer, held)
+	}
+
+	go func() {
+		defer close(scan.done)
+		scan.result, scan.err = validation.Scanner.Scan(reader)
+		io.Copy(io.Discard, reader)
+	}()
+	return scan, io.TeeReader(content, sink), nil
+}
+
+func (s *streamScan) abort(err error) {
+	s.pipe.CloseWithError(err)
+	<-s.done
+	s.release()
+}
+
+func (s *streamScan) finish() error {
+	s.pipe.Close()
+	<-s.done
+	if s.err != nil {
+		s.release()
+		return fmt.Errorf("content scan failed: %w", s.err)
+	}
+	if !s.result.Infected {
+		s.release()
+		return nil
+	}
+
+	if s.held != nil {
+		s.held.Close()
+		target := filepath.Join(s.validation.QuarantineDir, quarantineName(s.filename))
+		if err := os.Rename(s.held.Name(), target); err != nil {
+			os.Remove(s.held.Name())
+			return fmt.Errorf("failed to quarantine file: %v", err)
+		}
+	}
+	return infectedError(s.result)
+}
+
+func (s *streamScan) release() {
+	if s.held != nil {
+		s.held.Close()
+		os.Remove(s.held.Name())
+	}
+}
+
+func quarantine(dir, filename string, r io.Reader) error {
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return fmt.Errorf("failed to create quarantine directory: %v", err)
+	}
+
+	out, err := os.OpenFile(filepath.Join(dir, quarantineName(filename)), os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0600)
+	if err != nil {
+		return fmt.Errorf("failed to quarantine file: %v", err)
+	}
+	defer out.Close()
+
+	if _, err := io.Copy(out, r); err != nil {
+		os.Remove(out.Name())
+		return fmt.Errorf("failed to quarantine file: %v", err)
+	}
+	return nil
+}
+
+func quarantineName(filename string) string {
+	return fmt.Sprintf("%d_%s", GetCurrentTimestamp(), SanitizeFilename(filename))
+}
+
+func infectedError(result ScanResult) Error {
+	message := "File was rejected by the content scanner"
+	if result.Threat != "" {
+		message += ": " + result.Threat
+	}
+	return Error{Message: message, Code: "infected"}
+}
@@ -0,0 +1,100 @@
+package upload
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+type Size int64
+
+var sizeUnits = map[string]float64{
+	"":    1,
+	"b":   1,
+	"k":   1 << 10,
+	"kb":  1 << 10,
+	"kib": 1 << 10,
+	"m":   1 << 20,
+	"mb":  1 << 20,
+	"mib": 1 << 20,
+	"g":   1 << 30,
+	"gb":  1 << 30,
+	"gib": 1 << 30,
+	"t":   1 << 40,
+	"tb":  1 << 40,
+	"tib": 1 << 40,
+	"p":   1 << 50,
+	"pb":  1 << 50,
+	"pib": 1 << 50,
+}
+
+func ParseSize(s string) (int64, error) {
+	str := strings.TrimSpace(s)
+	if str == "" {
+		return 0, fmt.Errorf("invalid size %q: empty string", s)
+	}
+
+	i := 0
+	for i < len(str) && (str[i] >= '0' && str[i] <= '9' || str[i] == '.') {
+		i++
+	}
+
+	number, unit := str[:i], strings.ToLower(strings.TrimSpace(str[i:]))
+	value, err := strconv.ParseFloat(number, 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid size %q: bad number", s)
+	}
+
+	multiplier, ok := sizeUnits[unit]
+	if !ok {
+		return 0, fmt.Errorf("invalid size %q: unknown unit %q", s, str[i:])
+	}
+
+	bytes := value * multiplier
+	if bytes > math.MaxInt64 {
+		return 0, fmt.Errorf("invalid size %q: value too large", s)
+	}
+
+	return int64(bytes), nil
+}
+
+func MustParseSize(s string) int64 {
+	size, err := ParseSize(s)
+	if err != nil {
+		panic(err)
+	}
+	return size
+}
+
+func (s Size) Int64() int64 {
+	return int64(s)
+}
+
+func (s Size) String() string {
+	return FormatFileSize(int64(s))
+}
+
+func (s *Size) UnmarshalText(text []byte) error {
+	size, err := ParseSize(string(text))
+	if err != nil {
+		return err
+	}
+	*s = Size(size)
+	return nil
+}
+
+func (s *Size) UnmarshalJSON(data []byte) error {
+	var str string
+	if err := json.Unmarshal(data, &str); err == nil {
+		return s.UnmarshalText([]byte(str))
+	}
+
+	var number int64
+	if err := json.Unmarshal(data, &number); err != nil {
+		return fmt.Errorf("invalid size %s: expected string or integer", data)
+	}
+	*s = Size(number)
+	return nil
+}
@@ -0,0 +1,107 @@
+package upload
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"sync"
+)
+
+const sniffLen = 512
+
+type Magic struct {
+	Type      string
+	Offset    int
+	Signature []byte
+}
+
+var (
+	magicMu    sync.RWMutex
+	magicTable = []Magic{
+		{Type: "image/tiff", Signature: []byte("II*\x00")},
+		{Type: "image/tiff", Signature: []byte("MM\x00*")},
+		{Type: "image/heic", Offset: 4, Signature: []byte("ftypheic")},
+		{Type: "image/avif", Offset: 4, Signature: []byte("ftypavif")},
+		{Type: "video/quicktime", Offset: 4, Signature: []byte("ftypqt  ")},
+		{Type: "application/x-7z-compressed", Signature: []byte("7z\xbc\xaf\x27\x1c")},
+		{Type: "application/x-xz", Signature: []byte("\xfd7zXZ\x00")},
+		{Type: "application/zstd", Signature: []byte("\x28\xb5\x2f\xfd")},
+	}
+)
+
+func RegisterMagic(mimeType string, offset int, signature []byte) {
+	magicMu.Lock()
+	defer magicMu.Unlock()
+	magicTable = append([]Magic{{Type: mimeType, Offset: offset, Signature: signature}}, magicTable...)
+}
+
+func DetectContentType(data []byte) string {
+	if len(data) > sniffLen {
+		data = data[:sniffLen]
+	}
+
+	magicMu.RLock()
+	for _, magic := range magicTable {
+		end := magic.Offset + len(magic.Signature)
+		if end <= len(data) && bytes.Equal(data[magic.Offset:end], magic.Signature) {
+			magicMu.RUnlock()
+			return magic.Type
+		}
+	}
+	magicMu.RUnlock()
+
+	contentType := http.DetectContentType(data)
+	if mediaType, _, err := mime.ParseMediaType(contentType); err == nil {
+		return mediaType
+	}
+	return contentType
+}
+
+func SniffContentType(fileHeader *FileHeader) (string, error) {
+	var contentType string
+	err := readContent(fileHeader, func(r io.Reader) error {
+		buf := make([]byte, sniffLen)
+		n, err := io.ReadFull(r, buf)
+		if err != nil && err != io.EOF && err != io.ErrUnexpectedEOF {
+			return fmt.Errorf("failed to read file content: %v", err)
+		}
+		contentType = DetectContentType(buf[:n])
+		return nil
+	})
+	return contentType, err
+}
+
+func readContent(fileHeader *FileHeader, fn func(r io.Reader) error) error {
+	if fileHeader == nil {
+		return fmt.Errorf("file header is nil")
+	}
+
+	if fileHeader.File != nil {
+		defer rewind(fileHeader.File)
+		return fn(fileHeader.File)
+	}
+	if fileHeader.FileHeader == nil {
+		return fmt.Errorf("file content is not available")
+	}
+
+	file, err := fileHeader.Open()
+	if err != nil {
+		return fmt.Errorf("failed to open file: %v", err)
+	}
+	defer file.Close()
+	return fn(file)
+}
+
+func checkType(contentType string, allowedTypes []string) error {
+	for _, allowedType := range allowedTypes {
+		if contentType == allowedType {
+			return nil
+		}
+	}
+	return Error{
+		Message: fmt.Sprintf("File type '%s' is not allowed. Allowed types: %v", contentType, allowedTypes),
+		Code:    "invalid_type",
+	}
+}
@@ -0,0 +1,190 @@
+package upload
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"hash"
+	"image"
+	"io"
+	"mime/multipart"
+	"net/textproto"
+)
+
+type Stream struct {
+	Validation  Validation
+	Namer       Namer
+	Storage     Storage
+	MaxAttempts int
+}
+
+func (s *Stream) Process(part *multipart.Part) (*Result, error) {
+	if part == nil {
+		return nil, fmt.Errorf("multipart part is nil")
+	}
+	if s.Storage == nil {
+		return nil, fmt.Errorf("upload stream has no storage")
+	}
+
+	fileHeader := &FileHeader{
+		FileHeader: &multipart.FileHeader{
+			Filename: part.FileName(),
+			Header:   part.Header,
+		},
+	}
+
+	var content io.Reader = part
+	validation := s.Validation
+	validation.MaxSize, validation.MinSize = 0, 0
+	validation.Scanner = nil
+	if validation.StrictTypeCheck {
+		buffered := bufio.NewReaderSize(part, sniffLen)
+		head, err := buffered.Peek(sniffLen)
+		if err != nil && err != io.EOF {
+			return nil, err
+		}
+		header := textproto.MIMEHeader{}
+		for key, values := range part.Header {
+			header[key] = values
+		}
+		header.Set("Content-Type", DetectContentType(head))
+		fileHeader.Header = header
+		validation.StrictTypeCheck = false
+		content = buffered
+	}
+	if err := Validate(fileHeader, validation.withoutImageChecks()); err != nil {
+		return nil, err
+	}
+	if validation.checksImage() {
+		var head bytes.Buffer
+		config, _, err := image.DecodeConfig(io.TeeReader(io.LimitReader(content, maxImageHeader), &head))
+		if err := checkImage(config, err, validation); err != nil {
+			return nil, err
+		}
+		content = io.MultiReader(&head, content)
+	}
+
+	name, err := s.name(fileHeader)
+	if err != nil {
+		return nil, err
+	}
+
+	var scan *streamScan
+	if s.Validation.Scanner != nil {
+		scan, content, err = startScan(s.Validation, fileHeader.Filename, content)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	reader := &checksumReader{r: content, hash: sha256.New(), max: int64(s.Validation.MaxSize)}
+	location, err := s.Storage.Save(name, reader)
+	if err != nil {
+		if !errors.Is(err, ErrCollision) {
+			s.Storage.Delete(name)
+		}
+		if scan != nil {
+			scan.abort(err)
+		}
+		if reader.err != nil {
+			return nil, reader.err
+		}
+		return nil, err
+	}
+
+	if scan != nil {
+		if err := scan.finish(); err != nil {
+			s.Storage.Delete(name)
+			return nil, err
+		}
+	}
+
+	if s.Validation.MinSize > 0 && reader.n < int64(s.Validation.MinSize) {
+		s.Storage.Delete(name)
+		return nil, Error{
+			Message: fmt.Sprintf("File size is below minimum required size of %d bytes", s.Validation.MinSize),
+			Code:    "min_size",
+		}
+	}
+
+	return &Result{
+		OriginalName: fileHeader.Filename,
+		Name:         name,
+		Location:     location,
+		Size:         reader.n,
+		ContentType:  fileContentType(fileHeader),
+		Checksum:     hex.EncodeToString(reader.hash.Sum(nil)),
+	}, nil
+}
+
+func (s *Stream) name(fileHeader *FileHeader) (string, error) {
+	namer := s.Namer
+	if namer == nil {
+		namer = TimestampNamer{}
+	}
+	attempts := s.MaxAttempts
+	if attempts <= 0 {
+		attempts = DefaultMaxAttempts
+	}
+
+	previous := ""
+	for attempt := 0; attempt < attempts; attempt++ {
+		var name string
+		var err error
+		if attemptNamer, ok := namer.(AttemptNamer); ok {
+			name, err = attemptNamer.NameAttempt(fileHeader, attempt)
+		} else {
+			name, err = namer.Name(fileHeader)
+		}
+		if err != nil {
+			return "", err
+		}
+		if name == previous {
+			break
+		}
+		previous = name
+
+		exists, err := s.Storage.Exists(name)
+		if err != nil {
+			return "", err
+		}
+		if !exists {
+			return name, nil
+		}
+	}
+
+	return "", fmt.Errorf("%w: %s", ErrCollision, previous)
+}
+
+type checksumReader struct {
+	r    io.Reader
+	hash hash.Hash
+	max  int64
+	n    int64
+	err  error
+}
+
+func (r *checksumReader) Read(p []byte) (int, error) {
+	if r.err != nil {
+		return 0, r.err
+	}
+
+	n, err := r.r.Read(p)
+	r.n += int64(n)
+	r.hash.Write(p[:n])
+
+	if r.max > 0 && r.n > r.max {
+		r.err = Error{
+			Message: fmt.Sprintf("File size exceeds maximum allowed size of %d bytes", r.max),
+			Code:    "max_size",
+		}
+		return n, r.err
+	}
+	if err != nil && err != io.EOF {
+		r.err = err
+	}
+	return n, err
+}
@@ -0,0 +1,288 @@
+package upload
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+)
+
+var (
+	ErrUploadNotFound = errors.New("upload: resumable upload not found")
+	ErrOffsetMismatch = errors.New("upload: offset does not match the current upload offset")
+	ErrUploadLocked   = errors.New("upload: resumable upload is being written by another request")
+	ErrSizeExceeded   = errors.New("upload: data exceeds the declared upload length")
+)
+
+type TusInfo struct {
+	ID        string            `json:"id"`
+	Size      int64             `json:"size"`
+	Offset    int64             `json:"offset"`
+	Metadata  map[string]string `json:"metadata,omitempty"`
+	CreatedAt time.Time         `json:"created_at"`
+	ExpiresAt time.Time         `json:"expires_at,omitempty"`
+}
+
+func (i *TusInfo) Complete() bool {
+	return i.Offset >= i.Size
+}
+
+func (i *TusInfo) Expired(now time.Time) bool {
+	return !i.Complete() && !i.ExpiresAt.IsZero() && !now.Before(i.ExpiresAt)
+}
+
+type TusStore interface {
+	Create(info TusInfo) (*TusInfo, error)
+	Info(id string) (*TusInfo, error)
+	Write(id string, offset int64, r io.Reader) (*TusInfo, error)
+	Open(id string) (io.ReadCloser, error)
+	Delete(id string) error
+}
+
+type TusDiskStore struct {
+	dir       string
+	mu        sync.Mutex
+	writing   map[string]bool
+	lastSweep time.Time
+}
+
+func NewTusDiskStore(dir string) (*TusDiskStore, error) {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, fmt.Errorf("failed to create upload directory: %v", err)
+	}
+	return &TusDiskStore{dir: dir, writing: make(map[string]bool), lastSweep: time.Now()}, nil
+}
+
+func (s *TusDiskStore) Path(id string) string {
+	return filepath.Join(s.dir, id+".bin")
+}
+
+func (s *TusDiskStore) infoPath(id string) string {
+	return filepath.Join(s.dir, id+".info")
+}
+
+func (s *TusDiskStore) Create(info TusInfo) (*TusInfo, error) {
+	if info.Size < 0 {
+		return nil, fmt.Errorf("upload length must not be negative")
+	}
+
+	var b [16]byte
+	if _, err := rand.Read(b[:]); err != nil {
+		return nil, fmt.Errorf("failed to generate upload id: %v", err)
+	}
+	info.ID = hex.EncodeToString(b[:])
+	info.Offset = 0
+	if info.CreatedAt.IsZero() {
+		info.CreatedAt = time.Now()
+	}
+
+	s.sweep()
+
+	file, err := os.OpenFile(s.Path(info.ID), os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0644)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create upload file: %v", err)
+	}
+	file.Close()
+
+	if err := s.saveInfo(&info); err != nil {
+		os.Remove(s.Path(info.ID))
+		return nil, err
+	}
+	return &info, nil
+}
+
+func (s *TusDiskStore) Info(id string) (*TusInfo, error) {
+	if !validUploadID(id) {
+		return nil, ErrUploadNotFound
+	}
+
+	data, err := os.ReadFile(s.infoPath(id))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, ErrUploadNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var info TusInfo
+	if err := json.Unmarshal(data, &info); err != nil {
+		return nil, ErrUploadNotFound
+	}
+	if info.Expired(time.Now()) {
+		return nil, ErrUploadNotFound
+	}
+	return &info, nil
+}
+
+func (s *TusDiskStore) Write(id string, offset int64, r io.Reader) (*TusInfo, error) {
+	if !s.lock(id) {
+		return nil, ErrUploadLocked
+	}
+	defer s.unlock(id)
+
+	info, err := s.Info(id)
+	if err != nil {
+		return nil, err
+	}
+	if offset != info.Offset {
+		return info, ErrOffsetMismatch
+	}
+
+	file, err := os.OpenFile(s.Path(id), os.O_WRONLY, 0644)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open upload file: %v", err)
+	}
+	defer file.Close()
+
+	if err := file.Truncate(offset); err != nil {
+		return nil, fmt.Errorf("failed to prepare upload file: %v", err)
+	}
+	if _, err := file.Seek(offset, io.SeekStart); err != nil {
+		return nil, fmt.Errorf("failed to prepare upload file: %v", err)
+	}
+
+	n, copyErr := io.Copy(file, io.LimitReader(r, info.Size-offset))
+	info.Offset += n
+	if err := s.saveInfo(info); err != nil {
+		return nil, err
+	}
+	if copyErr != nil {
+		return info, copyErr
+	}
+
+	if info.Complete() {
+		var extra [1]byte
+		if n, _ := r.Read(extra[:]); n > 0 {
+			return info, ErrSizeExceeded
+		}
+	}
+	return info, nil
+}
+
+func (s *TusDiskStore) Open(id string) (io.ReadCloser, error) {
+	if _, err := s.Info(id); err != nil {
+		return nil, err
+	}
+	return os.Open(s.Path(id))
+}
+
+func (s *TusDiskStore) Delete(id string) error {
+	if !validUploadID(id) {
+		return ErrUploadNotFound
+	}
+
+	err := os.Remove(s.infoPath(id))
+	if errors.Is(err, os.ErrNotExist) {
+		return ErrUploadNotFound
+	}
+	if err != nil {
+		return err
+	}
+	if err := os.Remove(s.Path(id)); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return err
+	}
+	return nil
+}
+
+func (s *TusDiskStore) Cleanup() (int, error) {
+	entries, err := os.ReadDir(s.dir)
+	if err != nil {
+		return 0, err
+	}
+
+	removed := 0
+	now := time.Now()
+	for _, entry := range entries {
+		id, ok := strings.CutSuffix(entry.Name(), ".info")
+		if !ok || !validUploadID(id) {
+			continue
+		}
+
+		data, err := os.ReadFile(s.infoPath(id))
+		if err != nil {
+			continue
+		}
+		var info TusInfo
+		if err := json.Unmarshal(data, &info); err != nil || !info.Expired(now) {
+			continue
+		}
+		if !s.lock(id) {
+			continue
+		}
+		if err := s.Delete(id); err == nil {
+			removed++
+		}
+		s.unlock(id)
+	}
+	return removed, nil
+}
+
+func (s *TusDiskStore) sweep() {
+	s.mu.Lock()
+	due := time.Since(s.lastSweep) > time.Minute
+	if due {
+		s.lastSweep = time.Now()
+	}
+	s.mu.Unlock()
+
+	if due {
+		s.Cleanup()
+	}
+}
+
+func (s *TusDiskStore) saveInfo(info *TusInfo) error {
+	data, err := json.Marshal(info)
+	if err != nil {
+		return err
+	}
+
+	tmp, err := os.CreateTemp(s.dir, ".tus-*")
+	if err != nil {
+		return fmt.Errorf("failed to save upload info: %v", err)
+	}
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		os.Remove(tmp.Name())
+		return fmt.Errorf("failed to save upload info: %v", err)
+	}
+	if err := tmp.Close(); err != nil {
+		os.Remove(tmp.Name())
+		return fmt.Errorf("failed to save upload info: %v", err)
+	}
+	if err := os.Rename(tmp.Name(), s.infoPath(info.ID)); err != nil {
+		os.Remove(tmp.Name())
+		return fmt.Errorf("failed to save upload info: %v", err)
+	}
+	return nil
+}
+
+func (s *TusDiskStore) lock(id string) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.writing[id] {
+		return false
+	}
+	s.writing[id] = true
+	return true
+}
+
+func (s *TusDiskStore) unlock(id string) {
+	s.mu.Lock()
+	delete(s.writing, id)
+	s.mu.Unlock()
+}
+
+func validUploadID(id string) bool {
+	if len(id) != 32 {
+		return false
+	}
+	_, err := hex.DecodeString(id)
+	return err == nil
+}
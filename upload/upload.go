@@ -0,0 +1,222 @@
+package upload
+
+import (
+	"fmt"
+	"io"
+	"mime/multipart"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+type FileHeader struct {
+	*multipart.FileHeader
+	File multipart.File
+}
+
+func (fh *FileHeader) Close() error {
+	if fh == nil || fh.File == nil {
+		return nil
+	}
+	err := fh.File.Close()
+	fh.File = nil
+	return err
+}
+
+type Validation struct {
+	MaxSize	Size
+	MinSize	Size
+	AllowedTypes []string
+	AllowedExts	[]string
+	Required bool
+	StrictTypeCheck bool
+	MinWidth int
+	MaxWidth int
+	MinHeight int
+	MaxHeight int
+	MinAspectRatio float64
+	MaxAspectRatio float64
+	Scanner Scanner
+	ScanAction string
+	QuarantineDir string
+}
+
+type Error	struct {
+	Field string `json:"field"`
+	Message string `json:"message"`
+	Code	string `json:"code"`
+}
+
+func (fue Error) Error() string {
+	return fue.Message
+}
+
+type Errors []Error
+
+func (fues Errors) Error() string {
+	var messages []string
+
+	for _, err := range fues {
+		messages = append(messages, err.Message)
+	}
+
+	return strings.Join(messages, "; ")
+}
+
+func Validate(fileHeader *FileHeader, validation Validation) error {
+	if fileHeader == nil {
+		if validation.Required {
+			return Error{
+				Message: "File is required",
+				Code:    "required",
+			}
+		}
+		return nil
+	}
+
+	if validation.MaxSize > 0 && fileHeader.Size > int64(validation.MaxSize) {
+		return Error{
+			Message: fmt.Sprintf("File size exceeds maximum allowed size of %s", validation.MaxSize),
+			Code:    "max_size",
+		}
+	}
+
+	if validation.MinSize > 0 && fileHeader.Size < int64(validation.MinSize) {
+		return Error{
+			Message: fmt.Sprintf("File size is below minimum required size of %s", validation.MinSize),
+			Code:    "min_size",
+		}
+	}
+
+	if len(validation.AllowedTypes) > 0 {
+		contentType := fileContentType(fileHeader)
+		if validation.StrictTypeCheck {
+			sniffed, err := SniffContentType(fileHeader)
+			if err != nil {
+				return Error{
+					Message: fmt.Sprintf("Failed to detect file type: %v", err),
+					Code:    "invalid_type",
+				}
+			}
+			contentType = sniffed
+		}
+		if err := checkType(contentType, validation.AllowedTypes); err != nil {
+			return err
+		}
+	}
+
+	if len(validation.AllowedExts) > 0 {
+		ext := strings.ToLower(filepath.Ext(fileHeader.Filename))
+		allowed := false
+		for _, allowedExt := range validation.AllowedExts {
+			if ext == strings.ToLower(allowedExt) {
+				allowed = true
+				break
+			}
+		}
+		if !allowed {
+			return Error{
+				Message: fmt.Sprintf("File extension '%s' is not allowed. Allowed extensions: %v", 
+					ext, validation.AllowedExts),
+				Code: "invalid_extension",
+			}
+		}
+	}
+
+	if validation.checksImage() {
+		config, _, err := ImageConfig(fileHeader)
+		if err := checkImage(config, err, validation); err != nil {
+			return err
+		}
+	}
+
+	if validation.Scanner != nil {
+		if err := scanFile(fileHeader, validation); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func SaveFile(fileHeader *FileHeader, dst string) error {
+	if fileHeader == nil {
+		return fmt.Errorf("file header is nil")
+	}
+
+	if err := os.MkdirAll(filepath.Dir(dst), 0755); err != nil {
+		return fmt.Errorf("failed to create destination directory: %v", err)
+	}
+
+	out, err := os.Create(dst)
+	if err != nil {
+		return fmt.Errorf("failed to create destination file: %v", err)
+	}
+	defer out.Close()
+
+	_, err = io.Copy(out, fileHeader.File)
+	if err != nil {
+		return fmt.Errorf("failed to copy file content: %v", err)
+	}
+
+	return nil
+}
+
+func SaveFileWithName(fileHeader *FileHeader, dir, filename string) error {
+	dst, err := SecureJoin(dir, filename)
+	if err != nil {
+		return err
+	}
+	return SaveFile(fileHeader, dst)
+}
+
+func GenerateUniqueFilename(originalName string) string {
+	name := sanitizeFilename(originalName, maxFilenameLength-20)
+	ext := filepath.Ext(name)
+	base := strings.TrimSuffix(name, ext)
+
+	timestamp := fmt.Sprintf("%d", GetCurrentTimestamp())
+	
+	return fmt.Sprintf("%s_%s%s", base, timestamp, ext)
+}
+
+func GetCurrentTimestamp() int64 {
+	return time.Now().UnixNano()
+}
+
+func GetFileSize(filename string) (int64, error) {
+	info, err := os.Stat(filename)
+	if err != nil {
+		return 0, err
+	}
+	return info.Size(), nil
+}
+
+func FileExists(filename string) bool {
+	_, err := os.Stat(filename)
+	return !os.IsNotExist(err)
+}
+
+func DeleteFile(filename string) error {
+	return os.Remove(filename)
+}
+
+func IsImageFile(mimeType string) bool {
+	imageTypes := []string{
+		"image/jpeg",
+		"image/jpg", 
+		"image/png",
+		"image/gif",
+		"image/webp",
+		"image/svg+xml",
+	}
+	
+	for _, imageType := range imageTypes {
+		if mimeType == imageType {
+			return true
+		}
+	}
+	
+	return false
+}
@@ -0,0 +1,322 @@
+package upload
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"image"
+	"image/color"
+	"image/draw"
+	"image/jpeg"
+	"image/png"
+	"io"
+	"math"
+	"path/filepath"
+	"strings"
+)
+
+const (
+	FitContain = "contain"
+	FitCover   = "cover"
+)
+
+const (
+	defaultMaxPixels   = 50_000_000
+	defaultJPEGQuality = 85
+)
+
+type ImageVariant struct {
+	Name    string
+	Width   int
+	Height  int
+	Fit     string
+	Format  string
+	Quality int
+}
+
+type VariantResult struct {
+	Variant     string `json:"variant"`
+	Name        string `json:"name"`
+	Location    string `json:"location"`
+	URL         string `json:"url,omitempty"`
+	Width       int    `json:"width"`
+	Height      int    `json:"height"`
+	Size        int64  `json:"size"`
+	ContentType string `json:"content_type"`
+}
+
+type ImageProcessor struct {
+	Variants  []ImageVariant
+	BaseURL   string
+	MaxPixels int
+}
+
+func (p ImageProcessor) PostProcess(fileHeader *FileHeader, result *Result, storage Storage) error {
+	if p.BaseURL != "" {
+		result.URL = joinURL(p.BaseURL, result.Name)
+	}
+	if len(p.Variants) == 0 {
+		return nil
+	}
+
+	for _, variant := range p.Variants {
+		if variant.Name == "" || SanitizeFilename(variant.Name) != variant.Name {
+			return fmt.Errorf("invalid image variant name %q", variant.Name)
+		}
+	}
+
+	img, format, err := p.decode(fileHeader)
+	if err != nil {
+		return err
+	}
+
+	base := strings.TrimSuffix(result.Name, filepath.Ext(result.Name))
+	var variants []VariantResult
+	var created []string
+	for _, variant := range p.Variants {
+		data, rendered, err := variant.render(img, format)
+		if err != nil {
+			deleteAll(storage, created)
+			return err
+		}
+
+		name := base + "_" + variant.Name + imageExtensions[rendered.format]
+		location, err := storage.Save(name, bytes.NewReader(data))
+		switch {
+		case err == nil:
+			created = append(created, name)
+		case errors.Is(err, ErrCollision) && result.Duplicate:
+			location = locate(storage, name)
+		default:
+			deleteAll(storage, created)
+			return err
+		}
+
+		variantResult := VariantResult{
+			Variant:     variant.Name,
+			Name:        name,
+			Location:    location,
+			Width:       rendered.width,
+			Height:      rendered.height,
+			Size:        int64(len(data)),
+			ContentType: "image/" + rendered.format,
+		}
+		if p.BaseURL != "" {
+			variantResult.URL = joinURL(p.BaseURL, name)
+		}
+		variants = append(variants, variantResult)
+	}
+
+	result.Variants = variants
+	return nil
+}
+
+func (p ImageProcessor) decode(fileHeader *FileHeader) (*image.RGBA, string, error) {
+	if fileHeader.File != nil {
+		if err := rewind(fileHeader.File); err != nil {
+			return nil, "", err
+		}
+	}
+
+	var data []byte
+	err := readContent(fileHeader, func(r io.Reader) error {
+		var err error
+		data, err = io.ReadAll(r)
+		return err
+	})
+	if err != nil {
+		return nil, "", err
+	}
+
+	maxPixels := p.MaxPixels
+	if maxPixels <= 0 {
+		maxPixels = defaultMaxPixels
+	}
+	config, _, err := image.DecodeConfig(bytes.NewReader(data))
+	if err != nil {
+		return nil, "", checkImage(config, err, Validation{})
+	}
+	if config.Width*config.Height > maxPixels {
+		return nil, "", Error{
+			Message: fmt.Sprintf("Image of %dx%d exceeds the processing limit of %d pixels", config.Width, config.Height, maxPixels),
+			Code:    "image_too_large",
+		}
+	}
+
+	src, format, err := image.Decode(bytes.NewReader(data))
+	if err != nil {
+		return nil, "", checkImage(config, err, Validation{})
+	}
+
+	img := image.NewRGBA(image.Rect(0, 0, src.Bounds().Dx(), src.Bounds().Dy()))
+	draw.Draw(img, img.Bounds(), src, src.Bounds().Min, draw.Src)
+	if format == "jpeg" {
+		img = orient(img, jpegOrientation(data))
+	}
+	return img, format, nil
+}
+
+type renderedImage struct {
+	format        string
+	width, height int
+}
+
+func (v ImageVariant) render(img *image.RGBA, sourceFormat string) ([]byte, renderedImage, error) {
+	format := v.Format
+	if format == "" {
+		format = sourceFormat
+		if _, ok := imageExtensions[format]; !ok {
+			format = "png"
+		}
+	}
+	if format == "jpg" {
+		format = "jpeg"
+	}
+	if _, ok := imageExtensions[format]; !ok {
+		return nil, renderedImage{}, fmt.Errorf("unsupported image variant format %q", v.Format)
+	}
+
+	crop, width, height := v.size(img.Bounds().Dx(), img.Bounds().Dy())
+	out := resizeImage(img.SubImage(crop).(*image.RGBA), width, height)
+
+	var buf bytes.Buffer
+	var err error
+	switch format {
+	case "jpeg":
+		quality := v.Quality
+		if quality <= 0 {
+			quality = defaultJPEGQuality
+		}
+		opaque := image.NewRGBA(out.Bounds())
+		draw.Draw(opaque, opaque.Bounds(), image.NewUniform(color.White), image.Point{}, draw.Src)
+		draw.Draw(opaque, opaque.Bounds(), out, out.Bounds().Min, draw.Over)
+		err = jpeg.Encode(&buf, opaque, &jpeg.Options{Quality: quality})
+	case "png":
+		err = png.Encode(&buf, out)
+	}
+	if err != nil {
+		return nil, renderedImage{}, fmt.Errorf("failed to encode image variant %q: %v", v.Name, err)
+	}
+	return buf.Bytes(), renderedImage{format: format, width: width, height: height}, nil
+}
+
+func (v ImageVariant) size(width, height int) (image.Rectangle, int, int) {
+	full := image.Rect(0, 0, width, height)
+	if v.Width <= 0 && v.Height <= 0 {
+		return full, width, height
+	}
+
+	if v.Fit == FitCover && v.Width > 0 && v.Height > 0 {
+		scale := math.Min(1, math.Max(float64(v.Width)/float64(width), float64(v.Height)/float64(height)))
+		cropWidth := min(width, int(math.Round(float64(v.Width)/scale)))
+		cropHeight := min(height, int(math.Round(float64(v.Height)/scale)))
+		x := (width - cropWidth) / 2
+		y := (height - cropHeight) / 2
+		return image.Rect(x, y, x+cropWidth, y+cropHeight), scaled(cropWidth, scale), scaled(cropHeight, scale)
+	}
+
+	scale := 1.0
+	if v.Width > 0 {
+		scale = math.Min(scale, float64(v.Width)/float64(width))
+	}
+	if v.Height > 0 {
+		scale = math.Min(scale, float64(v.Height)/float64(height))
+	}
+	return full, scaled(width, scale), scaled(height, scale)
+}
+
+func scaled(size int, scale float64) int {
+	return max(1, int(math.Round(float64(size)*scale)))
+}
+
+func resizeImage(src *image.RGBA, width, height int) *image.RGBA {
+	bounds := src.Bounds()
+	srcWidth, srcHeight := bounds.Dx(), bounds.Dy()
+	dst := image.NewRGBA(image.Rect(0, 0, width, height))
+
+	for y := 0; y < height; y++ {
+		y0 := y * srcHeight / height
+		y1 := max(y0+1, (y+1)*srcHeight/height)
+		for x := 0; x < width; x++ {
+			x0 := x * srcWidth / width
+			x1 := max(x0+1, (x+1)*srcWidth/width)
+
+			var sum [4]int
+			for sy := y0; sy < y1; sy++ {
+				offset := src.PixOffset(bounds.Min.X+x0, bounds.Min.Y+sy)
+				for sx := x0; sx < x1; sx++ {
+					for c := 0; c < 4; c++ {
+						sum[c] += int(src.Pix[offset+c])
+					}
+					offset += 4
+				}
+			}
+
+			count := (y1 - y0) * (x1 - x0)
+			offset := dst.PixOffset(x, y)
+			for c := 0; c < 4; c++ {
+				dst.Pix[offset+c] = uint8((sum[c] + count/2) / count)
+			}
+		}
+	}
+	return dst
+}
+
+func orient(img *image.RGBA, orientation int) *image.RGBA {
+	if orientation < 2 || orientation > 8 {
+		return img
+	}
+
+	width, height := img.Bounds().Dx(), img.Bounds().Dy()
+	dstWidth, dstHeight := width, height
+	if orientation >= 5 {
+		dstWidth, dstHeight = height, width
+	}
+
+	dst := image.NewRGBA(image.Rect(0, 0, dstWidth, dstHeight))
+	for y := 0; y < dstHeight; y++ {
+		for x := 0; x < dstWidth; x++ {
+			var sx, sy int
+			switch orientation {
+			case 2:
+				sx, sy = width-1-x, y
+			case 3:
+				sx, sy = width-1-x, height-1-y
+			case 4:
+				sx, sy = x, height-1-y
+			case 5:
+				sx, sy = y, x
+			case 6:
+				sx, sy = y, height-1-x
+			case 7:
+				sx, sy = width-1-y, height-1-x
+			case 8:
+				sx, sy = width-1-y, x
+			}
+			dst.SetRGBA(x, y, img.RGBAAt(sx, sy))
+		}
+	}
+	return dst
+}
+
+var imageExtensions = map[string]string{
+	"jpeg": ".jpg",
+	"png":  ".png",
+}
+
+func locate(storage Storage, name string) string {
+	if locator, ok := storage.(Locator); ok {
+		return locator.Location(name)
+	}
+	return name
+}
+
+func deleteAll(storage Storage, names []string) {
+	for _, name := range names {
+		storage.Delete(name)
+	}
+}
+
+func joinURL(base, name string) string {
+	return strings.TrimSuffix(base, "/") + "/" + name
+}
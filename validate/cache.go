@@ -0,0 +1,73 @@
+package validate
+
+import (
+	"reflect"
+	"strings"
+	"sync"
+)
+
+type fieldMeta struct {
+	index    int
+	name     string
+	embedded bool
+	nested   bool
+	items    bool
+	rules    Rules
+	message  string
+}
+
+type structMeta struct {
+	fields []fieldMeta
+	hook   bool
+}
+
+var (
+	structCache            sync.Map
+	validatableType        = reflect.TypeOf((*Validatable)(nil)).Elem()
+	contextValidatableType = reflect.TypeOf((*ContextValidatable)(nil)).Elem()
+)
+
+func cachedStruct(typ reflect.Type) *structMeta {
+	if cached, ok := structCache.Load(typ); ok {
+		return cached.(*structMeta)
+	}
+
+	meta := &structMeta{fields: make([]fieldMeta, 0, typ.NumField())}
+	for i := 0; i < typ.NumField(); i++ {
+		fieldType := typ.Field(i)
+		field := fieldMeta{index: i, name: fieldType.Name}
+
+		if fieldType.Anonymous && fieldType.Tag.Get("json") == "" && isStructOrPointer(fieldType.Type) {
+			field.embedded = true
+			meta.fields = append(meta.fields, field)
+			continue
+		}
+		if !fieldType.IsExported() {
+			continue
+		}
+
+		if jsonTag := fieldType.Tag.Get("json"); jsonTag != "" {
+			if tagName := strings.Split(jsonTag, ",")[0]; tagName != "" && tagName != "-" {
+				field.name = tagName
+			}
+		}
+
+		field.nested = structType(fieldType.Type) != nil
+		field.items = (fieldType.Type.Kind() == reflect.Slice || fieldType.Type.Kind() == reflect.Map) && isStructOrPointer(fieldType.Type.Elem())
+		if tag := fieldType.Tag.Get("validate"); tag != "" {
+			field.rules = ParseRules(tag)
+			field.message = fieldType.Tag.Get("message")
+		}
+		meta.fields = append(meta.fields, field)
+	}
+
+	ptr := reflect.PointerTo(typ)
+	meta.hook = ptr.Implements(validatableType) || ptr.Implements(contextValidatableType)
+
+	actual, _ := structCache.LoadOrStore(typ, meta)
+	return actual.(*structMeta)
+}
+
+func isStructOrPointer(typ reflect.Type) bool {
+	return typ.Kind() == reflect.Struct || (typ.Kind() == reflect.Ptr && typ.Elem().Kind() == reflect.Struct)
+}
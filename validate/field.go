@@ -0,0 +1,220 @@
+package validate
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"time"
+)
+
+type Field struct {
+	Name   string
+	Value  interface{}
+	Param  string
+	Parent reflect.Value
+}
+
+func (f Field) Sibling(name string) (reflect.Value, bool) {
+	parent := f.Parent
+	for parent.Kind() == reflect.Ptr && !parent.IsNil() {
+		parent = parent.Elem()
+	}
+	if parent.Kind() != reflect.Struct {
+		return reflect.Value{}, false
+	}
+
+	typ := parent.Type()
+	if field, ok := typ.FieldByName(name); ok && field.IsExported() {
+		return parent.FieldByIndex(field.Index), true
+	}
+	for i := 0; i < typ.NumField(); i++ {
+		field := typ.Field(i)
+		if field.IsExported() && strings.Split(field.Tag.Get("json"), ",")[0] == name {
+			return parent.Field(i), true
+		}
+	}
+	return reflect.Value{}, false
+}
+
+var timeType = reflect.TypeOf(time.Time{})
+
+func (v *Validator) registerFieldValidators() {
+	comparisons := map[string]struct {
+		check   func(cmp int) bool
+		message string
+	}{
+		"eqfield":  {func(cmp int) bool { return cmp == 0 }, "field must be equal to %s"},
+		"nefield":  {func(cmp int) bool { return cmp != 0 }, "field must not be equal to %s"},
+		"gtfield":  {func(cmp int) bool { return cmp > 0 }, "field must be greater than %s"},
+		"gtefield": {func(cmp int) bool { return cmp >= 0 }, "field must be greater than or equal to %s"},
+		"ltfield":  {func(cmp int) bool { return cmp < 0 }, "field must be less than %s"},
+		"ltefield": {func(cmp int) bool { return cmp <= 0 }, "field must be less than or equal to %s"},
+	}
+
+	for tag, comparison := range comparisons {
+		tag, comparison := tag, comparison
+		v.RegisterFieldValidator(tag, func(field Field) error {
+			other, ok := field.Sibling(field.Param)
+			if !ok {
+				return fmt.Errorf("%s: unknown field %s", tag, field.Param)
+			}
+
+			cmp, ok := compareValues(reflect.ValueOf(field.Value), other, tag == "eqfield" || tag == "nefield")
+			if !ok {
+				return nil
+			}
+			if !comparison.check(cmp) {
+				return fmt.Errorf(comparison.message, field.Param)
+			}
+			return nil
+		})
+	}
+}
+
+func (v *Validator) registerConditionalValidators() {
+	v.RegisterFieldValidator("required_if", func(field Field) error {
+		if conditionMatches(field, field.Param) && isEmpty(field.Value) {
+			return fmt.Errorf("field is required when %s", describeCondition(field.Param))
+		}
+		return nil
+	})
+
+	v.RegisterFieldValidator("required_unless", func(field Field) error {
+		if !conditionMatches(field, field.Param) && isEmpty(field.Value) {
+			return fmt.Errorf("field is required unless %s", describeCondition(field.Param))
+		}
+		return nil
+	})
+
+	v.RegisterFieldValidator("required_with", func(field Field) error {
+		for _, name := range strings.Fields(field.Param) {
+			if sibling, ok := field.Sibling(name); ok && !isZeroValue(sibling) && isEmpty(field.Value) {
+				return fmt.Errorf("field is required when %s is present", name)
+			}
+		}
+		return nil
+	})
+
+	v.RegisterFieldValidator("required_without", func(field Field) error {
+		for _, name := range strings.Fields(field.Param) {
+			if sibling, ok := field.Sibling(name); ok && isZeroValue(sibling) && isEmpty(field.Value) {
+				return fmt.Errorf("field is required when %s is missing", name)
+			}
+		}
+		return nil
+	})
+}
+
+func conditionMatches(field Field, param string) bool {
+	parts := strings.Fields(param)
+	if len(parts) == 0 || len(parts)%2 != 0 {
+		return false
+	}
+
+	for i := 0; i < len(parts); i += 2 {
+		sibling, ok := field.Sibling(parts[i])
+		if !ok {
+			return false
+		}
+		for sibling.Kind() == reflect.Ptr || sibling.Kind() == reflect.Interface {
+			if sibling.IsNil() {
+				return false
+			}
+			sibling = sibling.Elem()
+		}
+		if !sibling.CanInterface() || fmt.Sprint(sibling.Interface()) != parts[i+1] {
+			return false
+		}
+	}
+	return true
+}
+
+func describeCondition(param string) string {
+	parts := strings.Fields(param)
+	var conditions []string
+	for i := 0; i+1 < len(parts); i += 2 {
+		conditions = append(conditions, parts[i]+" is "+parts[i+1])
+	}
+	return strings.Join(conditions, " and ")
+}
+
+func isZeroValue(v reflect.Value) bool {
+	if !v.IsValid() || v.IsZero() {
+		return true
+	}
+	switch v.Kind() {
+	case reflect.Slice, reflect.Map:
+		return v.Len() == 0
+	}
+	return false
+}
+
+func compareValues(a, b reflect.Value, equality bool) (int, bool) {
+	for a.Kind() == reflect.Ptr || a.Kind() == reflect.Interface {
+		if a.IsNil() {
+			break
+		}
+		a = a.Elem()
+	}
+	for b.Kind() == reflect.Ptr || b.Kind() == reflect.Interface {
+		if b.IsNil() {
+			break
+		}
+		b = b.Elem()
+	}
+	if !a.IsValid() || !b.IsValid() {
+		return 0, false
+	}
+
+	aNil := (a.Kind() == reflect.Ptr || a.Kind() == reflect.Interface) && a.IsNil()
+	bNil := (b.Kind() == reflect.Ptr || b.Kind() == reflect.Interface) && b.IsNil()
+	if aNil || bNil {
+		if equality && aNil && bNil {
+			return 0, true
+		}
+		if equality {
+			return 1, true
+		}
+		return 0, false
+	}
+
+	if a.Type() == timeType && b.Type() == timeType {
+		return a.Interface().(time.Time).Compare(b.Interface().(time.Time)), true
+	}
+
+	if x, ok := numericValue(a); ok {
+		if y, ok := numericValue(b); ok {
+			switch {
+			case x < y:
+				return -1, true
+			case x > y:
+				return 1, true
+			}
+			return 0, true
+		}
+	}
+
+	if a.Kind() == reflect.String && b.Kind() == reflect.String {
+		return strings.Compare(a.String(), b.String()), true
+	}
+
+	if equality && a.Type() == b.Type() && a.CanInterface() && b.CanInterface() {
+		if reflect.DeepEqual(a.Interface(), b.Interface()) {
+			return 0, true
+		}
+		return 1, true
+	}
+	return 0, false
+}
+
+func numericValue(v reflect.Value) (float64, bool) {
+	switch v.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return float64(v.Int()), true
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return float64(v.Uint()), true
+	case reflect.Float32, reflect.Float64:
+		return v.Float(), true
+	}
+	return 0, false
+}
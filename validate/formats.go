@@ -0,0 +1,172 @@
+package validate
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+)
+
+var uuidRegex = regexp.MustCompile(`^[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12}$`)
+
+var patterns sync.Map
+
+type compiledPattern struct {
+	re  *regexp.Regexp
+	err error
+}
+
+func compilePattern(pattern string) (*regexp.Regexp, error) {
+	if cached, ok := patterns.Load(pattern); ok {
+		compiled := cached.(compiledPattern)
+		return compiled.re, compiled.err
+	}
+
+	re, err := regexp.Compile(pattern)
+	patterns.Store(pattern, compiledPattern{re: re, err: err})
+	return re, err
+}
+
+func (v *Validator) registerFormatValidators() {
+	v.RegisterValidator("regexp", func(value interface{}, param string) error {
+		str, ok := value.(string)
+		if !ok {
+			return fmt.Errorf("regexp validation only works on strings")
+		}
+		re, err := compilePattern(param)
+		if err != nil {
+			return fmt.Errorf("invalid regexp parameter: %s", param)
+		}
+		if !re.MatchString(str) {
+			return fmt.Errorf("field does not match pattern %s", param)
+		}
+		return nil
+	})
+
+	v.RegisterValidator("uuid", func(value interface{}, param string) error {
+		str, ok := value.(string)
+		if !ok {
+			return fmt.Errorf("uuid validation only works on strings")
+		}
+		if !uuidRegex.MatchString(str) {
+			return fmt.Errorf("invalid UUID format")
+		}
+		if param != "" && (len(param) != 1 || str[14] != param[0]) {
+			return fmt.Errorf("UUID must be version %s", param)
+		}
+		return nil
+	})
+
+	v.RegisterValidator("ip", func(value interface{}, param string) error {
+		str, ok := value.(string)
+		if !ok {
+			return fmt.Errorf("ip validation only works on strings")
+		}
+		if net.ParseIP(str) == nil {
+			return fmt.Errorf("invalid IP address")
+		}
+		return nil
+	})
+
+	v.RegisterValidator("ipv4", func(value interface{}, param string) error {
+		str, ok := value.(string)
+		if !ok {
+			return fmt.Errorf("ipv4 validation only works on strings")
+		}
+		if ip := net.ParseIP(str); ip == nil || ip.To4() == nil || strings.Contains(str, ":") {
+			return fmt.Errorf("invalid IPv4 address")
+		}
+		return nil
+	})
+
+	v.RegisterValidator("ipv6", func(value interface{}, param string) error {
+		str, ok := value.(string)
+		if !ok {
+			return fmt.Errorf("ipv6 validation only works on strings")
+		}
+		if net.ParseIP(str) == nil || !strings.Contains(str, ":") {
+			return fmt.Errorf("invalid IPv6 address")
+		}
+		return nil
+	})
+
+	v.RegisterValidator("cidr", func(value interface{}, param string) error {
+		str, ok := value.(string)
+		if !ok {
+			return fmt.Errorf("cidr validation only works on strings")
+		}
+		if _, _, err := net.ParseCIDR(str); err != nil {
+			return fmt.Errorf("invalid CIDR notation")
+		}
+		return nil
+	})
+
+	v.RegisterValidator("date", func(value interface{}, param string) error {
+		str, ok := value.(string)
+		if !ok {
+			return fmt.Errorf("date validation only works on strings")
+		}
+		if _, err := time.Parse("2006-01-02", str); err != nil {
+			return fmt.Errorf("invalid date, expected YYYY-MM-DD")
+		}
+		return nil
+	})
+
+	v.RegisterValidator("datetime", func(value interface{}, param string) error {
+		str, ok := value.(string)
+		if !ok {
+			return fmt.Errorf("datetime validation only works on strings")
+		}
+		layout := param
+		if layout == "" {
+			layout = time.RFC3339
+		}
+		if _, err := time.Parse(layout, str); err != nil {
+			return fmt.Errorf("invalid datetime, expected format %s", layout)
+		}
+		return nil
+	})
+
+	v.RegisterValidator("json", func(value interface{}, param string) error {
+		var data []byte
+		switch val := value.(type) {
+		case string:
+			data = []byte(val)
+		case []byte:
+			data = val
+		case json.RawMessage:
+			data = val
+		default:
+			return fmt.Errorf("json validation only works on strings")
+		}
+		if !json.Valid(data) {
+			return fmt.Errorf("invalid JSON")
+		}
+		return nil
+	})
+
+	v.RegisterValidator("base64", func(value interface{}, param string) error {
+		str, ok := value.(string)
+		if !ok {
+			return fmt.Errorf("base64 validation only works on strings")
+		}
+
+		encoding := base64.StdEncoding
+		switch param {
+		case "url":
+			encoding = base64.URLEncoding
+		case "raw":
+			encoding = base64.RawStdEncoding
+		case "rawurl":
+			encoding = base64.RawURLEncoding
+		}
+		if _, err := encoding.DecodeString(str); err != nil {
+			return fmt.Errorf("invalid base64 encoding")
+		}
+		return nil
+	})
+}
@@ -0,0 +1,70 @@
+package validate
+
+import (
+	"context"
+	"errors"
+	"reflect"
+)
+
+type Options struct {
+	Context context.Context
+	Locale  string
+}
+
+type Validatable interface {
+	Validate() error
+}
+
+type ContextValidatable interface {
+	ValidateStruct(ctx context.Context) error
+}
+
+func structErrors(val reflect.Value, prefix string, ctx context.Context) Errors {
+	var target interface{}
+	if val.CanAddr() && val.Addr().CanInterface() {
+		target = val.Addr().Interface()
+	} else if val.CanInterface() {
+		target = val.Interface()
+	} else {
+		return nil
+	}
+
+	var err error
+	switch hook := target.(type) {
+	case ContextValidatable:
+		err = hook.ValidateStruct(ctx)
+	case Validatable:
+		err = hook.Validate()
+	default:
+		return nil
+	}
+	if err == nil {
+		return nil
+	}
+
+	var list Errors
+	var single Error
+	var singlePtr *Error
+	switch {
+	case errors.As(err, &list):
+	case errors.As(err, &single):
+		list = Errors{single}
+	case errors.As(err, &singlePtr) && singlePtr != nil:
+		list = Errors{*singlePtr}
+	default:
+		return Errors{{Field: prefix, Tag: "struct", Message: err.Error()}}
+	}
+
+	result := make(Errors, 0, len(list))
+	for _, e := range list {
+		if prefix != "" {
+			if e.Field == "" {
+				e.Field = prefix
+			} else {
+				e.Field = prefix + "." + e.Field
+			}
+		}
+		result = append(result, e)
+	}
+	return result
+}
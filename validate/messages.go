@@ -0,0 +1,116 @@
+package validate
+
+import (
+	"reflect"
+	"strings"
+	"sync"
+)
+
+type messageCatalog struct {
+	mu       sync.RWMutex
+	messages map[string]map[string]string
+}
+
+var builtinMessages = map[string]map[string]string{
+	"ru": {
+		"required":         "обязательное поле",
+		"min.string":       "минимальная длина {param}",
+		"min.array":        "минимальное количество элементов {param}",
+		"min":              "минимальное значение {param}",
+		"max.string":       "максимальная длина {param}",
+		"max.array":        "максимальное количество элементов {param}",
+		"max":              "максимальное значение {param}",
+		"email":            "некорректный email",
+		"url":              "некорректный URL",
+		"alpha":            "поле должно содержать только буквы",
+		"alphanum":         "поле должно содержать только буквы и цифры",
+		"numeric":          "поле должно содержать только цифры",
+		"oneof":            "поле должно быть одним из: {param}",
+		"eqfield":          "значение должно совпадать с полем {param}",
+		"nefield":          "значение не должно совпадать с полем {param}",
+		"gtfield":          "значение должно быть больше поля {param}",
+		"gtefield":         "значение должно быть не меньше поля {param}",
+		"ltfield":          "значение должно быть меньше поля {param}",
+		"ltefield":         "значение должно быть не больше поля {param}",
+		"required_if":      "обязательное поле при условии {param}",
+		"required_unless":  "обязательное поле, кроме случая {param}",
+		"required_with":    "обязательное поле, если заполнено {param}",
+		"required_without": "обязательное поле, если не заполнено {param}",
+		"uuid":             "некорректный UUID",
+		"ip":               "некорректный IP-адрес",
+		"ipv4":             "некорректный IPv4-адрес",
+		"ipv6":             "некорректный IPv6-адрес",
+		"cidr":             "некорректная CIDR-подсеть",
+		"date":             "некорректная дата, ожидается ГГГГ-ММ-ДД",
+		"datetime":         "некорректные дата и время",
+		"json":             "некорректный JSON",
+		"base64":           "некорректная строка base64",
+		"regexp":           "значение не соответствует шаблону {param}",
+		"required_keys":    "отсутствуют обязательные ключи: {param}",
+	},
+}
+
+func (v *Validator) RegisterMessages(locale string, messages map[string]string) {
+	locale = strings.ToLower(strings.ReplaceAll(locale, "_", "-"))
+
+	v.catalog.mu.Lock()
+	defer v.catalog.mu.Unlock()
+	if v.catalog.messages == nil {
+		v.catalog.messages = make(map[string]map[string]string)
+	}
+	if v.catalog.messages[locale] == nil {
+		v.catalog.messages[locale] = make(map[string]string, len(messages))
+	}
+	for key, message := range messages {
+		v.catalog.messages[locale][key] = message
+	}
+}
+
+func RegisterMessages(locale string, messages map[string]string) {
+	Default.RegisterMessages(locale, messages)
+}
+
+func (v *Validator) message(locale, tag string, value interface{}) (string, bool) {
+	if locale == "" {
+		return "", false
+	}
+
+	locale = strings.ToLower(strings.ReplaceAll(locale, "_", "-"))
+	candidates := []string{locale}
+	if base, _, found := strings.Cut(locale, "-"); found {
+		candidates = append(candidates, base)
+	}
+	keys := []string{tag}
+	if kind := messageKind(value); kind != "" {
+		keys = []string{tag + "." + kind, tag}
+	}
+
+	v.catalog.mu.RLock()
+	defer v.catalog.mu.RUnlock()
+	for _, candidate := range candidates {
+		for _, key := range keys {
+			if message, ok := v.catalog.messages[candidate][key]; ok {
+				return message, true
+			}
+		}
+	}
+	return "", false
+}
+
+func messageKind(value interface{}) string {
+	if value == nil {
+		return ""
+	}
+
+	switch reflect.ValueOf(value).Kind() {
+	case reflect.String:
+		return "string"
+	case reflect.Slice, reflect.Array, reflect.Map:
+		return "array"
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64:
+		return "number"
+	}
+	return ""
+}
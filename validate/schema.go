@@ -0,0 +1,150 @@
+package validate
+
+import (
+	"reflect"
+	"strings"
+)
+
+type Schema struct {
+	Name   string        `json:"name"`
+	Fields []FieldSchema `json:"fields"`
+}
+
+type FieldSchema struct {
+	Name     string        `json:"name"`
+	Type     string        `json:"type"`
+	Required bool          `json:"required,omitempty"`
+	Rules    Rules         `json:"rules,omitempty"`
+	Fields   []FieldSchema `json:"fields,omitempty"`
+}
+
+func SchemaOf(v interface{}) Schema {
+	typ, ok := v.(reflect.Type)
+	if !ok {
+		typ = reflect.TypeOf(v)
+	}
+	for typ != nil && typ.Kind() == reflect.Ptr {
+		typ = typ.Elem()
+	}
+	if typ == nil || typ.Kind() != reflect.Struct {
+		return Schema{Fields: []FieldSchema{}}
+	}
+
+	return Schema{
+		Name:   typ.Name(),
+		Fields: structSchema(typ, make(map[reflect.Type]bool)),
+	}
+}
+
+func structSchema(typ reflect.Type, visiting map[reflect.Type]bool) []FieldSchema {
+	if visiting[typ] {
+		return nil
+	}
+	visiting[typ] = true
+	defer delete(visiting, typ)
+
+	fields := []FieldSchema{}
+	for i := 0; i < typ.NumField(); i++ {
+		fieldType := typ.Field(i)
+
+		if fieldType.Anonymous && fieldType.Tag.Get("json") == "" {
+			if embedded := structType(fieldType.Type); embedded != nil {
+				fields = append(fields, structSchema(embedded, visiting)...)
+				continue
+			}
+		}
+
+		if !fieldType.IsExported() {
+			continue
+		}
+
+		name := fieldType.Name
+		if jsonTag := fieldType.Tag.Get("json"); jsonTag != "" {
+			tagName := strings.Split(jsonTag, ",")[0]
+			if tagName == "-" {
+				continue
+			}
+			if tagName != "" {
+				name = tagName
+			}
+		}
+
+		rules := ParseRules(fieldType.Tag.Get("validate"))
+		field := FieldSchema{
+			Name:  name,
+			Type:  schemaType(fieldType.Type),
+			Rules: rules,
+		}
+		for _, rule := range rules {
+			if rule.Tag == "dive" {
+				break
+			}
+			if rule.Tag == "required" {
+				field.Required = true
+			}
+		}
+
+		if nested := structType(fieldType.Type); nested != nil {
+			field.Fields = structSchema(nested, visiting)
+		} else if elem := elemType(fieldType.Type); elem != nil {
+			if nested := structType(elem); nested != nil {
+				field.Fields = structSchema(nested, visiting)
+			}
+		}
+
+		fields = append(fields, field)
+	}
+	return fields
+}
+
+func structType(typ reflect.Type) reflect.Type {
+	if typ.Kind() == reflect.Ptr {
+		typ = typ.Elem()
+	}
+	if typ.Kind() != reflect.Struct || typ.PkgPath() == "time" {
+		return nil
+	}
+	return typ
+}
+
+func elemType(typ reflect.Type) reflect.Type {
+	if typ.Kind() == reflect.Ptr {
+		typ = typ.Elem()
+	}
+	if typ.Kind() == reflect.Slice || typ.Kind() == reflect.Array || typ.Kind() == reflect.Map {
+		return typ.Elem()
+	}
+	return nil
+}
+
+func schemaType(typ reflect.Type) string {
+	if typ.Kind() == reflect.Ptr {
+		typ = typ.Elem()
+	}
+
+	switch typ.Kind() {
+	case reflect.String:
+		return "string"
+	case reflect.Bool:
+		return "boolean"
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return "integer"
+	case reflect.Float32, reflect.Float64:
+		return "number"
+	case reflect.Slice, reflect.Array:
+		if typ.Elem().Kind() == reflect.Uint8 {
+			return "string"
+		}
+		return "array"
+	case reflect.Map:
+		return "object"
+	case reflect.Struct:
+		if typ.PkgPath() == "time" && typ.Name() == "Time" {
+			return "string"
+		}
+		return "object"
+	default:
+		return "any"
+	}
+}
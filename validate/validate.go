@@ -0,0 +1,551 @@
+package validate
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+type Validator struct {
+	validators map[string]FieldFunc
+	catalog    messageCatalog
+}
+
+type Func func(value interface{}, param string) error
+
+type FieldFunc func(field Field) error
+
+type Rule struct {
+	Tag     string `json:"tag"`
+	Param   string `json:"param,omitempty"`
+	Message string `json:"message,omitempty"`
+}
+
+type Rules []Rule
+
+type Error struct {
+	Field   string `json:"field"`
+	Value   interface{} `json:"value"`
+	Tag     string `json:"tag"`
+	Param   string `json:"param,omitempty"`
+	Message string `json:"message"`
+}
+
+type Errors []Error
+
+func (e Error) Error() string {
+	return e.Message
+}
+
+func (ve Errors) Error() string {
+	var messages []string
+	for _, err := range ve {
+		messages = append(messages, err.Message)
+	}
+	return strings.Join(messages, "; ")
+}
+
+func New() *Validator {
+	v := &Validator{
+		validators: make(map[string]FieldFunc),
+	}
+
+	v.registerBuiltinValidators()
+	v.registerFormatValidators()
+	v.registerFieldValidators()
+	v.registerConditionalValidators()
+	for locale, messages := range builtinMessages {
+		v.RegisterMessages(locale, messages)
+	}
+	
+	return v
+}
+
+var Default = New()
+
+func ParseRules(tag string) Rules {
+	var rules Rules
+	for _, rule := range splitUnescaped(tag, ',') {
+		rule, message := cutUnescaped(rule, '~')
+		rule = strings.TrimSpace(rule)
+		if rule == "" {
+			continue
+		}
+
+		parts := strings.SplitN(rule, "=", 2)
+		r := Rule{Tag: parts[0], Message: unescapeRule(strings.TrimSpace(message))}
+		if len(parts) > 1 {
+			r.Param = unescapeRule(parts[1])
+		}
+		rules = append(rules, r)
+	}
+	return rules
+}
+
+func splitUnescaped(s string, sep byte) []string {
+	var parts []string
+	start := 0
+	for i := 0; i < len(s); i++ {
+		switch s[i] {
+		case '\\':
+			i++
+		case sep:
+			parts = append(parts, s[start:i])
+			start = i + 1
+		}
+	}
+	return append(parts, s[start:])
+}
+
+func cutUnescaped(s string, sep byte) (string, string) {
+	for i := 0; i < len(s); i++ {
+		switch s[i] {
+		case '\\':
+			i++
+		case sep:
+			return s[:i], s[i+1:]
+		}
+	}
+	return s, ""
+}
+
+func unescapeRule(s string) string {
+	if !strings.Contains(s, "\\") {
+		return s
+	}
+	return strings.NewReplacer(`\,`, ",", `\~`, "~").Replace(s)
+}
+
+func (v *Validator) RegisterValidator(tag string, fn Func) {
+	v.validators[tag] = func(field Field) error {
+		return fn(field.Value, field.Param)
+	}
+}
+
+func Register(tag string, fn Func) {
+	Default.RegisterValidator(tag, fn)
+}
+
+func (v *Validator) RegisterFieldValidator(tag string, fn FieldFunc) {
+	v.validators[tag] = fn
+}
+
+func RegisterField(tag string, fn FieldFunc) {
+	Default.RegisterFieldValidator(tag, fn)
+}
+
+func (v *Validator) Validate(s interface{}) Errors {
+	return v.ValidateWith(s, Options{})
+}
+
+func Struct(s interface{}) Errors {
+	return Default.Validate(s)
+}
+
+func (v *Validator) ValidateLocale(s interface{}, locale string) Errors {
+	return v.ValidateWith(s, Options{Locale: locale})
+}
+
+func StructLocale(s interface{}, locale string) Errors {
+	return Default.ValidateLocale(s, locale)
+}
+
+func (v *Validator) ValidateWith(s interface{}, opts Options) Errors {
+	if opts.Context == nil {
+		opts.Context = context.Background()
+	}
+	return v.validateStruct(reflect.ValueOf(s), "", opts)
+}
+
+func StructWith(s interface{}, opts Options) Errors {
+	return Default.ValidateWith(s, opts)
+}
+
+func (v *Validator) Var(field string, value interface{}, tag string) Errors {
+	return v.validateRules(field, value, ParseRules(tag), reflect.Value{}, "", Options{})
+}
+
+func Var(field string, value interface{}, tag string) Errors {
+	return Default.Var(field, value, tag)
+}
+
+func (v *Validator) validateRules(field string, value interface{}, rules Rules, parent reflect.Value, fieldMessage string, opts Options) Errors {
+	var errors Errors
+	original := value
+	value = indirect(value)
+	for i, rule := range rules {
+		if rule.Tag == "dive" {
+			errors = append(errors, v.validateElements(field, value, rules[i+1:], parent, fieldMessage, opts)...)
+			break
+		}
+
+		if rule.Tag == "omitempty" {
+			if value == nil || isZeroValue(reflect.ValueOf(original)) {
+				break
+			}
+			continue
+		}
+
+		if value == nil && !presenceTags[rule.Tag] {
+			continue
+		}
+
+		validator, exists := v.validators[rule.Tag]
+		if !exists {
+			continue
+		}
+
+		if err := validator(Field{Name: field, Value: value, Param: rule.Param, Parent: parent}); err != nil {
+			message := err.Error()
+			if rule.Message != "" {
+				message = formatMessage(rule.Message, field, rule.Param)
+			} else if fieldMessage != "" {
+				message = formatMessage(fieldMessage, field, rule.Param)
+			} else if translated, ok := v.message(opts.Locale, rule.Tag, value); ok {
+				message = formatMessage(translated, field, rule.Param)
+			}
+
+			errors = append(errors, Error{
+				Field:   field,
+				Value:   value,
+				Tag:     rule.Tag,
+				Param:   rule.Param,
+				Message: message,
+			})
+		}
+	}
+	return errors
+}
+
+func (v *Validator) validateElements(field string, value interface{}, rules Rules, parent reflect.Value, fieldMessage string, opts Options) Errors {
+	rv := reflect.ValueOf(value)
+	for rv.Kind() == reflect.Ptr && !rv.IsNil() {
+		rv = rv.Elem()
+	}
+	if rv.Kind() == reflect.Map {
+		var errors Errors
+		for _, key := range sortedKeys(rv) {
+			item := rv.MapIndex(key)
+			if !item.CanInterface() {
+				continue
+			}
+			errors = append(errors, v.validateRules(fmt.Sprintf("%s[%v]", field, key.Interface()), item.Interface(), rules, parent, fieldMessage, opts)...)
+		}
+		return errors
+	}
+	if rv.Kind() != reflect.Slice && rv.Kind() != reflect.Array {
+		return nil
+	}
+
+	var errors Errors
+	for i := 0; i < rv.Len(); i++ {
+		item := rv.Index(i)
+		if !item.CanInterface() {
+			continue
+		}
+		errors = append(errors, v.validateRules(fmt.Sprintf("%s[%d]", field, i), item.Interface(), rules, parent, fieldMessage, opts)...)
+	}
+	return errors
+}
+
+func (v *Validator) validateStruct(val reflect.Value, prefix string, opts Options) Errors {
+	var errors Errors
+
+	if val.Kind() == reflect.Ptr {
+		if val.IsNil() {
+			return errors
+		}
+		val = val.Elem()
+	}
+	
+	if val.Kind() != reflect.Struct {
+		return errors
+	}
+	
+	meta := cachedStruct(val.Type())
+	for _, fieldMeta := range meta.fields {
+		field := val.Field(fieldMeta.index)
+
+		if fieldMeta.embedded {
+			errors = append(errors, v.validateStruct(field, prefix, opts)...)
+			continue
+		}
+
+		fieldName := fieldMeta.name
+		if prefix != "" {
+			fieldName = prefix + "." + fieldName
+		}
+
+		if fieldMeta.nested {
+			errors = append(errors, v.validateStruct(field, fieldName, opts)...)
+		}
+
+		if fieldMeta.items {
+			if field.Kind() == reflect.Map {
+				for _, key := range sortedKeys(field) {
+					keyFieldName := fmt.Sprintf("%s[%v]", fieldName, key.Interface())
+					errors = append(errors, v.validateStruct(field.MapIndex(key), keyFieldName, opts)...)
+				}
+			} else {
+				for j := 0; j < field.Len(); j++ {
+					indexFieldName := fmt.Sprintf("%s[%d]", fieldName, j)
+					errors = append(errors, v.validateStruct(field.Index(j), indexFieldName, opts)...)
+				}
+			}
+		}
+
+		if len(fieldMeta.rules) == 0 {
+			continue
+		}
+
+		errors = append(errors, v.validateRules(fieldName, field.Interface(), fieldMeta.rules, val, fieldMeta.message, opts)...)
+	}
+
+	if meta.hook {
+		errors = append(errors, structErrors(val, prefix, opts.Context)...)
+	}
+	return errors
+}
+
+func (v *Validator) registerBuiltinValidators() {
+	v.RegisterValidator("required", func(value interface{}, param string) error {
+		if isEmpty(value) {
+			return fmt.Errorf("field is required")
+		}
+		return nil
+	})
+
+	v.RegisterValidator("min", func(value interface{}, param string) error {
+		min, err := strconv.Atoi(param)
+		if err != nil {
+			return fmt.Errorf("invalid min parameter: %s", param)
+		}
+		
+		switch v := value.(type) {
+		case string:
+			if len(v) < min {
+				return fmt.Errorf("minimum length is %d", min)
+			}
+		case int, int8, int16, int32, int64:
+			val := reflect.ValueOf(v).Int()
+			if val < int64(min) {
+				return fmt.Errorf("minimum value is %d", min)
+			}
+		case uint, uint8, uint16, uint32, uint64:
+			val := reflect.ValueOf(v).Uint()
+			if val < uint64(min) {
+				return fmt.Errorf("minimum value is %d", min)
+			}
+		case float32, float64:
+			val := reflect.ValueOf(v).Float()
+			if val < float64(min) {
+				return fmt.Errorf("minimum value is %d", min)
+			}
+		default:
+			rv := reflect.ValueOf(v)
+			if rv.Kind() == reflect.Slice || rv.Kind() == reflect.Array || rv.Kind() == reflect.Map {
+				if rv.Len() < min {
+					return fmt.Errorf("minimum length is %d", min)
+				}
+			}
+		}
+		return nil
+	})
+
+	v.RegisterValidator("max", func(value interface{}, param string) error {
+		max, err := strconv.Atoi(param)
+		if err != nil {
+			return fmt.Errorf("invalid max parameter: %s", param)
+		}
+		
+		switch v := value.(type) {
+		case string:
+			if len(v) > max {
+				return fmt.Errorf("maximum length is %d", max)
+			}
+		case int, int8, int16, int32, int64:
+			val := reflect.ValueOf(v).Int()
+			if val > int64(max) {
+				return fmt.Errorf("maximum value is %d", max)
+			}
+		case uint, uint8, uint16, uint32, uint64:
+			val := reflect.ValueOf(v).Uint()
+			if val > uint64(max) {
+				return fmt.Errorf("maximum value is %d", max)
+			}
+		case float32, float64:
+			val := reflect.ValueOf(v).Float()
+			if val > float64(max) {
+				return fmt.Errorf("maximum value is %d", max)
+			}
+		default:
+			rv := reflect.ValueOf(v)
+			if rv.Kind() == reflect.Slice || rv.Kind() == reflect.Array || rv.Kind() == reflect.Map {
+				if rv.Len() > max {
+					return fmt.Errorf("maximum length is %d", max)
+				}
+			}
+		}
+		return nil
+	})
+
+	v.RegisterValidator("email", func(value interface{}, param string) error {
+		str, ok := value.(string)
+		if !ok {
+			return fmt.Errorf("email validation only works on strings")
+		}
+		
+		emailRegex := regexp.MustCompile(`^[a-zA-Z0-9._%+-]+@[a-zA-Z0-9.-]+\.[a-zA-Z]{2,}$`)
+		if !emailRegex.MatchString(str) {
+			return fmt.Errorf("invalid email format")
+		}
+		return nil
+	})
+
+	v.RegisterValidator("url", func(value interface{}, param string) error {
+		str, ok := value.(string)
+		if !ok {
+			return fmt.Errorf("url validation only works on strings")
+		}
+		
+		urlRegex := regexp.MustCompile(`^https?://[^\s/$.?#].[^\s]*$`)
+		if !urlRegex.MatchString(str) {
+			return fmt.Errorf("invalid URL format")
+		}
+		return nil
+	})
+
+	v.RegisterValidator("alpha", func(value interface{}, param string) error {
+		str, ok := value.(string)
+		if !ok {
+			return fmt.Errorf("alpha validation only works on strings")
+		}
+		
+		alphaRegex := regexp.MustCompile(`^[a-zA-Z]+$`)
+		if !alphaRegex.MatchString(str) {
+			return fmt.Errorf("field must contain only letters")
+		}
+		return nil
+	})
+	
+	// Alphanumeric validator
+	v.RegisterValidator("alphanum", func(value interface{}, param string) error {
+		str, ok := value.(string)
+		if !ok {
+			return fmt.Errorf("alphanum validation only works on strings")
+		}
+		
+		alphanumRegex := regexp.MustCompile(`^[a-zA-Z0-9]+$`)
+		if !alphanumRegex.MatchString(str) {
+			return fmt.Errorf("field must contain only letters and numbers")
+		}
+		return nil
+	})
+
+	v.RegisterValidator("numeric", func(value interface{}, param string) error {
+		str, ok := value.(string)
+		if !ok {
+			return fmt.Errorf("numeric validation only works on strings")
+		}
+		
+		numericRegex := regexp.MustCompile(`^[0-9]+$`)
+		if !numericRegex.MatchString(str) {
+			return fmt.Errorf("field must contain only numbers")
+		}
+		return nil
+	})
+
+	v.RegisterValidator("oneof", func(value interface{}, param string) error {
+		str := fmt.Sprintf("%v", value)
+		options := strings.Split(param, " ")
+		
+		for _, option := range options {
+			if str == option {
+				return nil
+			}
+		}
+		
+		return fmt.Errorf("field must be one of: %s", param)
+	})
+
+	v.RegisterValidator("required_keys", func(value interface{}, param string) error {
+		rv := reflect.ValueOf(value)
+		if rv.Kind() != reflect.Map {
+			return fmt.Errorf("required_keys validation only works on maps")
+		}
+
+		present := make(map[string]bool, rv.Len())
+		for _, key := range rv.MapKeys() {
+			present[fmt.Sprint(key.Interface())] = true
+		}
+		var missing []string
+		for _, key := range strings.Fields(param) {
+			if !present[key] {
+				missing = append(missing, key)
+			}
+		}
+		if len(missing) > 0 {
+			return fmt.Errorf("missing required keys: %s", strings.Join(missing, ", "))
+		}
+		return nil
+	})
+}
+
+func formatMessage(message, field, param string) string {
+	return strings.NewReplacer("{field}", field, "{param}", param).Replace(message)
+}
+
+func sortedKeys(rv reflect.Value) []reflect.Value {
+	keys := rv.MapKeys()
+	sort.Slice(keys, func(i, j int) bool {
+		return fmt.Sprint(keys[i].Interface()) < fmt.Sprint(keys[j].Interface())
+	})
+	return keys
+}
+
+var presenceTags = map[string]bool{
+	"required":         true,
+	"required_if":      true,
+	"required_unless":  true,
+	"required_with":    true,
+	"required_without": true,
+}
+
+func indirect(value interface{}) interface{} {
+	rv := reflect.ValueOf(value)
+	if rv.Kind() != reflect.Ptr {
+		return value
+	}
+	for rv.Kind() == reflect.Ptr {
+		if rv.IsNil() {
+			return nil
+		}
+		rv = rv.Elem()
+	}
+	if !rv.CanInterface() {
+		return value
+	}
+	return rv.Interface()
+}
+
+func isEmpty(value interface{}) bool {
+	if value == nil {
+		return true
+	}
+	
+	rv := reflect.ValueOf(value)
+	switch rv.Kind() {
+	case reflect.String:
+		return rv.String() == ""
+	case reflect.Slice, reflect.Map, reflect.Array:
+		return rv.Len() == 0
+	case reflect.Ptr, reflect.Interface:
+		return rv.IsNil()
+	default:
+		return false
+	}
+}
\ No newline at end of file
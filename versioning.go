@@ -0,0 +1,130 @@
+package goify
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"sort"
+)
+
+var ErrUnsupportedVersion = errors.New("goify: unsupported body version")
+
+type BodyVersionConfig struct {
+	Header  string
+	Query   string
+	Default string
+}
+
+func DefaultBodyVersionConfig() BodyVersionConfig {
+	return BodyVersionConfig{
+		Header: "X-API-Version",
+		Query:  "version",
+	}
+}
+
+type BodyVersions struct {
+	config   BodyVersionConfig
+	versions map[string]bodyVersion
+}
+
+type bodyVersion struct {
+	model   reflect.Type
+	convert func(interface{}) (interface{}, error)
+}
+
+func NewBodyVersions(config ...BodyVersionConfig) *BodyVersions {
+	cfg := DefaultBodyVersionConfig()
+	if len(config) > 0 {
+		cfg = config[0]
+	}
+
+	return &BodyVersions{
+		config:   cfg,
+		versions: make(map[string]bodyVersion),
+	}
+}
+
+func (bv *BodyVersions) Register(version string, model interface{}, convert func(interface{}) (interface{}, error)) *BodyVersions {
+	typ := reflect.TypeOf(model)
+	for typ != nil && typ.Kind() == reflect.Ptr {
+		typ = typ.Elem()
+	}
+	if typ == nil || typ.Kind() != reflect.Struct {
+		panic(fmt.Sprintf("goify: body version %q must be a struct", version))
+	}
+
+	bv.versions[version] = bodyVersion{model: typ, convert: convert}
+	return bv
+}
+
+func (bv *BodyVersions) Versions() []string {
+	versions := make([]string, 0, len(bv.versions))
+	for version := range bv.versions {
+		versions = append(versions, version)
+	}
+	sort.Strings(versions)
+	return versions
+}
+
+func (bv *BodyVersions) resolve(c *Context) string {
+	if bv.config.Header != "" {
+		if version := c.GetHeader(bv.config.Header); version != "" {
+			return version
+		}
+	}
+	if bv.config.Query != "" {
+		if version := c.Query(bv.config.Query); version != "" {
+			return version
+		}
+	}
+	return bv.config.Default
+}
+
+func (c *Context) BindVersion(versions *BodyVersions, obj interface{}) error {
+	version := versions.resolve(c)
+	entry, exists := versions.versions[version]
+	if !exists {
+		c.SendBadRequest("Unsupported API version", H{"version": version, "supported": versions.Versions()})
+		return ErrUnsupportedVersion
+	}
+	c.Set("bodyVersion", version)
+
+	if entry.convert == nil {
+		return c.Bind(obj)
+	}
+
+	model := reflect.New(entry.model)
+	if err := c.Bind(model.Interface()); err != nil {
+		return err
+	}
+
+	converted, err := entry.convert(model.Interface())
+	if err != nil {
+		c.SendBadRequest(err.Error())
+		return err
+	}
+
+	if err := assignConverted(obj, converted); err != nil {
+		c.SendInternalError()
+		return err
+	}
+	return nil
+}
+
+func assignConverted(obj, converted interface{}) error {
+	target := reflect.ValueOf(obj)
+	if target.Kind() != reflect.Ptr || target.IsNil() {
+		return fmt.Errorf("obj must be a non-nil pointer")
+	}
+
+	value := reflect.ValueOf(converted)
+	if value.Kind() == reflect.Ptr && value.Type().Elem() == target.Type().Elem() {
+		value = value.Elem()
+	}
+	if !value.IsValid() || !value.Type().AssignableTo(target.Type().Elem()) {
+		return fmt.Errorf("converter returned %T, expected %s", converted, target.Type().Elem())
+	}
+
+	target.Elem().Set(value)
+	return nil
+}
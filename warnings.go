@@ -0,0 +1,77 @@
+package goify
+
+import (
+	"fmt"
+	"mime/multipart"
+	"sync"
+)
+
+type warningState struct {
+	disabled bool
+	seen     sync.Map
+}
+
+func (rt *Router) DisableWarnings() {
+	rt.warnings.disabled = true
+}
+
+func (rt *Router) warnOnce(key, format string, args ...interface{}) {
+	if rt == nil || rt.warnings.disabled || rt.health.Environment() == "production" {
+		return
+	}
+
+	if _, loaded := rt.warnings.seen.LoadOrStore(key, struct{}{}); loaded {
+		return
+	}
+
+	rt.Logger().Warn(fmt.Sprintf(format, args...), "warning", key)
+}
+
+func (c *Context) warnRoute() string {
+	if c.fullPath == "" {
+		return c.Request.Method + " <unmatched>"
+	}
+	return c.Request.Method + " " + c.fullPath
+}
+
+func (c *Context) markBodyRead(method string) {
+	if c.bodyRead != "" {
+		c.router.warnOnce("body:"+c.warnRoute(), "request body for %s %s is read by %s after it was already consumed by %s; the second read sees an empty body", c.Request.Method, c.Request.URL.Path, method, c.bodyRead)
+		return
+	}
+	c.bodyRead = method
+}
+
+type trackedFile struct {
+	multipart.File
+	key    string
+	closed bool
+}
+
+func (f *trackedFile) Close() error {
+	if f.closed {
+		return nil
+	}
+	f.closed = true
+	return f.File.Close()
+}
+
+func (c *Context) trackFile(key string, file multipart.File) multipart.File {
+	tracked := &trackedFile{File: file, key: key}
+	c.openFiles = append(c.openFiles, tracked)
+	return tracked
+}
+
+func (c *Context) finish() {
+	// Recovery marks every request it wraps, whether it was added to the
+	// router, a group or a single route.
+	if !c.recovery && c.fullPath != "" {
+		c.router.warnOnce("recovery:"+c.warnRoute(), "%s is served without Recovery() middleware; a panicking handler will drop the connection without a response", c.warnRoute())
+	}
+
+	for _, file := range c.openFiles {
+		if !file.closed {
+			c.router.warnOnce("file:"+c.warnRoute(), "uploaded file %q in %s %s was not closed by the handler; it is closed after the response, call fileHeader.Close() to release it sooner", file.key, c.Request.Method, c.Request.URL.Path)
+		}
+	}
+}
@@ -0,0 +1,73 @@
+package goify
+
+import (
+	"bytes"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func newWarningRouter() (*Router, *bytes.Buffer) {
+	var logs bytes.Buffer
+	rt := New()
+	rt.SetLogger(slog.New(slog.NewTextHandler(&logs, nil)))
+	return rt, &logs
+}
+
+func TestWarningsGoThroughRouterLogger(t *testing.T) {
+	rt, logs := newWarningRouter()
+	rt.Use(Recovery())
+	rt.GET("/users/:id", func(c *Context) {})
+	rt.GET("/users/:id", func(c *Context) {})
+
+	out := logs.String()
+	if !strings.Contains(out, "level=WARN") || !strings.Contains(out, "conflicts with an existing route") {
+		t.Fatalf("expected duplicate route warning in router log, got %q", out)
+	}
+}
+
+func TestMissingRecoveryWarnsOncePerRoute(t *testing.T) {
+	rt, logs := newWarningRouter()
+	rt.GET("/users/:id", func(c *Context) { c.Status(http.StatusOK) })
+
+	for _, path := range []string{"/users/1", "/users/2"} {
+		rt.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, path, nil))
+	}
+
+	if n := strings.Count(logs.String(), "without Recovery()"); n != 1 {
+		t.Fatalf("expected one Recovery warning, got %d: %q", n, logs.String())
+	}
+	if !strings.Contains(logs.String(), "GET /users/:id") {
+		t.Fatalf("expected the route template in the warning, got %q", logs.String())
+	}
+}
+
+func TestRecoveryOnRouterOrGroupSilencesWarning(t *testing.T) {
+	rt, logs := newWarningRouter()
+	rt.Use(Recovery())
+	rt.GET("/a", func(c *Context) { c.Status(http.StatusOK) })
+	rt.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/a", nil))
+
+	grouped, groupLogs := newWarningRouter()
+	api := grouped.Group("/api")
+	api.Use(Recovery())
+	api.GET("/b", func(c *Context) { c.Status(http.StatusOK) })
+	grouped.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/api/b", nil))
+
+	if strings.Contains(logs.String(), "Recovery()") || strings.Contains(groupLogs.String(), "Recovery()") {
+		t.Fatalf("unexpected Recovery warning: %q %q", logs.String(), groupLogs.String())
+	}
+}
+
+func TestDisableWarnings(t *testing.T) {
+	rt, logs := newWarningRouter()
+	rt.DisableWarnings()
+	rt.GET("/a", func(c *Context) { c.Status(http.StatusOK) })
+	rt.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/a", nil))
+
+	if logs.Len() != 0 {
+		t.Fatalf("expected no warnings, got %q", logs.String())
+	}
+}
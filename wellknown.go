@@ -0,0 +1,71 @@
+package goify
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+)
+
+const (
+	robotsCacheMaxAge  = 24 * time.Hour
+	faviconCacheMaxAge = 7 * 24 * time.Hour
+)
+
+func (rt *Router) Robots(content string) {
+	rt.serveBytes("/robots.txt", []byte(content), "text/plain; charset=utf-8", robotsCacheMaxAge)
+}
+
+func (rt *Router) Favicon(source interface{}) {
+	var data []byte
+	contentType := ""
+
+	switch src := source.(type) {
+	case []byte:
+		data = src
+	case string:
+		content, err := os.ReadFile(src)
+		if err != nil {
+			panic(fmt.Sprintf("goify: failed to read favicon: %v", err))
+		}
+		data = content
+		contentType = LookupMimeType(src)
+	default:
+		panic(fmt.Sprintf("goify: favicon source must be a file path or []byte, got %T", source))
+	}
+
+	if contentType == "" {
+		contentType = http.DetectContentType(data)
+		if bytes.HasPrefix(data, []byte{0, 0, 1, 0}) {
+			contentType = "image/x-icon"
+		}
+	}
+
+	rt.serveBytes("/favicon.ico", data, contentType, faviconCacheMaxAge)
+}
+
+func (rt *Router) WellKnown(name string, handler HandlerFunc) {
+	path := "/.well-known/" + strings.TrimPrefix(name, "/")
+	rt.GET(path, handler)
+	rt.HEAD(path, handler)
+}
+
+func (rt *Router) serveBytes(path string, data []byte, contentType string, maxAge time.Duration) {
+	sum := sha256.Sum256(data)
+	etag := `"` + hex.EncodeToString(sum[:8]) + `"`
+	modTime := time.Now()
+
+	handler := func(c *Context) {
+		c.SetHeader("Content-Type", contentType)
+		c.SetHeader("Cache-Control", fmt.Sprintf("public, max-age=%d", int(maxAge.Seconds())))
+		c.SetHeader("ETag", etag)
+		http.ServeContent(c.Response, c.Request, "", modTime, bytes.NewReader(data))
+	}
+
+	rt.GET(path, handler)
+	rt.HEAD(path, handler)
+}
@@ -0,0 +1,120 @@
+package goify
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"net"
+	"net/http"
+)
+
+type responseWriter struct {
+	http.ResponseWriter
+	ctx         *Context
+	status      int
+	size        int
+	written     bool
+	beforeWrite []func()
+	writeErr    error
+	headerCalls int
+}
+
+func (w *responseWriter) WriteHeader(code int) {
+	w.headerCalls++
+	if w.written {
+		if w.ctx != nil {
+			w.ctx.router.warnOnce("write:"+w.ctx.warnRoute(), "response for %s %s was already sent with status %d; ignoring WriteHeader(%d)", w.ctx.Request.Method, w.ctx.Request.URL.Path, w.status, code)
+		}
+		return
+	}
+
+	w.status = code
+	w.written = true
+	for i := len(w.beforeWrite) - 1; i >= 0; i-- {
+		w.beforeWrite[i]()
+	}
+	w.ResponseWriter.WriteHeader(code)
+}
+
+func (w *responseWriter) Write(b []byte) (int, error) {
+	if !w.written {
+		w.WriteHeader(http.StatusOK)
+	}
+
+	if w.writeErr != nil {
+		return 0, w.writeErr
+	}
+
+	n, err := w.ResponseWriter.Write(b)
+	w.size += n
+	if err != nil && err != http.ErrHandlerTimeout {
+		w.writeErr = err
+		if w.ctx != nil {
+			w.ctx.Logger().Debug("client disconnected", "error", err, "method", w.ctx.Request.Method, "path", w.ctx.routeTemplate())
+		}
+	}
+	return n, err
+}
+
+func (w *responseWriter) Flush() {
+	if !w.written {
+		w.WriteHeader(http.StatusOK)
+	}
+
+	if flusher, ok := w.ResponseWriter.(http.Flusher); ok {
+		flusher.Flush()
+	}
+}
+
+func (w *responseWriter) Hijack() (net.Conn, *bufio.ReadWriter, error) {
+	hijacker, ok := w.ResponseWriter.(http.Hijacker)
+	if !ok {
+		return nil, nil, fmt.Errorf("response writer does not support hijacking")
+	}
+
+	conn, rw, err := hijacker.Hijack()
+	if err != nil {
+		return nil, nil, err
+	}
+	w.written = true
+	return conn, rw, nil
+}
+
+func (w *responseWriter) Unwrap() http.ResponseWriter {
+	return w.ResponseWriter
+}
+
+func (c *Context) onBeforeWrite(fn func()) {
+	if c.writer == nil {
+		return
+	}
+	c.writer.beforeWrite = append(c.writer.beforeWrite, fn)
+}
+
+func (c *Context) StatusCode() int {
+	if c.writer == nil {
+		return 0
+	}
+	if !c.writer.written {
+		return http.StatusOK
+	}
+	return c.writer.status
+}
+
+func (c *Context) BytesWritten() int {
+	if c.writer == nil {
+		return 0
+	}
+	return c.writer.size
+}
+
+func (c *Context) ClientGone() bool {
+	if c.writer != nil && c.writer.writeErr != nil {
+		return true
+	}
+	return c.Request.Context().Err() == context.Canceled
+}
+
+func (c *Context) Written() bool {
+	return c.writer != nil && c.writer.written
+}
@@ -0,0 +1,759 @@
+package goify
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// YAML support covers the subset used by configuration-style payloads:
+// block mappings and sequences, flow collections, quoted/plain scalars and
+// literal/folded block scalars. Values are bridged through encoding/json,
+// so `json` struct tags control field names in both directions.
+
+type yamlItem struct {
+	Key   string
+	Value interface{}
+}
+
+type yamlMapSlice []yamlItem
+
+// yamlNumber is a plain scalar that looks numeric. The source text is kept
+// so that it can still be bound into string fields unchanged.
+type yamlNumber struct {
+	text   string
+	number json.Number
+}
+
+// maxYAMLDepth limits collection nesting so hostile input cannot exhaust
+// the goroutine stack.
+const maxYAMLDepth = 10000
+
+var errYAMLDepth = fmt.Errorf("yaml: exceeded max depth of %d", maxYAMLDepth)
+
+func (c *Context) BindYAML(obj interface{}) error {
+	c.markBodyRead("BindYAML")
+	data, err := io.ReadAll(c.Request.Body)
+	if err != nil {
+		return err
+	}
+	return unmarshalYAML(data, obj)
+}
+
+func (c *Context) YAML(code int, obj interface{}) error {
+	data, err := marshalYAML(obj)
+	if err != nil {
+		return err
+	}
+
+	c.SetHeader("Content-Type", "application/yaml")
+	c.Response.WriteHeader(code)
+	_, err = c.Response.Write(data)
+	return err
+}
+
+func marshalYAML(obj interface{}) ([]byte, error) {
+	data, err := json.Marshal(obj)
+	if err != nil {
+		return nil, err
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	value, err := decodeOrderedJSON(decoder)
+	if err != nil {
+		return nil, err
+	}
+
+	var buf bytes.Buffer
+	writeYAMLValue(&buf, value, 0, false)
+	return buf.Bytes(), nil
+}
+
+func unmarshalYAML(data []byte, obj interface{}) error {
+	value, err := parseYAML(data)
+	if err != nil {
+		return err
+	}
+
+	intermediate, err := json.Marshal(resolveYAMLNumbers(value, reflect.TypeOf(obj)))
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(intermediate, obj)
+}
+
+func decodeOrderedJSON(decoder *json.Decoder) (interface{}, error) {
+	token, err := decoder.Token()
+	if err != nil {
+		return nil, err
+	}
+
+	switch t := token.(type) {
+	case json.Delim:
+		switch t {
+		case '{':
+			var m yamlMapSlice
+			for decoder.More() {
+				keyToken, err := decoder.Token()
+				if err != nil {
+					return nil, err
+				}
+				value, err := decodeOrderedJSON(decoder)
+				if err != nil {
+					return nil, err
+				}
+				m = append(m, yamlItem{Key: keyToken.(string), Value: value})
+			}
+			if _, err := decoder.Token(); err != nil {
+				return nil, err
+			}
+			if m == nil {
+				m = yamlMapSlice{}
+			}
+			return m, nil
+		case '[':
+			list := []interface{}{}
+			for decoder.More() {
+				value, err := decodeOrderedJSON(decoder)
+				if err != nil {
+					return nil, err
+				}
+				list = append(list, value)
+			}
+			if _, err := decoder.Token(); err != nil {
+				return nil, err
+			}
+			return list, nil
+		}
+		return nil, fmt.Errorf("unexpected delimiter %v", t)
+	default:
+		return t, nil
+	}
+}
+
+func writeYAMLValue(b *bytes.Buffer, value interface{}, indent int, inline bool) {
+	switch v := value.(type) {
+	case yamlMapSlice:
+		if len(v) == 0 {
+			b.WriteString("{}\n")
+			return
+		}
+		for i, item := range v {
+			if i > 0 || !inline {
+				b.WriteString(strings.Repeat(" ", indent))
+			}
+			b.WriteString(yamlString(item.Key))
+			b.WriteString(":")
+			writeYAMLChild(b, item.Value, indent)
+		}
+	case []interface{}:
+		if len(v) == 0 {
+			b.WriteString("[]\n")
+			return
+		}
+		for i, item := range v {
+			if i > 0 || !inline {
+				b.WriteString(strings.Repeat(" ", indent))
+			}
+			b.WriteString("- ")
+			if isYAMLCollection(item) {
+				writeYAMLValue(b, item, indent+2, true)
+			} else {
+				b.WriteString(yamlScalar(item))
+				b.WriteString("\n")
+			}
+		}
+	default:
+		b.WriteString(yamlScalar(v))
+		b.WriteString("\n")
+	}
+}
+
+func writeYAMLChild(b *bytes.Buffer, value interface{}, indent int) {
+	if isYAMLCollection(value) {
+		b.WriteString("\n")
+		writeYAMLValue(b, value, indent+2, false)
+		return
+	}
+
+	b.WriteString(" ")
+	writeYAMLValue(b, value, indent, true)
+}
+
+func isYAMLCollection(value interface{}) bool {
+	switch v := value.(type) {
+	case yamlMapSlice:
+		return len(v) > 0
+	case []interface{}:
+		return len(v) > 0
+	}
+	return false
+}
+
+var (
+	yamlPlainRegex  = regexp.MustCompile(`^[A-Za-z0-9_./@(][A-Za-z0-9_ ./@()+=-]*$`)
+	yamlNumberRegex = regexp.MustCompile(`^[-+]?(\d+\.?\d*|\.\d+)([eE][-+]?\d+)?$`)
+)
+
+func yamlScalar(value interface{}) string {
+	switch v := value.(type) {
+	case nil:
+		return "null"
+	case bool:
+		return strconv.FormatBool(v)
+	case json.Number:
+		return v.String()
+	case string:
+		return yamlString(v)
+	default:
+		return fmt.Sprintf("%v", v)
+	}
+}
+
+func yamlString(s string) string {
+	switch strings.ToLower(s) {
+	case "yes", "no", "on", "off", "y", "n":
+		quoted, _ := json.Marshal(s)
+		return string(quoted)
+	}
+
+	if yamlPlainRegex.MatchString(s) && !strings.HasSuffix(s, " ") {
+		if _, isString := resolveYAMLScalar(s).(string); isString {
+			return s
+		}
+	}
+
+	quoted, _ := json.Marshal(s)
+	return string(quoted)
+}
+
+type yamlLine struct {
+	indent int
+	text   string
+	raw    string
+	num    int
+}
+
+type yamlParser struct {
+	lines []yamlLine
+	pos   int
+	depth int
+}
+
+func parseYAML(data []byte) (interface{}, error) {
+	p := &yamlParser{}
+	for i, raw := range strings.Split(strings.ReplaceAll(string(data), "\r\n", "\n"), "\n") {
+		if strings.Contains(raw, "\t") && strings.TrimLeft(raw, " ") != strings.TrimLeft(raw, " \t") {
+			return nil, fmt.Errorf("yaml: line %d: tabs are not allowed for indentation", i+1)
+		}
+
+		text := stripYAMLComment(raw)
+		trimmed := strings.TrimSpace(text)
+		if trimmed == "---" || trimmed == "..." {
+			if len(p.lines) > 0 && trimmed == "---" {
+				return nil, fmt.Errorf("yaml: line %d: multiple documents are not supported", i+1)
+			}
+			continue
+		}
+
+		p.lines = append(p.lines, yamlLine{
+			indent: len(text) - len(strings.TrimLeft(text, " ")),
+			text:   trimmed,
+			raw:    raw,
+			num:    i + 1,
+		})
+	}
+
+	p.skipBlank()
+	if p.pos >= len(p.lines) {
+		return nil, nil
+	}
+
+	value, err := p.parseNode(p.lines[p.pos].indent)
+	if err != nil {
+		return nil, err
+	}
+
+	p.skipBlank()
+	if p.pos < len(p.lines) {
+		return nil, fmt.Errorf("yaml: line %d: unexpected content", p.lines[p.pos].num)
+	}
+	return value, nil
+}
+
+func (p *yamlParser) skipBlank() {
+	for p.pos < len(p.lines) && p.lines[p.pos].text == "" {
+		p.pos++
+	}
+}
+
+func (p *yamlParser) parseNode(indent int) (interface{}, error) {
+	p.skipBlank()
+	if p.pos >= len(p.lines) {
+		return nil, nil
+	}
+
+	p.depth++
+	defer func() { p.depth-- }()
+	if p.depth > maxYAMLDepth {
+		return nil, errYAMLDepth
+	}
+
+	line := p.lines[p.pos]
+	if isYAMLSequenceItem(line.text) {
+		return p.parseSequence(indent)
+	}
+	if _, _, ok := splitYAMLKey(line.text); ok {
+		return p.parseMapping(indent)
+	}
+
+	p.pos++
+	return parseYAMLInline(line.text)
+}
+
+func isYAMLSequenceItem(text string) bool {
+	return text == "-" || strings.HasPrefix(text, "- ")
+}
+
+func (p *yamlParser) parseSequence(indent int) (interface{}, error) {
+	list := []interface{}{}
+
+	for {
+		p.skipBlank()
+		if p.pos >= len(p.lines) {
+			break
+		}
+
+		line := p.lines[p.pos]
+		if line.indent != indent || !isYAMLSequenceItem(line.text) {
+			if line.indent > indent {
+				return nil, fmt.Errorf("yaml: line %d: bad indentation", line.num)
+			}
+			break
+		}
+
+		rest := strings.TrimSpace(strings.TrimPrefix(line.text, "-"))
+		if rest == "" {
+			p.pos++
+			p.skipBlank()
+			if p.pos < len(p.lines) && p.lines[p.pos].indent > indent {
+				item, err := p.parseNode(p.lines[p.pos].indent)
+				if err != nil {
+					return nil, err
+				}
+				list = append(list, item)
+			} else {
+				list = append(list, nil)
+			}
+			continue
+		}
+
+		itemIndent := indent + (len(line.text) - len(rest))
+		p.lines[p.pos] = yamlLine{indent: itemIndent, text: rest, raw: line.raw, num: line.num}
+		item, err := p.parseNode(itemIndent)
+		if err != nil {
+			return nil, err
+		}
+		list = append(list, item)
+	}
+
+	return list, nil
+}
+
+func (p *yamlParser) parseMapping(indent int) (interface{}, error) {
+	m := make(map[string]interface{})
+
+	for {
+		p.skipBlank()
+		if p.pos >= len(p.lines) {
+			break
+		}
+
+		line := p.lines[p.pos]
+		if line.indent != indent {
+			if line.indent > indent {
+				return nil, fmt.Errorf("yaml: line %d: bad indentation", line.num)
+			}
+			break
+		}
+
+		key, rest, ok := splitYAMLKey(line.text)
+		if !ok {
+			return nil, fmt.Errorf("yaml: line %d: expected a mapping key", line.num)
+		}
+		p.pos++
+
+		var value interface{}
+		var err error
+		switch {
+		case rest == "":
+			p.skipBlank()
+			if p.pos < len(p.lines) {
+				next := p.lines[p.pos]
+				if next.indent > indent {
+					value, err = p.parseNode(next.indent)
+				} else if next.indent == indent && isYAMLSequenceItem(next.text) {
+					value, err = p.parseSequence(indent)
+				}
+			}
+		case strings.HasPrefix(rest, "|") || strings.HasPrefix(rest, ">"):
+			value = p.parseBlockScalar(indent, rest)
+		default:
+			value, err = parseYAMLInline(rest)
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		m[key] = value
+	}
+
+	return m, nil
+}
+
+func (p *yamlParser) parseBlockScalar(indent int, header string) string {
+	folded := strings.HasPrefix(header, ">")
+	chomp := strings.TrimLeft(header, "|>")
+
+	var lines []string
+	blockIndent := -1
+	for p.pos < len(p.lines) {
+		raw := p.lines[p.pos].raw
+		content := strings.TrimLeft(raw, " ")
+		rawIndent := len(raw) - len(content)
+		if content != "" && rawIndent <= indent {
+			break
+		}
+		if content != "" && blockIndent < 0 {
+			blockIndent = rawIndent
+		}
+		if content == "" {
+			lines = append(lines, "")
+		} else {
+			lines = append(lines, raw[blockIndent:])
+		}
+		p.pos++
+	}
+
+	trailing := 0
+	for len(lines) > 0 && lines[len(lines)-1] == "" {
+		lines = lines[:len(lines)-1]
+		trailing++
+	}
+
+	var text string
+	if folded {
+		var b strings.Builder
+		for i, line := range lines {
+			if i > 0 {
+				if line == "" || lines[i-1] == "" {
+					b.WriteString("\n")
+				} else {
+					b.WriteString(" ")
+				}
+			}
+			b.WriteString(line)
+		}
+		text = b.String()
+	} else {
+		text = strings.Join(lines, "\n")
+	}
+
+	switch chomp {
+	case "-":
+		return text
+	case "+":
+		return text + "\n" + strings.Repeat("\n", trailing)
+	default:
+		if text == "" {
+			return ""
+		}
+		return text + "\n"
+	}
+}
+
+func stripYAMLComment(line string) string {
+	inSingle, inDouble := false, false
+	for i := 0; i < len(line); i++ {
+		switch ch := line[i]; {
+		case ch == '\'' && !inDouble:
+			inSingle = !inSingle
+		case ch == '"' && !inSingle && (i == 0 || line[i-1] != '\\'):
+			inDouble = !inDouble
+		case ch == '#' && !inSingle && !inDouble && (i == 0 || line[i-1] == ' '):
+			return strings.TrimRight(line[:i], " ")
+		}
+	}
+	return strings.TrimRight(line, " ")
+}
+
+func splitYAMLKey(text string) (string, string, bool) {
+	if text == "" || text[0] == '[' || text[0] == '{' || isYAMLSequenceItem(text) {
+		return "", "", false
+	}
+
+	if text[0] == '"' || text[0] == '\'' {
+		end := findYAMLQuoteEnd(text)
+		if end < 0 || end+1 >= len(text) || text[end+1] != ':' {
+			return "", "", false
+		}
+		key, err := unquoteYAML(text[:end+1])
+		if err != nil {
+			return "", "", false
+		}
+		rest := text[end+2:]
+		if rest != "" && rest[0] != ' ' {
+			return "", "", false
+		}
+		return key, strings.TrimSpace(rest), true
+	}
+
+	for i := 0; i < len(text); i++ {
+		if text[i] == ':' && (i+1 == len(text) || text[i+1] == ' ') {
+			return strings.TrimSpace(text[:i]), strings.TrimSpace(text[i+1:]), true
+		}
+	}
+	return "", "", false
+}
+
+func findYAMLQuoteEnd(text string) int {
+	quote := text[0]
+	for i := 1; i < len(text); i++ {
+		switch {
+		case quote == '"' && text[i] == '\\':
+			i++
+		case quote == '\'' && text[i] == '\'' && i+1 < len(text) && text[i+1] == '\'':
+			i++
+		case text[i] == quote:
+			return i
+		}
+	}
+	return -1
+}
+
+func unquoteYAML(s string) (string, error) {
+	if s[0] == '\'' {
+		return strings.ReplaceAll(s[1:len(s)-1], "''", "'"), nil
+	}
+	return strconv.Unquote(s)
+}
+
+func parseYAMLInline(text string) (interface{}, error) {
+	if text == "" {
+		return nil, nil
+	}
+
+	if text[0] == '[' || text[0] == '{' {
+		value, rest, err := parseYAMLFlow(text, 0)
+		if err != nil {
+			return nil, err
+		}
+		if strings.TrimSpace(rest) != "" {
+			return nil, fmt.Errorf("yaml: unexpected trailing content %q", rest)
+		}
+		return value, nil
+	}
+
+	if text[0] == '"' || text[0] == '\'' {
+		end := findYAMLQuoteEnd(text)
+		if end != len(text)-1 {
+			return nil, fmt.Errorf("yaml: invalid quoted scalar %s", text)
+		}
+		return unquoteYAML(text)
+	}
+
+	return resolveYAMLScalar(text), nil
+}
+
+func parseYAMLFlow(text string, depth int) (interface{}, string, error) {
+	text = strings.TrimLeft(text, " ")
+	if text == "" {
+		return nil, "", fmt.Errorf("yaml: unexpected end of flow collection")
+	}
+	if depth >= maxYAMLDepth {
+		return nil, "", errYAMLDepth
+	}
+
+	switch text[0] {
+	case '[':
+		list := []interface{}{}
+		rest := strings.TrimLeft(text[1:], " ")
+		for {
+			if strings.HasPrefix(rest, "]") {
+				return list, rest[1:], nil
+			}
+			value, remaining, err := parseYAMLFlow(rest, depth+1)
+			if err != nil {
+				return nil, "", err
+			}
+			list = append(list, value)
+			rest = strings.TrimLeft(remaining, " ")
+			if strings.HasPrefix(rest, ",") {
+				rest = strings.TrimLeft(rest[1:], " ")
+			} else if !strings.HasPrefix(rest, "]") {
+				return nil, "", fmt.Errorf("yaml: expected ',' or ']' in flow sequence")
+			}
+		}
+	case '{':
+		m := make(map[string]interface{})
+		rest := strings.TrimLeft(text[1:], " ")
+		for {
+			if strings.HasPrefix(rest, "}") {
+				return m, rest[1:], nil
+			}
+			keyValue, remaining, err := parseYAMLFlowScalar(rest, ":,}")
+			if err != nil {
+				return nil, "", err
+			}
+			rest = strings.TrimLeft(remaining, " ")
+			if !strings.HasPrefix(rest, ":") {
+				return nil, "", fmt.Errorf("yaml: expected ':' in flow mapping")
+			}
+			value, remaining, err := parseYAMLFlow(rest[1:], depth+1)
+			if err != nil {
+				return nil, "", err
+			}
+			m[fmt.Sprintf("%v", keyValue)] = value
+			rest = strings.TrimLeft(remaining, " ")
+			if strings.HasPrefix(rest, ",") {
+				rest = strings.TrimLeft(rest[1:], " ")
+			} else if !strings.HasPrefix(rest, "}") {
+				return nil, "", fmt.Errorf("yaml: expected ',' or '}' in flow mapping")
+			}
+		}
+	default:
+		return parseYAMLFlowScalar(text, ",]}")
+	}
+}
+
+func parseYAMLFlowScalar(text, terminators string) (interface{}, string, error) {
+	if text != "" && (text[0] == '"' || text[0] == '\'') {
+		end := findYAMLQuoteEnd(text)
+		if end < 0 {
+			return nil, "", fmt.Errorf("yaml: unterminated quoted scalar")
+		}
+		value, err := unquoteYAML(text[:end+1])
+		return value, text[end+1:], err
+	}
+
+	end := strings.IndexAny(text, terminators)
+	if end < 0 {
+		end = len(text)
+	}
+	return resolveYAMLScalar(strings.TrimSpace(text[:end])), text[end:], nil
+}
+
+func resolveYAMLScalar(s string) interface{} {
+	switch s {
+	case "", "~", "null", "Null", "NULL":
+		return nil
+	case "true", "True", "TRUE":
+		return true
+	case "false", "False", "FALSE":
+		return false
+	case ".inf", ".Inf", ".INF", "-.inf", "-.Inf", "-.INF", ".nan", ".NaN", ".NAN":
+		return s
+	}
+
+	if strings.HasPrefix(s, "0x") || strings.HasPrefix(s, "0o") {
+		if n, err := strconv.ParseInt(s, 0, 64); err == nil {
+			return yamlNumber{text: s, number: json.Number(strconv.FormatInt(n, 10))}
+		}
+	}
+
+	if !yamlNumberRegex.MatchString(s) {
+		return s
+	}
+	if n, err := strconv.ParseInt(s, 10, 64); err == nil {
+		return yamlNumber{text: s, number: json.Number(strconv.FormatInt(n, 10))}
+	}
+	if f, err := strconv.ParseFloat(s, 64); err == nil {
+		return yamlNumber{text: s, number: json.Number(strconv.FormatFloat(f, 'g', -1, 64))}
+	}
+
+	return s
+}
+
+var jsonUnmarshalerType = reflect.TypeOf((*json.Unmarshaler)(nil)).Elem()
+
+// resolveYAMLNumbers replaces numeric scalars with their source text where
+// the target is a string and with json.Number everywhere else. t is the type
+// the value will be decoded into, or nil when it is unknown.
+func resolveYAMLNumbers(value interface{}, t reflect.Type) interface{} {
+	for t != nil && t.Kind() == reflect.Ptr {
+		if t.Implements(jsonUnmarshalerType) {
+			t = nil
+			break
+		}
+		t = t.Elem()
+	}
+	if t != nil && reflect.PointerTo(t).Implements(jsonUnmarshalerType) {
+		t = nil
+	}
+
+	switch v := value.(type) {
+	case yamlNumber:
+		if t != nil && t.Kind() == reflect.String {
+			return v.text
+		}
+		return v.number
+	case []interface{}:
+		var elem reflect.Type
+		if t != nil && (t.Kind() == reflect.Slice || t.Kind() == reflect.Array) {
+			elem = t.Elem()
+		}
+		for i, item := range v {
+			v[i] = resolveYAMLNumbers(item, elem)
+		}
+		return v
+	case map[string]interface{}:
+		for key, item := range v {
+			var elem reflect.Type
+			if t != nil {
+				switch t.Kind() {
+				case reflect.Map:
+					elem = t.Elem()
+				case reflect.Struct:
+					elem = yamlFieldType(t, key)
+				}
+			}
+			v[key] = resolveYAMLNumbers(item, elem)
+		}
+		return v
+	}
+	return value
+}
+
+// yamlFieldType finds the struct field encoding/json would decode key into,
+// preferring an exact name match over a case-insensitive one.
+func yamlFieldType(t reflect.Type, key string) reflect.Type {
+	var fold reflect.Type
+	for _, sf := range reflect.VisibleFields(t) {
+		if !sf.IsExported() || (sf.Anonymous && sf.Type.Kind() == reflect.Struct) {
+			continue
+		}
+
+		tag := sf.Tag.Get("json")
+		if tag == "-" {
+			continue
+		}
+		name := sf.Name
+		if parts := strings.Split(tag, ","); parts[0] != "" {
+			name = parts[0]
+		}
+
+		if name == key {
+			return sf.Type
+		}
+		if fold == nil && strings.EqualFold(name, key) {
+			fold = sf.Type
+		}
+	}
+	return fold
+}
@@ -0,0 +1,124 @@
+package goify
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestYAMLUnmarshalStruct(t *testing.T) {
+	type config struct {
+		Name    string            `json:"name"`
+		Port    int               `json:"port"`
+		Debug   bool              `json:"debug"`
+		Version string            `json:"version"`
+		Build   string            `json:"build"`
+		Hosts   []string          `json:"hosts"`
+		Ratio   float64           `json:"ratio"`
+		Labels  map[string]string `json:"labels"`
+		Limits  map[string]int    `json:"limits"`
+	}
+
+	input := `
+name: api
+port: 8080
+debug: true
+version: 1.10
+build: 0x1F
+hosts: [a.example.com, 10]
+ratio: 0.5
+labels:
+  zone: 01
+limits: {rps: 100}
+`
+	var cfg config
+	if err := unmarshalYAML([]byte(input), &cfg); err != nil {
+		t.Fatalf("unmarshal: %v", err)
+	}
+
+	if cfg.Name != "api" || cfg.Port != 8080 || !cfg.Debug || cfg.Ratio != 0.5 {
+		t.Fatalf("unexpected scalars: %+v", cfg)
+	}
+	if cfg.Version != "1.10" {
+		t.Fatalf("expected version %q, got %q", "1.10", cfg.Version)
+	}
+	if cfg.Build != "0x1F" {
+		t.Fatalf("expected build %q, got %q", "0x1F", cfg.Build)
+	}
+	if len(cfg.Hosts) != 2 || cfg.Hosts[1] != "10" {
+		t.Fatalf("unexpected hosts: %v", cfg.Hosts)
+	}
+	if cfg.Labels["zone"] != "01" || cfg.Limits["rps"] != 100 {
+		t.Fatalf("unexpected maps: %v %v", cfg.Labels, cfg.Limits)
+	}
+}
+
+func TestYAMLUnmarshalNumbersIntoInterface(t *testing.T) {
+	var out map[string]interface{}
+	if err := unmarshalYAML([]byte("count: 3\nname: x\n"), &out); err != nil {
+		t.Fatalf("unmarshal: %v", err)
+	}
+	if out["count"] != float64(3) || out["name"] != "x" {
+		t.Fatalf("unexpected values: %v", out)
+	}
+}
+
+func TestYAMLRoundTrip(t *testing.T) {
+	type doc struct {
+		Version string   `json:"version"`
+		Items   []string `json:"items"`
+	}
+
+	data, err := marshalYAML(doc{Version: "1.10", Items: []string{"yes", "plain"}})
+	if err != nil {
+		t.Fatalf("marshal: %v", err)
+	}
+
+	var out doc
+	if err := unmarshalYAML(data, &out); err != nil {
+		t.Fatalf("unmarshal %q: %v", data, err)
+	}
+	if out.Version != "1.10" || len(out.Items) != 2 || out.Items[0] != "yes" {
+		t.Fatalf("round trip mismatch: %+v", out)
+	}
+}
+
+func TestYAMLRejectsDeepFlowNesting(t *testing.T) {
+	var out interface{}
+	err := unmarshalYAML([]byte(strings.Repeat("[", maxYAMLDepth+1)), &out)
+	if err == nil || !strings.Contains(err.Error(), "max depth") {
+		t.Fatalf("expected depth error, got %v", err)
+	}
+}
+
+func TestYAMLRejectsDeepBlockNesting(t *testing.T) {
+	var out interface{}
+	err := unmarshalYAML([]byte(strings.Repeat("- ", maxYAMLDepth+1)+"x"), &out)
+	if err == nil || !strings.Contains(err.Error(), "max depth") {
+		t.Fatalf("expected depth error, got %v", err)
+	}
+}
+
+func TestYAMLDeepBodyDoesNotCrashBinding(t *testing.T) {
+	rt := New()
+	rt.Use(Recovery())
+	rt.POST("/config", func(c *Context) {
+		var body map[string]interface{}
+		if err := c.ShouldBind(&body); err != nil {
+			c.Status(http.StatusBadRequest)
+			return
+		}
+		c.Status(http.StatusOK)
+	})
+
+	req := httptest.NewRequest(http.MethodPost, "/config", bytes.NewReader(bytes.Repeat([]byte("["), 4<<20)))
+	req.Header.Set("Content-Type", MIMEYAML)
+	w := httptest.NewRecorder()
+	rt.ServeHTTP(w, req)
+
+	if w.Code != http.StatusBadRequest {
+		t.Fatalf("expected 400, got %d", w.Code)
+	}
+}